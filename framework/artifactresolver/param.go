@@ -16,6 +16,7 @@ package artifactresolver
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/palantir/godel/v2/pkg/osarch"
 )
@@ -43,3 +44,13 @@ func (l Locator) String() string {
 func (l Locator) GroupAndProductString() string {
 	return fmt.Sprintf("%s:%s", l.Group, l.Product)
 }
+
+// LocatorIDWithoutVersion returns the "group:product" portion of the provided locator ID, which is normally of the form
+// "group:product:version". Returns the provided ID unmodified if it does not contain a ":".
+func LocatorIDWithoutVersion(id string) string {
+	parts := strings.Split(id, ":")
+	if len(parts) < 2 {
+		return id
+	}
+	return parts[0] + ":" + parts[1]
+}
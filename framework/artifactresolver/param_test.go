@@ -0,0 +1,36 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactresolver_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/artifactresolver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocatorIDWithoutVersion(t *testing.T) {
+	for i, tc := range []struct {
+		name string
+		id   string
+		want string
+	}{
+		{"locator with version", "com.palantir.godel-format-plugin:format-plugin:1.0.0", "com.palantir.godel-format-plugin:format-plugin"},
+		{"locator without version", "com.palantir.godel-format-plugin:format-plugin", "com.palantir.godel-format-plugin:format-plugin"},
+		{"no separator", "format-plugin", "format-plugin"},
+	} {
+		assert.Equal(t, tc.want, artifactresolver.LocatorIDWithoutVersion(tc.id), "Case %d: %s", i, tc.name)
+	}
+}
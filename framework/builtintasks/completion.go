@@ -0,0 +1,60 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"path/filepath"
+
+	"github.com/palantir/godel/v2/framework/godel"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func CompletionTask() godellauncher.Task {
+	var globalCfg godellauncher.GlobalConfig
+	return godellauncher.CobraCLITask(&cobra.Command{
+		Use:   "completion [bash|zsh|fish]",
+		Short: "Print the shell completion script for bash, zsh or fish",
+		Long: `Prints the script that completes tasks, their flags and their arguments for the specified shell. The script
+completes the wrapper script (for example, "./godelw"). For example, run the following to enable completion in the
+current bash shell:
+
+  source <(./godelw completion bash)`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the completion scripts invoke the "__complete" command of the program that is being completed, which is
+			// handled by the launcher using the flags and subcommands of the tasks
+			name := godel.AppName
+			if globalCfg.Wrapper != "" {
+				name = filepath.Base(globalCfg.Wrapper)
+			}
+			rootCmd := &cobra.Command{
+				Use: name,
+			}
+			switch args[0] {
+			case "bash":
+				return rootCmd.GenBashCompletionV2(cmd.OutOrStdout(), true)
+			case "zsh":
+				return rootCmd.GenZshCompletion(cmd.OutOrStdout())
+			case "fish":
+				return rootCmd.GenFishCompletion(cmd.OutOrStdout(), true)
+			default:
+				return errors.Errorf("unsupported shell %q: must be one of bash, zsh or fish", args[0])
+			}
+		},
+	}, &globalCfg)
+}
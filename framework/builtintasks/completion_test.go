@@ -0,0 +1,79 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionTask(t *testing.T) {
+	for i, tc := range []struct {
+		shell string
+		want  []string
+	}{
+		{"bash", []string{"__start_godelw", "__complete"}},
+		{"zsh", []string{"#compdef godelw", "__complete"}},
+		{"fish", []string{"complete -c godelw", "__complete"}},
+	} {
+		task := builtintasks.CompletionTask()
+		buf := &bytes.Buffer{}
+		err := task.Run(godellauncher.GlobalConfig{
+			Executable: "godel",
+			Wrapper:    "/project/godelw",
+			Task:       task.Name,
+			TaskArgs:   []string{tc.shell},
+		}, buf)
+		require.NoError(t, err, "Case %d: %s", i, tc.shell)
+		for _, want := range tc.want {
+			assert.Contains(t, buf.String(), want, "Case %d: %s", i, tc.shell)
+		}
+	}
+}
+
+func TestVerifyTaskCompletion(t *testing.T) {
+	format := recordingTask("format", nil)
+	format.Verify = &godellauncher.VerifyOptions{
+		VerifyTaskFlags: []godellauncher.VerifyFlag{
+			{Name: "skip-tests", Description: "skip formatting test files", Type: godellauncher.BoolFlag},
+		},
+	}
+	tasks := []godellauncher.Task{
+		format,
+		builtintasks.VerifyTask([]godellauncher.Task{format}, config.VerifyTasksConfig{}),
+	}
+
+	global := godellauncher.GlobalConfig{
+		Task:     cobra.ShellCompRequestCmd,
+		TaskArgs: []string{"verify", "--skip"},
+	}
+	task, err := godellauncher.TaskForInput(global, tasks)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, task.Run(global, buf))
+	assert.Equal(t, []string{
+		"--skip-format\tskip 'format' task",
+		"--skip-tests\tskip formatting test files",
+		":4",
+	}, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"))
+}
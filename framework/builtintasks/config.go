@@ -0,0 +1,141 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/palantir/godel/v2/framework/builtintasks/configschemas"
+	"github.com/palantir/godel/v2/framework/builtintasks/configvalidate"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ConfigTask returns the "config" task. The schemas for the configuration files are taken from the provided tasks.
+func ConfigTask(tasks []godellauncher.Task) godellauncher.Task {
+	// the tasks are used when the task is run, so copy them to ensure that later modifications to the backing array
+	// of the provided slice are not visible
+	tasks = slices.Clone(tasks)
+	var globalCfg godellauncher.GlobalConfig
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with gödel and plugin configuration files",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Validate godel.yml and plugin configuration files against their schemas",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir, err := globalCfg.ProjectDir()
+			if err != nil {
+				return err
+			}
+			cfgDir, err := godellauncher.ConfigDirPath(projectDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := configFileSchemas(tasks)
+			if err != nil {
+				return err
+			}
+			return configvalidate.Validate(projectDir, cfgDir, schemas, cmd.OutOrStdout())
+		},
+	})
+
+	var outputDirFlagVal string
+	schemasCmd := &cobra.Command{
+		Use:   "schemas",
+		Short: "Write the JSON Schemas for godel.yml and plugin configuration files to a directory",
+		Long: `Writes the JSON Schema for godel.yml and the JSON Schemas published by plugins for their configuration files to
+a directory. Each schema is written to a file named "<config file name>.schema.json". The schemas can be used by
+editors to provide completion and documentation for configuration files.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir, err := globalCfg.ProjectDir()
+			if err != nil {
+				return err
+			}
+			outputDir := outputDirFlagVal
+			if outputDir == "" {
+				cfgDir, err := godellauncher.ConfigDirPath(projectDir)
+				if err != nil {
+					return err
+				}
+				outputDir = filepath.Join(cfgDir, configschemas.DefaultOutputDirName)
+			}
+			schemas, err := configFileSchemas(tasks)
+			if err != nil {
+				return err
+			}
+			paths, err := configschemas.Write(outputDir, schemas)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				cmd.Println(path)
+			}
+			return nil
+		},
+	}
+	schemasCmd.Flags().StringVar(&outputDirFlagVal, "output-dir", "", `directory to write schemas to (default is the "schemas" directory in the gödel configuration directory)`)
+	cmd.AddCommand(schemasCmd)
+
+	return godellauncher.CobraCLITask(cmd, &globalCfg)
+}
+
+// configFileSchemas returns the schema for "godel.yml" followed by the schemas for the configuration files of the
+// provided tasks sorted by configuration file name. Tasks provided by the same plugin share a configuration file, so
+// each configuration file is only included once.
+func configFileSchemas(tasks []godellauncher.Task) ([]configvalidate.ConfigFileSchema, error) {
+	godelSchemaObj, err := config.GodelConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+	godelSchema, err := json.Marshal(godelSchemaObj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal schema for %s", godellauncher.GodelConfigYML)
+	}
+	schemas := []configvalidate.ConfigFileSchema{
+		{
+			ConfigFile: godellauncher.GodelConfigYML,
+			Schema:     godelSchema,
+		},
+	}
+
+	taskSchemas := make(map[string][]byte)
+	for _, task := range tasks {
+		if task.ConfigFile == "" || task.ConfigSchema == nil {
+			continue
+		}
+		taskSchemas[task.ConfigFile] = task.ConfigSchema
+	}
+	var cfgFiles []string
+	for cfgFile := range taskSchemas {
+		cfgFiles = append(cfgFiles, cfgFile)
+	}
+	sort.Strings(cfgFiles)
+	for _, cfgFile := range cfgFiles {
+		schemas = append(schemas, configvalidate.ConfigFileSchema{
+			ConfigFile: cfgFile,
+			Schema:     taskSchemas[cfgFile],
+		})
+	}
+	return schemas, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"os"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/plugins"
+	"github.com/spf13/cobra"
+)
+
+// ConfigProvidersTask returns the "config-providers" task, which manages the tasks configuration providers specified in
+// godel.yml.
+func ConfigProvidersTask() godellauncher.Task {
+	var globalCfg godellauncher.GlobalConfig
+
+	configProvidersCmd := &cobra.Command{
+		Use:   "config-providers",
+		Short: "Manage the tasks configuration providers",
+	}
+	refreshSubcommand := &cobra.Command{
+		Use:   "refresh",
+		Short: "Resolve all of the tasks configuration providers again, ignoring their refresh policy",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir, err := globalCfg.ProjectDir()
+			if err != nil {
+				return err
+			}
+			godelCfg, err := config.ReadGodelConfigFromProjectDir(projectDir)
+			if err != nil {
+				return err
+			}
+			providersCfg := config.TasksConfigProvidersConfig(godelCfg.TasksConfigProviders)
+			providersParam, err := providersCfg.ToParam()
+			if err != nil {
+				return err
+			}
+			return plugins.RefreshConfigProviders(projectDir, providersParam, cmd.OutOrStdout(), os.Stderr)
+		},
+	}
+	configProvidersCmd.AddCommand(refreshSubcommand)
+	return godellauncher.CobraCLITask(configProvidersCmd, &globalCfg)
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/builtintasks/configvalidate"
+	"github.com/pkg/errors"
+)
+
+// DefaultOutputDirName is the name of the directory within the configuration directory that schemas are written to by
+// default.
+const DefaultOutputDirName = "schemas"
+
+// SchemaFileName returns the name of the schema file for the provided configuration file name. For example, the schema
+// file name for "godel.yml" is "godel.schema.json".
+func SchemaFileName(cfgFile string) string {
+	return strings.TrimSuffix(cfgFile, filepath.Ext(cfgFile)) + ".schema.json"
+}
+
+// Write writes the provided schemas to the provided output directory (creating it if it does not exist) and returns
+// the paths of the written files. The name of each file is determined by SchemaFileName.
+func Write(outputDir string, schemas []configvalidate.ConfigFileSchema) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory %s", outputDir)
+	}
+	var paths []string
+	for _, schema := range schemas {
+		buf := &bytes.Buffer{}
+		if err := json.Indent(buf, schema.Schema, "", "  "); err != nil {
+			return nil, errors.Wrapf(err, "invalid schema for %s", schema.ConfigFile)
+		}
+		buf.WriteString("\n")
+
+		schemaPath := filepath.Join(outputDir, SchemaFileName(schema.ConfigFile))
+		if err := os.WriteFile(schemaPath, buf.Bytes(), 0644); err != nil {
+			return nil, errors.Wrapf(err, "failed to write schema to %s", schemaPath)
+		}
+		paths = append(paths, schemaPath)
+	}
+	return paths, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschemas_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks/configschemas"
+	"github.com/palantir/godel/v2/framework/builtintasks/configvalidate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	outputDir := filepath.Join(tmpDir, "schemas")
+	paths, err := configschemas.Write(outputDir, []configvalidate.ConfigFileSchema{
+		{
+			ConfigFile: "godel.yml",
+			Schema:     []byte(`{"type":"object"}`),
+		},
+		{
+			ConfigFile: "dist-plugin.yml",
+			Schema:     []byte(`{"type":"object","properties":{"products":{"type":"object"}}}`),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(outputDir, "godel.schema.json"),
+		filepath.Join(outputDir, "dist-plugin.schema.json"),
+	}, paths)
+
+	content, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "type": "object",
+  "properties": {
+    "products": {
+      "type": "object"
+    }
+  }
+}
+`, string(content))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/palantir/godel/v2/pkg/configschema"
+	"github.com/pkg/errors"
+)
+
+// ConfigFileSchema is the JSON Schema for a configuration file.
+type ConfigFileSchema struct {
+	// ConfigFile is the name of the configuration file in the configuration directory ("godel.yml").
+	ConfigFile string
+	// Schema is the JSON-encoded schema for the configuration file.
+	Schema []byte
+}
+
+// Validate validates the configuration files in the provided configuration directory against the provided schemas.
+// Configuration files that do not exist are skipped. Every error is printed to stdout on its own line in the form
+// "<path>:<line>:<column>: <message>", where the path is relative to projectDir. Returns an error if any of the
+// configuration files is not valid.
+func Validate(projectDir, cfgDir string, schemas []ConfigFileSchema, stdout io.Writer) error {
+	numErrs := 0
+	for _, fileSchema := range schemas {
+		cfgFile := filepath.Join(cfgDir, fileSchema.ConfigFile)
+		displayPath := cfgFile
+		if relPath, err := filepath.Rel(projectDir, cfgFile); err == nil {
+			displayPath = relPath
+		}
+
+		cfgBytes, err := os.ReadFile(cfgFile)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to read file %s", cfgFile)
+		}
+
+		schema, err := configschema.Parse(fileSchema.Schema)
+		if err != nil {
+			_, _ = fmt.Fprintf(stdout, "%s: invalid schema: %v\n", displayPath, err)
+			numErrs++
+			continue
+		}
+		validationErrs, err := configschema.Validate(schema, cfgBytes)
+		if err != nil {
+			_, _ = fmt.Fprintf(stdout, "%s: %v\n", displayPath, err)
+			numErrs++
+			continue
+		}
+		for _, validationErr := range validationErrs {
+			_, _ = fmt.Fprintf(stdout, "%s:%v\n", displayPath, validationErr)
+		}
+		numErrs += len(validationErrs)
+	}
+	if numErrs > 0 {
+		return errors.Errorf("configuration validation failed: %d error(s)", numErrs)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks/configvalidate"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	godelSchemaObj, err := config.GodelConfigSchema()
+	require.NoError(t, err)
+	godelSchema, err := json.Marshal(godelSchemaObj)
+	require.NoError(t, err)
+	pluginSchema := []byte(`{"type":"object","properties":{"products":{"type":"object"}},"additionalProperties":false}`)
+
+	for i, tc := range []struct {
+		name       string
+		files      map[string]string
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			"valid configuration",
+			map[string]string{
+				"godel.yml": `plugins:
+  resolvers:
+    - https://example.com/{{GroupPath}}/{{Product}}/{{Version}}/{{Product}}-{{Version}}-{{OS}}-{{Arch}}.tgz
+  plugins:
+    - locator:
+        id: com.palantir.godel-mod-plugin:mod-plugin:1.0.0
+exclude:
+  names:
+    - "\\..+"
+`,
+				"dist-plugin.yml": `products: {}
+`,
+			},
+			"",
+			"",
+		},
+		{
+			"missing files are skipped",
+			nil,
+			"",
+			"",
+		},
+		{
+			"invalid configuration",
+			map[string]string{
+				"godel.yml": `plugins:
+  plugin:
+    - locator:
+        id: foo:bar:1.0.0
+verify-tasks:
+  ordering:
+    format: first
+`,
+				"dist-plugin.yml": `product: {}
+`,
+			},
+			`godel/config/godel.yml:2:3: plugins: unknown key "plugin"
+godel/config/godel.yml:7:13: verify-tasks.ordering.format: expected integer, was string
+godel/config/dist-plugin.yml:1:1: unknown key "product"
+`,
+			"configuration validation failed: 3 error(s)",
+		},
+	} {
+		projectDir, cleanup, err := dirs.TempDir("", "")
+		require.NoError(t, err)
+
+		cfgDir := filepath.Join(projectDir, "godel", "config")
+		require.NoError(t, os.MkdirAll(cfgDir, 0755))
+		for name, content := range tc.files {
+			require.NoError(t, os.WriteFile(filepath.Join(cfgDir, name), []byte(content), 0644))
+		}
+
+		outBuf := &bytes.Buffer{}
+		err = configvalidate.Validate(projectDir, cfgDir, []configvalidate.ConfigFileSchema{
+			{
+				ConfigFile: "godel.yml",
+				Schema:     godelSchema,
+			},
+			{
+				ConfigFile: "dist-plugin.yml",
+				Schema:     pluginSchema,
+			},
+		}, outBuf)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, "Case %d: %s", i, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.wantOutput, outBuf.String(), "Case %d: %s", i, tc.name)
+		cleanup()
+	}
+}
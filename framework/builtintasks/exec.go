@@ -36,7 +36,13 @@ func ExecTask() godellauncher.Task {
 			execCmd.Stdout = cmd.OutOrStdout()
 			execCmd.Stderr = cmd.ErrOrStderr()
 			execCmd.Stdin = os.Stdin
-			return execCmd.Run()
+			if err := execCmd.Run(); err != nil {
+				if exitCodeErr := godellauncher.ExitCodeErrorFromExec(err, err.Error()); exitCodeErr != nil {
+					return exitCodeErr
+				}
+				return err
+			}
+			return nil
 		},
 	}, &globalCfg)
 }
@@ -0,0 +1,74 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	formatFlagName = "format"
+	textFormat     = "text"
+	jsonFormat     = "json"
+)
+
+// addFormatFlag adds the "--format" flag to the provided flag set and returns the pointer to its value. The value
+// should be checked using isJSONFormat.
+func addFormatFlag(fset *pflag.FlagSet) *string {
+	return fset.String(formatFlagName, textFormat, `output format: "text" or "json"`)
+}
+
+// isJSONFormat returns true if the provided value of the "--format" flag specifies JSON output and false if it specifies
+// text output. Returns an error if the value is not a supported format.
+func isJSONFormat(format string) (bool, error) {
+	switch format {
+	case textFormat:
+		return false, nil
+	case jsonFormat:
+		return true, nil
+	default:
+		return false, errors.Errorf(`invalid format %q: must be "%s" or "%s"`, format, textFormat, jsonFormat)
+	}
+}
+
+// printJSON prints the indented JSON representation of the provided value followed by a newline.
+func printJSON(in any, stdout io.Writer) error {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(in); err != nil {
+		return errors.Wrapf(err, "failed to marshal JSON")
+	}
+	return nil
+}
+
+// yamlJSONValue returns a value whose JSON representation has the same structure and keys as the YAML representation
+// of the provided value. Used to print configuration, which only specifies YAML keys, as JSON.
+func yamlJSONValue(in any) (any, error) {
+	ymlBytes, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal YAML")
+	}
+	var out any
+	if err := yamlv3.Unmarshal(ymlBytes, &out); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal YAML")
+	}
+	return out, nil
+}
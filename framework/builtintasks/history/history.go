@@ -0,0 +1,343 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history records the task runs of gödel projects in a JSONL file in the gödel home directory and summarizes
+// them.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/builtintasks/installupdate/layout"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/pkg/specdir"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DisableEnvVar is the environment variable that disables recording task runs for all projects if its value is
+	// "true".
+	DisableEnvVar = "GODEL_DISABLE_HISTORY"
+	// MaxRecordsEnvVar is the environment variable that specifies the maximum number of task runs that are kept in the
+	// history.
+	MaxRecordsEnvVar = "GODEL_HISTORY_MAX_RECORDS"
+	// MaxAgeEnvVar is the environment variable that specifies the maximum age of the task runs that are kept in the
+	// history as a duration such as "720h".
+	MaxAgeEnvVar = "GODEL_HISTORY_MAX_AGE"
+
+	// DefaultMaxRecords is the maximum number of task runs that are kept in the history if MaxRecordsEnvVar is not set.
+	DefaultMaxRecords = 10000
+	// DefaultMaxAge is the maximum age of the task runs that are kept in the history if MaxAgeEnvVar is not set.
+	DefaultMaxAge = 90 * 24 * time.Hour
+
+	fileName       = "task-history.jsonl"
+	lockFileSuffix = ".lock"
+)
+
+// Limits are the limits of the history file. The history file is shared by all of the projects that use the same gödel
+// home directory, so the limits are global rather than configured per project.
+type Limits struct {
+	// MaxRecords is the maximum number of task runs that are kept in the history.
+	MaxRecords int
+	// MaxAge is the maximum age of the task runs that are kept in the history.
+	MaxAge time.Duration
+}
+
+// LimitsFromEnv returns the limits specified by MaxRecordsEnvVar and MaxAgeEnvVar. The defaults are used for the limits
+// that are not specified. Returns an error if a limit is not positive.
+func LimitsFromEnv() (Limits, error) {
+	limits := Limits{
+		MaxRecords: DefaultMaxRecords,
+		MaxAge:     DefaultMaxAge,
+	}
+	if val := os.Getenv(MaxRecordsEnvVar); val != "" {
+		maxRecords, err := strconv.Atoi(val)
+		if err != nil || maxRecords <= 0 {
+			return Limits{}, errors.Errorf("invalid value %q for %s: must be a positive number", val, MaxRecordsEnvVar)
+		}
+		limits.MaxRecords = maxRecords
+	}
+	if val := os.Getenv(MaxAgeEnvVar); val != "" {
+		maxAge, err := time.ParseDuration(val)
+		if err != nil || maxAge <= 0 {
+			return Limits{}, errors.Errorf(`invalid value %q for %s: must be a positive duration such as "720h"`, val, MaxAgeEnvVar)
+		}
+		limits.MaxAge = maxAge
+	}
+	return limits, nil
+}
+
+// Record is a single task run. It is stored as a single line of JSON in the history file.
+type Record struct {
+	// Time is the time at which the task started.
+	Time time.Time `json:"time"`
+	// Task is the name of the task.
+	Task string `json:"task"`
+	// Args are the arguments provided to the task.
+	Args []string `json:"args"`
+	// ProjectDir is the project directory in which the task was run.
+	ProjectDir string `json:"projectDir"`
+	// DurationMillis is the amount of time that the task ran for in milliseconds.
+	DurationMillis int64 `json:"durationMillis"`
+	// ExitCode is the exit code of the task: 0 if the task succeeded.
+	ExitCode int `json:"exitCode"`
+	// GodelVersion is the version of gödel that ran the task.
+	GodelVersion string `json:"godelVersion"`
+	// Plugin is the locator of the plugin that provides the task. Empty if the task is not provided by a plugin.
+	Plugin string `json:"plugin,omitempty"`
+}
+
+// Duration returns the amount of time that the task ran for.
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationMillis) * time.Millisecond
+}
+
+// Disabled returns true if task runs should not be recorded based on the provided parameters and DisableEnvVar.
+func Disabled(param godellauncher.HistoryParam) bool {
+	return param.Disabled || os.Getenv(DisableEnvVar) == "true"
+}
+
+// FilePath returns the path to the history file in the cache directory of the gödel home directory.
+func FilePath() (string, error) {
+	godelHomeSpecDir, err := layout.GodelHomeSpecDir(specdir.Create)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create gödel home directory")
+	}
+	return filepath.Join(godelHomeSpecDir.Path(layout.CacheDir), fileName), nil
+}
+
+// Append appends the provided record to the history file at the provided path and then removes the records that
+// exceed the provided limits. The history file is locked while it is modified so that records appended by concurrent
+// gödel runs are not lost when the file is pruned.
+func Append(path string, record Record, limits Limits) (rErr error) {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal task run record")
+	}
+	recordBytes = append(recordBytes, '\n')
+
+	lockFile, err := os.OpenFile(path+lockFileSuffix, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open history lock file")
+	}
+	defer func() {
+		if err := lockFile.Close(); err != nil && rErr == nil {
+			rErr = errors.Wrapf(err, "failed to close history lock file")
+		}
+	}()
+	if err := lockFileHandle(lockFile); err != nil {
+		return errors.Wrapf(err, "failed to lock history file %s", path)
+	}
+	defer func() {
+		if err := unlockFileHandle(lockFile); err != nil && rErr == nil {
+			rErr = errors.Wrapf(err, "failed to unlock history file %s", path)
+		}
+	}()
+
+	state, err := readFileState(lockFile, path)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open history file %s", path)
+	}
+	if _, err := f.Write(recordBytes); err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "failed to write history file %s", path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close history file %s", path)
+	}
+	state.Lines++
+	state.Size += int64(len(recordBytes))
+
+	if needsPrune(path, state, limits, record.Time) {
+		if state, err = prune(path, limits, record.Time); err != nil {
+			return err
+		}
+	}
+	return writeFileState(lockFile, state)
+}
+
+// fileState is the state of the history file. It is stored in the lock file so that the history file does not have to
+// be read on every run to determine whether it must be pruned.
+type fileState struct {
+	// Lines is the number of lines in the history file.
+	Lines int `json:"lines"`
+	// Size is the size of the history file in bytes. If it does not match the size of the history file, the file was
+	// modified without updating the state and the lines of the file are counted again.
+	Size int64 `json:"size"`
+}
+
+// readFileState returns the state of the history file at the provided path that is stored in the provided lock file.
+func readFileState(lockFile *os.File, path string) (fileState, error) {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return fileState{}, nil
+	} else if err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to stat history file %s", path)
+	}
+	stateBytes, err := io.ReadAll(io.NewSectionReader(lockFile, 0, 1<<20))
+	if err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to read history lock file")
+	}
+	var state fileState
+	if err := json.Unmarshal(stateBytes, &state); err == nil && state.Size == fi.Size() {
+		return state, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to open history file %s", path)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	state = fileState{}
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := f.Read(buf)
+		state.Lines += bytes.Count(buf[:n], []byte("\n"))
+		state.Size += int64(n)
+		if err == io.EOF {
+			return state, nil
+		} else if err != nil {
+			return fileState{}, errors.Wrapf(err, "failed to read history file %s", path)
+		}
+	}
+}
+
+// writeFileState stores the provided state of the history file in the provided lock file.
+func writeFileState(lockFile *os.File, state fileState) error {
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal history file state")
+	}
+	if err := lockFile.Truncate(0); err != nil {
+		return errors.Wrapf(err, "failed to truncate history lock file")
+	}
+	if _, err := lockFile.WriteAt(stateBytes, 0); err != nil {
+		return errors.Wrapf(err, "failed to write history lock file")
+	}
+	return nil
+}
+
+// needsPrune returns true if the history file should be pruned. Rewriting the file on every run once it reaches one of
+// the limits would slow down every task, so the file is only pruned once it exceeds the limits by 10%: if it has more
+// lines than the maximum number of records or if its first record is older than the maximum age. Only the first line of
+// the file is read.
+func needsPrune(path string, state fileState, limits Limits, now time.Time) bool {
+	if state.Lines > limits.MaxRecords+limits.MaxRecords/10 {
+		return true
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	firstLine, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return false
+	}
+	var first Record
+	if err := json.Unmarshal(firstLine, &first); err != nil {
+		// the first line is not a valid record, so prune to remove it
+		return true
+	}
+	return first.Time.Before(now.Add(-(limits.MaxAge + limits.MaxAge/10)))
+}
+
+// prune removes the records that exceed the limits from the history file and returns the state of the pruned file.
+func prune(path string, limits Limits, now time.Time) (fileState, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to read history file %s", path)
+	}
+	minTime := now.Add(-limits.MaxAge)
+	var kept []Record
+	for _, record := range parseRecords(fileBytes) {
+		if record.Time.Before(minTime) {
+			continue
+		}
+		kept = append(kept, record)
+	}
+	if len(kept) > limits.MaxRecords {
+		kept = kept[len(kept)-limits.MaxRecords:]
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, record := range kept {
+		if err := encoder.Encode(record); err != nil {
+			return fileState{}, errors.Wrapf(err, "failed to marshal task run record")
+		}
+	}
+	// write to a temporary file and rename it so that the history file is never partially written
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), fileName+".*.tmp")
+	if err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to create temporary history file")
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
+		_ = tmpFile.Close()
+		return fileState{}, errors.Wrapf(err, "failed to write temporary history file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to close temporary history file")
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fileState{}, errors.Wrapf(err, "failed to replace history file %s", path)
+	}
+	return fileState{Lines: len(kept), Size: int64(buf.Len())}, nil
+}
+
+// Read returns the records in the history file at the provided path in the order in which they were recorded. Returns
+// no records if the file does not exist. Lines that are not valid records are ignored.
+func Read(path string) ([]Record, error) {
+	fileBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read history file %s", path)
+	}
+	return parseRecords(fileBytes), nil
+}
+
+func parseRecords(fileBytes []byte) []Record {
+	var records []Record
+	scanner := bufio.NewScanner(bytes.NewReader(fileBytes))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(fileBytes)+1)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// ignore lines that are not valid records (for example, a partially written record)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
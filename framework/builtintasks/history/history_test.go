@@ -0,0 +1,293 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks/history"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendPrunesRecords(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	for i, tc := range []struct {
+		name      string
+		limits    history.Limits
+		times     []time.Time
+		wantTasks []string
+	}{
+		{
+			"records within limits are kept",
+			history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour},
+			[]time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour), now},
+			[]string{"task-0", "task-1", "task-2"},
+		},
+		{
+			"oldest records exceeding max records are removed",
+			history.Limits{MaxRecords: 2, MaxAge: 24 * time.Hour},
+			[]time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour), now},
+			[]string{"task-1", "task-2"},
+		},
+		{
+			"records are not removed until the number of records exceeds max records by 10%",
+			history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour},
+			timesUntil(now, 11),
+			tasks(0, 11),
+		},
+		{
+			"oldest records are removed once the number of records exceeds max records by 10%",
+			history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour},
+			timesUntil(now, 12),
+			tasks(2, 12),
+		},
+		{
+			"records older than max age are removed",
+			history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour},
+			[]time.Time{now.Add(-48 * time.Hour), now.Add(-time.Hour), now},
+			[]string{"task-1", "task-2"},
+		},
+	} {
+		historyFile := filepath.Join(tmpDir, "history.jsonl")
+		require.NoError(t, os.RemoveAll(historyFile), "Case %d: %s", i, tc.name)
+		for j, recordTime := range tc.times {
+			err := history.Append(historyFile, history.Record{
+				Time: recordTime,
+				Task: fmt.Sprintf("task-%d", j),
+			}, tc.limits)
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+
+		records, err := history.Read(historyFile)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		var gotTasks []string
+		for _, record := range records {
+			gotTasks = append(gotTasks, record.Task)
+		}
+		assert.Equal(t, tc.wantTasks, gotTasks, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestAppendConcurrently(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	historyFile := filepath.Join(tmpDir, "history.jsonl")
+	now := time.Now()
+	limits := history.Limits{MaxRecords: 100, MaxAge: 24 * time.Hour}
+	errs := make(chan error, 200)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				errs <- history.Append(historyFile, history.Record{Time: now, Task: "test"}, limits)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// the file is pruned to 100 records every time that it exceeds 110 records, so if no record was lost, the 200
+	// appended records leave 101 records
+	records, err := history.Read(historyFile)
+	require.NoError(t, err)
+	assert.Len(t, records, 101)
+}
+
+func TestAppendCountsRecordsOfModifiedFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	historyFile := filepath.Join(tmpDir, "history.jsonl")
+	now := time.Now()
+	limits := history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour}
+	require.NoError(t, history.Append(historyFile, history.Record{Time: now, Task: "task-0"}, limits))
+
+	// records that are appended without using Append are counted
+	f, err := os.OpenFile(historyFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	for i := 1; i < 11; i++ {
+		_, err := fmt.Fprintf(f, `{"time":%q,"task":"task-%d"}`+"\n", now.Format(time.RFC3339Nano), i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	require.NoError(t, history.Append(historyFile, history.Record{Time: now, Task: "task-11"}, limits))
+
+	records, err := history.Read(historyFile)
+	require.NoError(t, err)
+	require.Len(t, records, 10)
+	assert.Equal(t, "task-2", records[0].Task)
+}
+
+func timesUntil(end time.Time, n int) []time.Time {
+	var times []time.Time
+	for i := n - 1; i >= 0; i-- {
+		times = append(times, end.Add(-time.Duration(i)*time.Minute))
+	}
+	return times
+}
+
+func tasks(start, end int) []string {
+	var tasks []string
+	for i := start; i < end; i++ {
+		tasks = append(tasks, fmt.Sprintf("task-%d", i))
+	}
+	return tasks
+}
+
+func TestReadIgnoresInvalidLines(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	historyFile := filepath.Join(tmpDir, "history.jsonl")
+	records, err := history.Read(historyFile)
+	require.NoError(t, err)
+	assert.Empty(t, records, "history file that does not exist should have no records")
+
+	require.NoError(t, os.WriteFile(historyFile, []byte(`{"task":"verify","exitCode":1,"durationMillis":1500}
+{"task":"te
+{"task":"test","args":["./..."]}
+`), 0644))
+	records, err = history.Read(historyFile)
+	require.NoError(t, err)
+	assert.Equal(t, []history.Record{
+		{Task: "verify", ExitCode: 1, DurationMillis: 1500},
+		{Task: "test", Args: []string{"./..."}},
+	}, records)
+	assert.Equal(t, 1500*time.Millisecond, records[0].Duration())
+}
+
+func TestDisabled(t *testing.T) {
+	t.Setenv(history.DisableEnvVar, "")
+	assert.False(t, history.Disabled(godellauncher.HistoryParam{}))
+	assert.True(t, history.Disabled(godellauncher.HistoryParam{Disabled: true}))
+
+	t.Setenv(history.DisableEnvVar, "true")
+	assert.True(t, history.Disabled(godellauncher.HistoryParam{}))
+}
+
+func TestLimitsFromEnv(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		maxRecords string
+		maxAge     string
+		want       history.Limits
+		wantErr    string
+	}{
+		{
+			"defaults",
+			"",
+			"",
+			history.Limits{MaxRecords: history.DefaultMaxRecords, MaxAge: history.DefaultMaxAge},
+			"",
+		},
+		{
+			"configured limits",
+			"100",
+			"24h",
+			history.Limits{MaxRecords: 100, MaxAge: 24 * time.Hour},
+			"",
+		},
+		{
+			"invalid max records",
+			"-1",
+			"",
+			history.Limits{},
+			`invalid value "-1" for GODEL_HISTORY_MAX_RECORDS: must be a positive number`,
+		},
+		{
+			"invalid max age",
+			"",
+			"30d",
+			history.Limits{},
+			`invalid value "30d" for GODEL_HISTORY_MAX_AGE: must be a positive duration such as "720h"`,
+		},
+	} {
+		t.Setenv(history.MaxRecordsEnvVar, tc.maxRecords)
+		t.Setenv(history.MaxAgeEnvVar, tc.maxAge)
+		got, err := history.LimitsFromEnv()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	var records []history.Record
+	for i, durationMillis := range []int64{100, 200, 300, 400, 500, 600, 700, 800} {
+		record := history.Record{
+			Time:           start.Add(time.Duration(i) * time.Minute),
+			Task:           "verify",
+			DurationMillis: durationMillis,
+		}
+		if i%4 == 0 {
+			record.ExitCode = 1
+		}
+		records = append(records, record)
+	}
+	records = append(records, history.Record{
+		Time:           start,
+		Task:           "check",
+		DurationMillis: 50,
+	})
+
+	trend := 2.0
+	assert.Equal(t, []history.TaskSummary{
+		{
+			Task:      "check",
+			Runs:      1,
+			P50Millis: 50,
+			P90Millis: 50,
+			P99Millis: 50,
+			LastRun:   start,
+		},
+		{
+			Task:        "verify",
+			Runs:        8,
+			Failures:    2,
+			FailureRate: 0.25,
+			P50Millis:   400,
+			P90Millis:   800,
+			P99Millis:   800,
+			// the median of the newer half (600) is 200% longer than the median of the older half (200)
+			Trend:   &trend,
+			LastRun: start.Add(7 * time.Minute),
+		},
+	}, history.Summarize(records))
+}
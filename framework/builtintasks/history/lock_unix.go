@@ -0,0 +1,32 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package history
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFileHandle acquires an exclusive lock on the provided file, blocking until it is available.
+func lockFileHandle(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX)
+}
+
+func unlockFileHandle(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
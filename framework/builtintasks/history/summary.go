@@ -0,0 +1,100 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"time"
+)
+
+// minTrendRuns is the minimum number of runs of a task for which a trend is computed.
+const minTrendRuns = 4
+
+// TaskSummary summarizes the runs of a single task.
+type TaskSummary struct {
+	// Task is the name of the task.
+	Task string `json:"task"`
+	// Runs is the number of runs of the task.
+	Runs int `json:"runs"`
+	// Failures is the number of runs of the task that failed.
+	Failures int `json:"failures"`
+	// FailureRate is the fraction of the runs that failed (between 0 and 1).
+	FailureRate float64 `json:"failureRate"`
+	// P50Millis, P90Millis and P99Millis are the 50th, 90th and 99th percentiles of the durations of the runs in
+	// milliseconds.
+	P50Millis int64 `json:"p50Millis"`
+	P90Millis int64 `json:"p90Millis"`
+	P99Millis int64 `json:"p99Millis"`
+	// Trend is the relative change of the median duration of the newer half of the runs compared to the median duration
+	// of the older half: for example, 0.25 means that the newer runs took 25% longer. Nil if the task has fewer than 4
+	// runs.
+	Trend *float64 `json:"trend"`
+	// LastRun is the time at which the most recent run started.
+	LastRun time.Time `json:"lastRun"`
+}
+
+// Summarize returns the summaries of the runs of each task in the provided records sorted by task name. The records
+// must be in the order in which they were recorded.
+func Summarize(records []Record) []TaskSummary {
+	recordsByTask := make(map[string][]Record)
+	for _, record := range records {
+		recordsByTask[record.Task] = append(recordsByTask[record.Task], record)
+	}
+	var summaries []TaskSummary
+	for _, task := range slices.Sorted(maps.Keys(recordsByTask)) {
+		taskRecords := recordsByTask[task]
+		summary := TaskSummary{
+			Task: task,
+			Runs: len(taskRecords),
+		}
+		var durations []int64
+		for _, record := range taskRecords {
+			if record.ExitCode != 0 {
+				summary.Failures++
+			}
+			if record.Time.After(summary.LastRun) {
+				summary.LastRun = record.Time
+			}
+			durations = append(durations, record.DurationMillis)
+		}
+		summary.FailureRate = float64(summary.Failures) / float64(summary.Runs)
+		if len(durations) >= minTrendRuns {
+			older := percentile(durations[:len(durations)/2], 50)
+			newer := percentile(durations[len(durations)/2:], 50)
+			if older > 0 {
+				trend := float64(newer-older) / float64(older)
+				summary.Trend = &trend
+			}
+		}
+		summary.P50Millis = percentile(durations, 50)
+		summary.P90Millis = percentile(durations, 90)
+		summary.P99Millis = percentile(durations, 99)
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// percentile returns the provided percentile of the provided values using the nearest-rank method. The provided slice
+// is not modified.
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Sorted(slices.Values(values))
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// WithHooks returns the provided tasks with the hooks that are configured for them. The tasks that are run by hooks are
+// resolved using the tasks returned by the provided function when the hooks are run.
+func WithHooks(tasks []godellauncher.Task, param godellauncher.HooksParam, allTasks func() []godellauncher.Task) []godellauncher.Task {
+	if len(param.Hooks) == 0 {
+		return tasks
+	}
+	hookedTasks := make([]godellauncher.Task, len(tasks))
+	for i, task := range tasks {
+		hooks, ok := param.Hooks[task.Name]
+		if !ok {
+			hookedTasks[i] = task
+			continue
+		}
+		hookedTasks[i] = taskWithHooks(task, hooks, allTasks)
+	}
+	return hookedTasks
+}
+
+// CheckHooks returns an error if hooks are configured for a task that is not one of the provided tasks or if a hook runs
+// a task that is not one of the provided tasks.
+func CheckHooks(param godellauncher.HooksParam, tasks []godellauncher.Task) error {
+	taskNames := make(map[string]struct{})
+	for _, task := range tasks {
+		taskNames[task.Name] = struct{}{}
+	}
+	for _, name := range slices.Sorted(maps.Keys(param.Hooks)) {
+		hooks := param.Hooks[name]
+		if _, ok := taskNames[name]; !ok {
+			return errors.Errorf("hooks are configured for unknown task %s", name)
+		}
+		for _, hook := range slices.Concat(hooks.Pre, hooks.Post) {
+			if hook.Task == "" {
+				continue
+			}
+			if _, ok := taskNames[hook.Task]; !ok {
+				return errors.Errorf("hook of task %s runs unknown task %s", name, hook.Task)
+			}
+		}
+	}
+	return nil
+}
+
+func taskWithHooks(task godellauncher.Task, hooks godellauncher.TaskHooksParam, allTasks func() []godellauncher.Task) godellauncher.Task {
+	hookedTask := task
+	hookedTask.RunImpl = nil
+	hookedTask.RunContextImpl = func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+		for _, hook := range hooks.Pre {
+			if err := runHook(ctx, hook, task.Name, nil, global, stdout, allTasks); err != nil {
+				if hook.Warn {
+					_, _ = fmt.Fprintf(os.Stderr, "Warning: pre hook %q of task %s failed: %v\n", hook, task.Name, err)
+					continue
+				}
+				return errors.Wrapf(err, "pre hook %q of task %s failed", hook, task.Name)
+			}
+		}
+
+		taskErr := task.RunContext(ctx, global, stdout)
+		exitStatus := godellauncher.ExitCode(taskErr)
+
+		// all post hooks are run even if the task or an earlier post hook failed so that they can be used for cleanup.
+		// For the same reason, post hooks are not stopped if the task was cancelled.
+		postCtx := context.WithoutCancel(ctx)
+		var postErr error
+		for _, hook := range hooks.Post {
+			env := []string{"GODEL_TASK_EXIT_STATUS=" + strconv.Itoa(exitStatus)}
+			if err := runHook(postCtx, hook, task.Name, env, global, stdout, allTasks); err != nil {
+				if hook.Warn {
+					_, _ = fmt.Fprintf(os.Stderr, "Warning: post hook %q of task %s failed: %v\n", hook, task.Name, err)
+					continue
+				}
+				if postErr == nil {
+					postErr = errors.Wrapf(err, "post hook %q of task %s failed", hook, task.Name)
+				}
+			}
+		}
+		if taskErr != nil {
+			return taskErr
+		}
+		return postErr
+	}
+	return hookedTask
+}
+
+// runHook runs the provided hook of the task with the provided name. The provided environment variables are only
+// provided to script hooks.
+func runHook(ctx context.Context, hook godellauncher.TaskHookParam, taskName string, env []string, global godellauncher.GlobalConfig, stdout io.Writer, allTasks func() []godellauncher.Task) error {
+	if hook.Script != "" {
+		projectDir, err := global.ProjectDir()
+		if err != nil {
+			return err
+		}
+		return runShellCommand(ctx, hook.Script, taskName, nil, projectDir, append([]string{"GODEL_TASK=" + taskName}, env...), stdout, os.Stderr)
+	}
+	for _, task := range allTasks() {
+		if task.Name != hook.Task {
+			continue
+		}
+		hookGlobal := global
+		hookGlobal.Task = hook.Task
+		hookGlobal.TaskArgs = hook.Args
+		return task.RunContext(ctx, hookGlobal, stdout)
+	}
+	return errors.Errorf("unknown task %s", hook.Task)
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHooks(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	for i, tc := range []struct {
+		name       string
+		hooks      godellauncher.TaskHooksParam
+		taskErr    error
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			"pre and post hooks are run around the task",
+			godellauncher.TaskHooksParam{
+				Pre: []godellauncher.TaskHookParam{
+					{Script: `echo "pre $GODEL_TASK"`},
+					{Task: "generate", Args: []string{"--verify"}},
+				},
+				Post: []godellauncher.TaskHookParam{
+					{Script: `echo "post $GODEL_TASK $GODEL_TASK_EXIT_STATUS"`},
+				},
+			},
+			nil,
+			"",
+			"pre test\nran generate [--verify]\nran test [./...]\npost test 0\n",
+		},
+		{
+			"post hooks are run with the exit status of a failed task",
+			godellauncher.TaskHooksParam{
+				Post: []godellauncher.TaskHookParam{
+					{Script: `echo "post $GODEL_TASK_EXIT_STATUS"`},
+				},
+			},
+			errors.New("test failed"),
+			"test failed",
+			"ran test [./...]\npost 1\n",
+		},
+		{
+			"post hooks are run with the exit code of a task that failed with an exit code",
+			godellauncher.TaskHooksParam{
+				Post: []godellauncher.TaskHookParam{
+					{Script: `echo "post $GODEL_TASK_EXIT_STATUS"`},
+				},
+			},
+			&godellauncher.ExitCodeError{Code: 2},
+			"",
+			"ran test [./...]\npost 2\n",
+		},
+		{
+			"task is not run if a pre hook fails",
+			godellauncher.TaskHooksParam{
+				Pre: []godellauncher.TaskHookParam{
+					{Script: "exit 3"},
+				},
+			},
+			nil,
+			`pre hook "exit 3" of task test failed: exit status 3`,
+			"",
+		},
+		{
+			"failure of hook with warn policy is ignored",
+			godellauncher.TaskHooksParam{
+				Pre: []godellauncher.TaskHookParam{
+					{Script: "exit 3", Warn: true},
+				},
+				Post: []godellauncher.TaskHookParam{
+					{Script: "exit 4", Warn: true},
+				},
+			},
+			nil,
+			"",
+			"ran test [./...]\n",
+		},
+		{
+			"all post hooks are run if a post hook fails",
+			godellauncher.TaskHooksParam{
+				Post: []godellauncher.TaskHookParam{
+					{Script: "exit 4"},
+					{Script: "echo cleanup"},
+				},
+			},
+			nil,
+			`post hook "exit 4" of task test failed: exit status 4`,
+			"ran test [./...]\ncleanup\n",
+		},
+	} {
+		var allTasks []godellauncher.Task
+		allTasks = builtintasks.WithHooks([]godellauncher.Task{
+			recordingTask("test", tc.taskErr),
+			recordingTask("generate", nil),
+		}, godellauncher.HooksParam{
+			Hooks: map[string]godellauncher.TaskHooksParam{
+				"test": tc.hooks,
+			},
+		}, func() []godellauncher.Task {
+			return allTasks
+		})
+
+		outputBuf := &bytes.Buffer{}
+		err := allTasks[0].Run(godellauncher.GlobalConfig{
+			Wrapper:  filepath.Join(projectDir, "godelw"),
+			Task:     "test",
+			TaskArgs: []string{"./..."},
+		}, outputBuf)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		} else if tc.taskErr == nil {
+			assert.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.wantOutput, outputBuf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestWithHooksVerify(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	testTask := recordingTask("test", nil)
+	testTask.Verify = &godellauncher.VerifyOptions{}
+	var allTasks []godellauncher.Task
+	allTasks = builtintasks.WithHooks([]godellauncher.Task{testTask}, godellauncher.HooksParam{
+		Hooks: map[string]godellauncher.TaskHooksParam{
+			"test": {
+				Pre: []godellauncher.TaskHookParam{
+					{Script: "echo pre"},
+				},
+			},
+		},
+	}, func() []godellauncher.Task {
+		return allTasks
+	})
+
+	verifyTask := builtintasks.VerifyTask(allTasks, config.VerifyTasksConfig{})
+	outputBuf := &bytes.Buffer{}
+	err = verifyTask.Run(godellauncher.GlobalConfig{
+		Wrapper: filepath.Join(projectDir, "godelw"),
+		Task:    verifyTask.Name,
+	}, outputBuf)
+	require.NoError(t, err)
+	assert.Equal(t, "Running test...\npre\nran test []\n", outputBuf.String())
+}
+
+func TestWithHooksCancelled(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancelErr := errors.New("cancelled")
+	cancellingTask := godellauncher.Task{
+		Name: "test",
+		RunContextImpl: func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			cancel(cancelErr)
+			return context.Cause(ctx)
+		},
+	}
+	tasks := builtintasks.WithHooks([]godellauncher.Task{cancellingTask}, godellauncher.HooksParam{
+		Hooks: map[string]godellauncher.TaskHooksParam{
+			"test": {
+				Post: []godellauncher.TaskHookParam{
+					{Script: "echo cleanup"},
+				},
+			},
+		},
+	}, nil)
+
+	// post hooks are run even though the context of the task was cancelled
+	outputBuf := &bytes.Buffer{}
+	err = tasks[0].RunContext(ctx, godellauncher.GlobalConfig{
+		Wrapper: filepath.Join(projectDir, "godelw"),
+		Task:    "test",
+	}, outputBuf)
+	assert.Equal(t, cancelErr, err)
+	assert.Equal(t, "cleanup\n", outputBuf.String())
+}
+
+func TestCheckHooks(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{Name: "test"},
+		{Name: "generate"},
+	}
+	for i, tc := range []struct {
+		name    string
+		hooks   map[string]godellauncher.TaskHooksParam
+		wantErr string
+	}{
+		{
+			"valid hooks",
+			map[string]godellauncher.TaskHooksParam{
+				"test": {Pre: []godellauncher.TaskHookParam{{Task: "generate"}, {Script: "echo"}}},
+			},
+			"",
+		},
+		{
+			"hooks for unknown task",
+			map[string]godellauncher.TaskHooksParam{
+				"tset": {Pre: []godellauncher.TaskHookParam{{Script: "echo"}}},
+			},
+			"hooks are configured for unknown task tset",
+		},
+		{
+			"hook runs unknown task",
+			map[string]godellauncher.TaskHooksParam{
+				"test": {Post: []godellauncher.TaskHookParam{{Task: "notify"}}},
+			},
+			"hook of task test runs unknown task notify",
+		},
+	} {
+		err := builtintasks.CheckHooks(godellauncher.HooksParam{Hooks: tc.hooks}, tasks)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		} else {
+			assert.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+	}
+}
+
+// recordingTask returns a task that prints its name and arguments when it is run and returns the provided error.
+func recordingTask(name string, runErr error) godellauncher.Task {
+	return godellauncher.Task{
+		Name: name,
+		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			_, _ = fmt.Fprintf(stdout, "ran %s %v\n", global.Task, global.TaskArgs)
+			return runErr
+		},
+	}
+}
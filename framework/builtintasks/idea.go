@@ -15,12 +15,22 @@
 package builtintasks
 
 import (
+	"path/filepath"
+	"slices"
+
+	"github.com/palantir/godel/v2/framework/builtintasks/configschemas"
 	"github.com/palantir/godel/v2/framework/builtintasks/idea"
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
-func IDEATask() godellauncher.Task {
+// IDEATask returns the "idea" task. The JSON Schemas for the configuration files of gödel and of the provided tasks are
+// written to idea.SchemasDir and registered with the IDE.
+func IDEATask(tasks []godellauncher.Task) godellauncher.Task {
+	// the tasks are used when the task is run, so copy them to ensure that later modifications to the backing array
+	// of the provided slice are not visible
+	tasks = slices.Clone(tasks)
 	const intellijCmdUsage = "Create IntelliJ project files for this project"
 	var globalCfg godellauncher.GlobalConfig
 
@@ -39,7 +49,11 @@ func IDEATask() godellauncher.Task {
 			if len(args) > 0 {
 				return godellauncher.UnknownCommandError(cmd, args)
 			}
-			return idea.CreateIntelliJFiles(projectDir)
+			schemaMappings, err := writeIDEASchemas(projectDir, tasks)
+			if err != nil {
+				return err
+			}
+			return idea.CreateIntelliJFiles(projectDir, schemaMappings...)
 		},
 	}
 	goglandSubcommand := &cobra.Command{
@@ -50,7 +64,11 @@ func IDEATask() godellauncher.Task {
 			if err != nil {
 				return err
 			}
-			return idea.CreateGoglandFiles(projectDir)
+			schemaMappings, err := writeIDEASchemas(projectDir, tasks)
+			if err != nil {
+				return err
+			}
+			return idea.CreateGoglandFiles(projectDir, schemaMappings...)
 		},
 	}
 	intelliJSubcommand := &cobra.Command{
@@ -61,7 +79,11 @@ func IDEATask() godellauncher.Task {
 			if err != nil {
 				return err
 			}
-			return idea.CreateGoglandFiles(projectDir)
+			schemaMappings, err := writeIDEASchemas(projectDir, tasks)
+			if err != nil {
+				return err
+			}
+			return idea.CreateGoglandFiles(projectDir, schemaMappings...)
 		},
 	}
 	cleanSubcommand := &cobra.Command{
@@ -83,3 +105,37 @@ func IDEATask() godellauncher.Task {
 	)
 	return godellauncher.CobraCLITask(ideaCmd, &globalCfg)
 }
+
+// writeIDEASchemas writes the configuration schemas to idea.SchemasDir and returns the mappings that register them with
+// the IDE. The schemas are not written to the schemas directory in the configuration directory because that directory
+// is typically committed, and running the "idea" task should not modify committed files.
+func writeIDEASchemas(projectDir string, tasks []godellauncher.Task) ([]idea.JSONSchemaMapping, error) {
+	cfgDir, err := godellauncher.ConfigDirPath(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := configFileSchemas(tasks)
+	if err != nil {
+		return nil, err
+	}
+	schemaPaths, err := configschemas.Write(filepath.Join(projectDir, filepath.FromSlash(idea.SchemasDir)), schemas)
+	if err != nil {
+		return nil, err
+	}
+	var mappings []idea.JSONSchemaMapping
+	for i, schema := range schemas {
+		relSchemaPath, err := filepath.Rel(projectDir, schemaPaths[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to determine relative path of %s", schemaPaths[i])
+		}
+		relCfgPath, err := filepath.Rel(projectDir, filepath.Join(cfgDir, schema.ConfigFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to determine relative path of %s", schema.ConfigFile)
+		}
+		mappings = append(mappings, idea.JSONSchemaMapping{
+			SchemaPath:     filepath.ToSlash(relSchemaPath),
+			ConfigFilePath: filepath.ToSlash(relCfgPath),
+		})
+	}
+	return mappings, nil
+}
@@ -16,6 +16,7 @@ package idea
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -66,6 +67,32 @@ const (
       <envs />
     </TaskOptions>
   </component>
+{{- if .JSONSchemaMappings}}
+  <component name="JsonSchemaMappingsProjectConfiguration">
+    <state>
+      <map>
+{{- range .JSONSchemaMappings}}
+        <entry key="{{xml .ConfigFilePath}}">
+          <value>
+            <SchemaInfo>
+              <option name="name" value="{{xml .ConfigFilePath}}" />
+              <option name="relativePathToSchema" value="{{xml .SchemaPath}}" />
+              <option name="schemaVersion" value="JSON Schema version 7" />
+              <option name="patterns">
+                <list>
+                  <Item>
+                    <option name="path" value="{{xml .ConfigFilePath}}" />
+                  </Item>
+                </list>
+              </option>
+            </SchemaInfo>
+          </value>
+        </entry>
+{{- end}}
+      </map>
+    </state>
+  </component>
+{{- end}}
 </project>
 `
 	imlGoglandTemplateContent = `<?xml version="1.0" encoding="UTF-8"?>
@@ -107,19 +134,56 @@ const (
       <envs />
     </TaskOptions>
   </component>
+{{- if .JSONSchemaMappings}}
+  <component name="JsonSchemaMappingsProjectConfiguration">
+    <state>
+      <map>
+{{- range .JSONSchemaMappings}}
+        <entry key="{{xml .ConfigFilePath}}">
+          <value>
+            <SchemaInfo>
+              <option name="name" value="{{xml .ConfigFilePath}}" />
+              <option name="relativePathToSchema" value="{{xml .SchemaPath}}" />
+              <option name="schemaVersion" value="JSON Schema version 7" />
+              <option name="patterns">
+                <list>
+                  <Item>
+                    <option name="path" value="{{xml .ConfigFilePath}}" />
+                  </Item>
+                </list>
+              </option>
+            </SchemaInfo>
+          </value>
+        </entry>
+{{- end}}
+      </map>
+    </state>
+  </component>
+{{- end}}
 </project>
 `
 )
 
-func CreateIntelliJFiles(rootDir string) error {
-	return createIDEAFiles(rootDir, imlIntelliJTemplateContent, iprIntelliJTemplateContent)
+// SchemasDir is the path of the directory, relative to the project directory, to which the "idea" task writes the JSON
+// Schemas that it registers with the IDE. It is removed by CleanIDEAFiles.
+const SchemasDir = ".idea/godel-schemas"
+
+// JSONSchemaMapping registers a JSON Schema for a configuration file with the IDE. Both paths are relative to the
+// project directory.
+type JSONSchemaMapping struct {
+	SchemaPath     string
+	ConfigFilePath string
+}
+
+func CreateIntelliJFiles(rootDir string, schemaMappings ...JSONSchemaMapping) error {
+	return createIDEAFiles(rootDir, imlIntelliJTemplateContent, iprIntelliJTemplateContent, schemaMappings)
 }
 
-func CreateGoglandFiles(rootDir string) error {
-	return createIDEAFiles(rootDir, imlGoglandTemplateContent, iprGoglandTemplateContent)
+func CreateGoglandFiles(rootDir string, schemaMappings ...JSONSchemaMapping) error {
+	return createIDEAFiles(rootDir, imlGoglandTemplateContent, iprGoglandTemplateContent, schemaMappings)
 }
 
-func createIDEAFiles(rootDir string, imlContent, iprContent string) error {
+func createIDEAFiles(rootDir string, imlContent, iprContent string, schemaMappings []JSONSchemaMapping) error {
 	projectName := filepath.Base(rootDir)
 
 	goRoot, err := dirs.GoRoot()
@@ -127,10 +191,11 @@ func createIDEAFiles(rootDir string, imlContent, iprContent string) error {
 		return errors.Wrapf(err, "failed to determine GOROOT")
 	}
 	buffer := bytes.Buffer{}
-	templateValues := map[string]string{
-		"GoSDK":       defaultGoSDK,
-		"GoRoot":      goRoot,
-		"ProjectName": projectName,
+	templateValues := map[string]any{
+		"GoSDK":              defaultGoSDK,
+		"GoRoot":             goRoot,
+		"ProjectName":        projectName,
+		"JSONSchemaMappings": schemaMappings,
 	}
 	imlTemplate := template.Must(template.New("iml").Parse(imlContent))
 	if err := imlTemplate.Execute(&buffer, templateValues); err != nil {
@@ -142,7 +207,9 @@ func createIDEAFiles(rootDir string, imlContent, iprContent string) error {
 		return errors.Wrapf(err, "failed to write .iml file to %s", imlFilePath)
 	}
 
-	iprTemplate := template.Must(template.New("modules").Parse(iprContent))
+	iprTemplate := template.Must(template.New("modules").Funcs(template.FuncMap{
+		"xml": xmlEscape,
+	}).Parse(iprContent))
 	buffer = bytes.Buffer{}
 	if err := iprTemplate.Execute(&buffer, templateValues); err != nil {
 		return errors.Wrapf(err, "failed to execute template %s with values %v", iprContent, templateValues)
@@ -156,6 +223,12 @@ func createIDEAFiles(rootDir string, imlContent, iprContent string) error {
 	return nil
 }
 
+func xmlEscape(in string) string {
+	buf := bytes.Buffer{}
+	_ = xml.EscapeText(&buf, []byte(in))
+	return buf.String()
+}
+
 func CleanIDEAFiles(rootDir string) error {
 	projectName := filepath.Base(rootDir)
 	for _, ext := range []string{"iml", "ipr", "iws"} {
@@ -164,5 +237,11 @@ func CleanIDEAFiles(rootDir string) error {
 			return errors.Wrapf(err, "failed to remove file %s", currPath)
 		}
 	}
+	schemasDir := filepath.Join(rootDir, filepath.FromSlash(SchemasDir))
+	if err := os.RemoveAll(schemasDir); err != nil {
+		return errors.Wrapf(err, "failed to remove directory %s", schemasDir)
+	}
+	// the ".idea" directory may contain other files written by the IDE, so it is only removed if it is empty
+	_ = os.Remove(filepath.Dir(schemasDir))
 	return nil
 }
@@ -38,6 +38,24 @@ func TestCreateIdeaFiles(t *testing.T) {
 	verifyXMLHelper(t, ideaFilePath(tmpDir, "ipr"))
 }
 
+func TestCreateIdeaFilesWithSchemaMappings(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	err = idea.CreateIntelliJFiles(tmpDir, idea.JSONSchemaMapping{
+		SchemaPath:     ".idea/godel-schemas/godel.schema.json",
+		ConfigFilePath: "godel/config/godel.yml",
+	})
+	require.NoError(t, err)
+
+	iprBytes, err := os.ReadFile(ideaFilePath(tmpDir, "ipr"))
+	require.NoError(t, err)
+	assert.Contains(t, string(iprBytes), `<component name="JsonSchemaMappingsProjectConfiguration">`)
+	assert.Contains(t, string(iprBytes), `<option name="relativePathToSchema" value=".idea/godel-schemas/godel.schema.json" />`)
+	assert.Contains(t, string(iprBytes), `<option name="path" value="godel/config/godel.yml" />`)
+}
+
 func TestCleanIdeaFiles(t *testing.T) {
 	tmpDir, cleanup, err := dirs.TempDir("", "")
 	defer cleanup()
@@ -57,8 +75,14 @@ func TestCleanIdeaFiles(t *testing.T) {
 			require.NoError(t, err, "Case %d: failed to write %v", i, currPath)
 		}
 
+		schemasDir := filepath.Join(currDir, filepath.FromSlash(idea.SchemasDir))
+		require.NoError(t, os.MkdirAll(schemasDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(schemasDir, "godel.schema.json"), []byte("{}"), 0644))
+
 		err = idea.CleanIDEAFiles(currDir)
 		require.NoError(t, err)
+		_, err = os.Stat(filepath.Dir(schemasDir))
+		assert.True(t, os.IsNotExist(err), "Case %d: did not expect %v to exist", i, filepath.Dir(schemasDir))
 
 		for _, ext := range currCase {
 			currPath := ideaFilePath(currDir, ext)
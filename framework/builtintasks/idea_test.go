@@ -0,0 +1,58 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDEATaskDoesNotWriteConfigDir(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "godel", "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	task := builtintasks.IDEATask(nil)
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"intellij"},
+	}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(projectDir, ".idea", "godel-schemas", "godel.schema.json"))
+	_, err = os.Stat(filepath.Join(projectDir, "godel", "config", "schemas"))
+	assert.True(t, os.IsNotExist(err), "schemas should not be written to the configuration directory")
+
+	task = builtintasks.IDEATask(nil)
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"clean"},
+	}, &bytes.Buffer{})
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(projectDir, ".idea"))
+	assert.True(t, os.IsNotExist(err), ".idea directory should be removed")
+}
@@ -28,10 +28,15 @@ func InfoTask() godellauncher.Task {
 		Use:   "info",
 		Short: "Print information regarding gödel",
 	}
-	cmd.AddCommand(&cobra.Command{
+	var formatFlagVal *string
+	defaultTasksCmd := &cobra.Command{
 		Use:   "default-tasks",
 		Short: "Print configuration for default tasks",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := isJSONFormat(*formatFlagVal)
+			if err != nil {
+				return err
+			}
 			projectDir, err := globalCfg.ProjectDir()
 			if err != nil {
 				return err
@@ -40,6 +45,14 @@ func InfoTask() godellauncher.Task {
 			if err != nil {
 				return err
 			}
+			if jsonOutput {
+				// the JSON output has the same structure and keys as the YAML output
+				out, err := yamlJSONValue(godelCfg.DefaultTasks)
+				if err != nil {
+					return errors.Wrapf(err, "failed to marshal default task configuration")
+				}
+				return printJSON(out, cmd.OutOrStdout())
+			}
 			bytes, err := yaml.Marshal(godelCfg.DefaultTasks)
 			if err != nil {
 				return errors.Wrapf(err, "failed to marshal default task configuration")
@@ -47,6 +60,8 @@ func InfoTask() godellauncher.Task {
 			cmd.Print(string(bytes))
 			return nil
 		},
-	})
+	}
+	formatFlagVal = addFormatFlag(defaultTasksCmd.Flags())
+	cmd.AddCommand(defaultTasksCmd)
 	return godellauncher.CobraCLITask(cmd, &globalCfg)
 }
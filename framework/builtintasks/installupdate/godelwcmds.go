@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/palantir/godel/v2/framework/internal/versionsinternal"
 	"github.com/pkg/errors"
 )
 
@@ -43,28 +44,28 @@ func runUpgradeConfig(projectDir string, args []string, stdout, stderr io.Writer
 }
 
 // getGodelVersion returns the Version returned by "{{projectDir}}/godelw version".
-func getGodelVersion(projectDir string) (godelVersion, error) {
+func getGodelVersion(projectDir string) (versionsinternal.Version, error) {
 	godelw := filepath.Join(projectDir, "godelw")
 	cmd := exec.Command(godelw, "version")
 	output, err := cmd.Output()
 	if err != nil {
-		return godelVersion{}, errors.Wrapf(err, "failed to execute command %v: %s", cmd.Args, string(output))
+		return versionsinternal.Version{}, errors.Wrapf(err, "failed to execute command %v: %s", cmd.Args, string(output))
 	}
 
 	// split input on line breaks and only consider final line. Do this in case invoking "godelw" causes assets to be
 	// downloaded (in which case download messages will be in output before version is printed).
 	outputString, err := getLastLine(string(output))
 	if err != nil {
-		return godelVersion{}, err
+		return versionsinternal.Version{}, err
 	}
 
 	parts := strings.Split(outputString, " ")
 	if len(parts) != 3 {
-		return godelVersion{}, errors.Errorf(`expected output %q to have 3 parts when split by " ", but was %v`, outputString, parts)
+		return versionsinternal.Version{}, errors.Errorf(`expected output %q to have 3 parts when split by " ", but was %v`, outputString, parts)
 	}
-	v, err := newGodelVersion(parts[2])
+	v, err := versionsinternal.NewVersion(parts[2])
 	if err != nil {
-		return godelVersion{}, errors.Wrapf(err, "failed to create version from output")
+		return versionsinternal.Version{}, errors.Wrapf(err, "failed to create version from output")
 	}
 	return v, nil
 }
@@ -24,12 +24,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// packagesOutput is the output of "packages --format json".
+type packagesOutput struct {
+	// Packages are the paths of the packages in the project relative to the project directory (for example, "./foo").
+	Packages []string `json:"packages"`
+}
+
 func PackagesTask() godellauncher.Task {
 	var globalCfg godellauncher.GlobalConfig
-	return godellauncher.CobraCLITask(&cobra.Command{
+	var formatFlagVal *string
+	cmd := &cobra.Command{
 		Use:   "packages",
 		Short: "Lists all of the packages in the project except those excluded by configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := isJSONFormat(*formatFlagVal)
+			if err != nil {
+				return err
+			}
 			projectDir, err := globalCfg.ProjectDir()
 			if err != nil {
 				return err
@@ -39,12 +50,24 @@ func PackagesTask() godellauncher.Task {
 			if err != nil {
 				return err
 			}
-			pkgs, err := packages.List(cfg.Exclude.Matcher(), projectDir)
+			includedCfgs, err := config.ReadIncludedConfigs(projectDir, cfg)
 			if err != nil {
 				return err
 			}
+			exclude := config.CombinedExclude(cfg, includedCfgs)
+			pkgs, err := packages.List(exclude.Matcher(), projectDir)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return printJSON(packagesOutput{
+					Packages: append([]string{}, pkgs...),
+				}, cmd.OutOrStdout())
+			}
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), strings.Join(pkgs, "\n"))
 			return nil
 		},
-	}, &globalCfg)
+	}
+	formatFlagVal = addFormatFlag(cmd.Flags())
+	return godellauncher.CobraCLITask(cmd, &globalCfg)
 }
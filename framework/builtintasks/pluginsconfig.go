@@ -27,21 +27,85 @@ import (
 )
 
 func TasksConfigTask(tasksCfgInfo config.TasksConfigInfo) godellauncher.Task {
-	return godellauncher.CobraCLITask(&cobra.Command{
+	var explainFlagVal bool
+	var formatFlagVal *string
+	cmd := &cobra.Command{
 		Use:   "tasks-config",
 		Short: "Prints the full YAML configuration used to load tasks and assets",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := isJSONFormat(*formatFlagVal)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				if explainFlagVal {
+					return errors.Errorf("--explain cannot be used with --%s %s", formatFlagName, jsonFormat)
+				}
+				return printTasksCfgInfoJSON(tasksCfgInfo, cmd.OutOrStdout())
+			}
+			if explainFlagVal {
+				return printTasksCfgInfoExplanation(tasksCfgInfo, cmd.OutOrStdout())
+			}
 			return printTasksCfgInfo(tasksCfgInfo, cmd.OutOrStdout())
 		},
-	}, nil)
+	}
+	cmd.Flags().BoolVar(&explainFlagVal, "explain", false, "annotate every plugin, resolver, default task and verify task ordering with its source")
+	formatFlagVal = addFormatFlag(cmd.Flags())
+	return godellauncher.CobraCLITask(cmd, nil)
+}
+
+// tasksConfigOutput is the output of "tasks-config --format json". The configuration values have the same structure
+// and keys as the YAML printed by "tasks-config".
+type tasksConfigOutput struct {
+	// Warnings are the warnings for the plugin version conflicts that were resolved using the version conflict policy.
+	Warnings []string `json:"warnings"`
+	// BuiltinPluginsConfig is the configuration for built-in plugins that is built as part of gödel.
+	BuiltinPluginsConfig any `json:"builtinPluginsConfig"`
+	// IncludedConfigFiles are the project-relative paths of the included configuration files, ordered from lowest to
+	// highest precedence.
+	IncludedConfigFiles []string `json:"includedConfigFiles"`
+	// TasksConfig is the fully resolved tasks configuration.
+	TasksConfig any `json:"tasksConfig"`
+	// DefaultTasksPluginsConfig is the plugin configuration used to load the default tasks.
+	DefaultTasksPluginsConfig any `json:"defaultTasksPluginsConfig"`
+}
+
+func printTasksCfgInfoJSON(tasksCfgInfo config.TasksConfigInfo, stdout io.Writer) error {
+	out := tasksConfigOutput{
+		Warnings:            []string{},
+		IncludedConfigFiles: append([]string{}, tasksCfgInfo.IncludedConfigFiles...),
+	}
+	for _, conflict := range tasksCfgInfo.PluginVersionConflicts {
+		out.Warnings = append(out.Warnings, conflict.Warning())
+	}
+	var err error
+	if out.BuiltinPluginsConfig, err = yamlJSONValue(tasksCfgInfo.BuiltinPluginsConfig); err != nil {
+		return err
+	}
+	if out.TasksConfig, err = yamlJSONValue(tasksCfgInfo.TasksConfig); err != nil {
+		return err
+	}
+	if out.DefaultTasksPluginsConfig, err = yamlJSONValue(tasksCfgInfo.DefaultTasksPluginsConfig); err != nil {
+		return err
+	}
+	return printJSON(out, stdout)
 }
 
 func printTasksCfgInfo(tasksCfgInfo config.TasksConfigInfo, stdout io.Writer) error {
+	printPluginVersionConflictWarnings(tasksCfgInfo.PluginVersionConflicts, stdout)
+
 	if err := printWithHeader("Built-in plugin configuration", tasksCfgInfo.BuiltinPluginsConfig, stdout); err != nil {
 		return err
 	}
 	_, _ = fmt.Fprintln(stdout)
 
+	if len(tasksCfgInfo.IncludedConfigFiles) > 0 {
+		if err := printWithHeader("Included configuration files", tasksCfgInfo.IncludedConfigFiles, stdout); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(stdout)
+	}
+
 	if err := printWithHeader("Fully resolved godel tasks configuration", tasksCfgInfo.TasksConfig, stdout); err != nil {
 		return err
 	}
@@ -50,6 +114,18 @@ func printTasksCfgInfo(tasksCfgInfo config.TasksConfigInfo, stdout io.Writer) er
 	return printWithHeader("Plugin configuration for default tasks", tasksCfgInfo.DefaultTasksPluginsConfig, stdout)
 }
 
+// printPluginVersionConflictWarnings prints a warning for each of the provided conflicts followed by an empty line. Does
+// nothing if there are no conflicts.
+func printPluginVersionConflictWarnings(conflicts []config.PluginVersionConflict, stdout io.Writer) {
+	if len(conflicts) == 0 {
+		return
+	}
+	for _, conflict := range conflicts {
+		_, _ = fmt.Fprintln(stdout, "Warning:", conflict.Warning())
+	}
+	_, _ = fmt.Fprintln(stdout)
+}
+
 func printWithHeader(header string, in any, stdout io.Writer) error {
 	printHeader(header, stdout)
 	ymlString, err := toYAMLString(in)
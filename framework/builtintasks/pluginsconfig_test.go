@@ -0,0 +1,240 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTasksConfigTaskExplain(t *testing.T) {
+	providedCfg := unmarshalTasksConfig(t, `
+plugins:
+  resolvers:
+    - https://provider.example.com/{{Product}}
+  plugins:
+    - locator:
+        id: com.palantir:provided:1.0.0
+    - locator:
+        id: com.palantir:overridden:1.0.0
+default-tasks:
+  tasks:
+    com.palantir.godel:check-plugin:
+      exclude-all-default-assets: true
+`)
+	godelCfg := unmarshalTasksConfig(t, `
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:overridden:2.0.0
+      override: true
+verify-tasks:
+  ordering:
+    license: 10
+`)
+
+	var tasksCfg config.TasksConfig
+	var provenance config.TasksConfigProvenance
+	tasksCfg.CombineWithProvenance(&provenance, config.SourcedTasksConfig{
+		Source:      "config provider com.palantir:provider:1.0.0",
+		TasksConfig: providedCfg,
+	})
+	tasksCfg.CombineWithProvenance(&provenance, config.SourcedTasksConfig{
+		Source:      "godel.yml",
+		TasksConfig: godelCfg,
+	})
+
+	builtinPluginsCfg := config.PluginsConfig{
+		DefaultResolvers: []string{"https://builtin.example.com/{{Product}}"},
+		Plugins: config.ToSinglePluginConfigs([]config.SinglePluginConfig{
+			{
+				LocatorWithResolverConfig: config.ToLocatorWithResolverConfig(config.LocatorWithResolverConfig{
+					Locator: config.ToLocatorConfig(config.LocatorConfig{
+						ID: "com.palantir.godel:check-plugin:1.0.0",
+					}),
+				}),
+			},
+		}),
+	}
+	task := builtintasks.TasksConfigTask(config.TasksConfigInfo{
+		BuiltinPluginsConfig:      builtinPluginsCfg,
+		TasksConfig:               tasksCfg,
+		DefaultTasksPluginsConfig: builtinPluginsCfg,
+		TasksConfigProvenance:     provenance,
+	})
+	outputBuf := &bytes.Buffer{}
+	err := task.Run(godellauncher.GlobalConfig{
+		Task:     task.Name,
+		TaskArgs: []string{"--explain"},
+	}, outputBuf)
+	require.NoError(t, err)
+	assert.Equal(t, `Built-in plugin configuration:
+------------------------------
+resolvers:
+  - https://builtin.example.com/{{Product}} # built-in
+plugins:
+  - locator:
+      id: com.palantir.godel:check-plugin:1.0.0 # built-in
+
+Fully resolved godel tasks configuration:
+-----------------------------------------
+default-tasks:
+  tasks:
+    com.palantir.godel:check-plugin: # config provider com.palantir:provider:1.0.0
+      exclude-all-default-assets: true
+plugins:
+  resolvers:
+    - https://provider.example.com/{{Product}} # config provider com.palantir:provider:1.0.0
+  plugins:
+    - locator:
+        id: com.palantir:provided:1.0.0 # config provider com.palantir:provider:1.0.0
+    - locator:
+        id: com.palantir:overridden:2.0.0 # godel.yml
+      override: true
+verify-tasks:
+  ordering:
+    license: 10 # godel.yml
+
+Plugins removed by overrides:
+-----------------------------
+- locator:
+    id: com.palantir:overridden:1.0.0 # config provider com.palantir:provider:1.0.0, overridden by godel.yml
+
+Plugin configuration for default tasks:
+---------------------------------------
+resolvers:
+  - https://builtin.example.com/{{Product}} # built-in
+plugins:
+  - locator:
+      id: com.palantir.godel:check-plugin:1.0.0 # built-in, configured by config provider com.palantir:provider:1.0.0
+`, outputBuf.String())
+}
+
+func TestTasksConfigTaskPluginVersionConflictWarnings(t *testing.T) {
+	task := builtintasks.TasksConfigTask(config.TasksConfigInfo{
+		PluginVersionConflicts: []config.PluginVersionConflict{
+			{
+				Policy:   config.PluginVersionConflictFirst,
+				Selected: config.SourcedPluginLocator{ID: "com.palantir:foo:1.0.0", Source: "config provider a"},
+				Ignored: []config.SourcedPluginLocator{
+					{ID: "com.palantir:foo:2.0.0", Source: "config provider b"},
+				},
+			},
+		},
+	})
+	outputBuf := &bytes.Buffer{}
+	err := task.Run(godellauncher.GlobalConfig{
+		Task: task.Name,
+	}, outputBuf)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(outputBuf.String(), `Warning: configuration providers specify different versions of plugin com.palantir:foo: using com.palantir:foo:1.0.0 from config provider a ("first" policy), ignoring com.palantir:foo:2.0.0 from config provider b
+
+Built-in plugin configuration:
+`), outputBuf.String())
+}
+
+func TestTasksConfigTaskJSON(t *testing.T) {
+	pluginsCfg := config.PluginsConfig{
+		DefaultResolvers: []string{"https://builtin.example.com/{{Product}}"},
+	}
+	task := builtintasks.TasksConfigTask(config.TasksConfigInfo{
+		BuiltinPluginsConfig: pluginsCfg,
+		IncludedConfigFiles:  []string{"godel/config/base.yml"},
+		TasksConfig: unmarshalTasksConfig(t, `
+verify-tasks:
+  ordering:
+    license: 10
+`),
+		DefaultTasksPluginsConfig: pluginsCfg,
+		PluginVersionConflicts: []config.PluginVersionConflict{
+			{
+				Policy:   config.PluginVersionConflictFirst,
+				Selected: config.SourcedPluginLocator{ID: "com.palantir:foo:1.0.0", Source: "config provider a"},
+				Ignored: []config.SourcedPluginLocator{
+					{ID: "com.palantir:foo:2.0.0", Source: "config provider b"},
+				},
+			},
+		},
+	})
+	outputBuf := &bytes.Buffer{}
+	err := task.Run(godellauncher.GlobalConfig{
+		Task:     task.Name,
+		TaskArgs: []string{"--format", "json"},
+	}, outputBuf)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(outputBuf.Bytes(), &got), outputBuf.String())
+	assert.Equal(t, map[string]any{
+		"warnings": []any{
+			`configuration providers specify different versions of plugin com.palantir:foo: using com.palantir:foo:1.0.0 from config provider a ("first" policy), ignoring com.palantir:foo:2.0.0 from config provider b`,
+		},
+		"builtinPluginsConfig": map[string]any{
+			"resolvers": []any{"https://builtin.example.com/{{Product}}"},
+		},
+		"includedConfigFiles": []any{"godel/config/base.yml"},
+		"tasksConfig": map[string]any{
+			"verify-tasks": map[string]any{
+				"ordering": map[string]any{
+					"license": float64(10),
+				},
+			},
+		},
+		"defaultTasksPluginsConfig": map[string]any{
+			"resolvers": []any{"https://builtin.example.com/{{Product}}"},
+		},
+	}, got)
+}
+
+func TestTasksConfigTaskJSONErrors(t *testing.T) {
+	task := builtintasks.TasksConfigTask(config.TasksConfigInfo{})
+	for i, tc := range []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			"explain with JSON format",
+			[]string{"--format", "json", "--explain"},
+			"--explain cannot be used with --format json",
+		},
+		{
+			"invalid format",
+			[]string{"--format", "yaml"},
+			`invalid format "yaml": must be "text" or "json"`,
+		},
+	} {
+		err := task.Run(godellauncher.GlobalConfig{
+			Task:     task.Name,
+			TaskArgs: tc.args,
+		}, &bytes.Buffer{})
+		assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+	}
+}
+
+func unmarshalTasksConfig(t *testing.T, in string) config.TasksConfig {
+	var cfg config.TasksConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &cfg))
+	return cfg
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/builtintasks/history"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// statsOutput is the output of "stats --format json".
+type statsOutput struct {
+	// Tasks are the summaries of the runs of each task sorted by task name.
+	Tasks []history.TaskSummary `json:"tasks"`
+}
+
+func StatsTask() godellauncher.Task {
+	var (
+		globalCfg       godellauncher.GlobalConfig
+		tasksFlagVal    []string
+		sinceFlagVal    time.Duration
+		allProjectsFlag bool
+		formatFlagVal   *string
+	)
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the durations and failures of the task runs recorded in the task run history",
+		Long: `Summarizes the task runs of the project that are recorded in the task run history in the gödel home directory.
+For each task, prints the number of runs, the number and rate of failures, the 50th, 90th and 99th percentiles of
+the durations of the runs and the trend: the change of the median duration of the newer half of the runs compared
+to the older half.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, err := isJSONFormat(*formatFlagVal)
+			if err != nil {
+				return err
+			}
+			var projectDir string
+			if !allProjectsFlag {
+				dir, err := globalCfg.ProjectDir()
+				if err != nil {
+					return err
+				}
+				if projectDir, err = filepath.Abs(dir); err != nil {
+					return errors.Wrapf(err, "failed to determine absolute path of %s", dir)
+				}
+			}
+			historyFile, err := history.FilePath()
+			if err != nil {
+				return err
+			}
+			records, err := history.Read(historyFile)
+			if err != nil {
+				return err
+			}
+			var minTime time.Time
+			if sinceFlagVal > 0 {
+				minTime = time.Now().Add(-sinceFlagVal)
+			}
+			records = slices.DeleteFunc(records, func(record history.Record) bool {
+				return (projectDir != "" && record.ProjectDir != projectDir) ||
+					(len(tasksFlagVal) > 0 && !slices.Contains(tasksFlagVal, record.Task)) ||
+					record.Time.Before(minTime)
+			})
+			summaries := history.Summarize(records)
+			if jsonOutput {
+				return printJSON(statsOutput{
+					Tasks: append([]history.TaskSummary{}, summaries...),
+				}, cmd.OutOrStdout())
+			}
+			printStats(summaries, cmd.OutOrStdout())
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&tasksFlagVal, "task", nil, "only summarize the runs of the specified tasks")
+	cmd.Flags().DurationVar(&sinceFlagVal, "since", 0, `only summarize the runs that started within the specified duration (for example, "168h")`)
+	cmd.Flags().BoolVar(&allProjectsFlag, "all-projects", false, "summarize the runs of all projects rather than only the current project")
+	formatFlagVal = addFormatFlag(cmd.Flags())
+	return godellauncher.CobraCLITask(cmd, &globalCfg)
+}
+
+func printStats(summaries []history.TaskSummary, stdout io.Writer) {
+	if len(summaries) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No task runs recorded")
+		return
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TASK\tRUNS\tFAILURES\tFAILURE RATE\tP50\tP90\tP99\tTREND")
+	for _, summary := range summaries {
+		trend := "-"
+		if summary.Trend != nil {
+			trend = fmt.Sprintf("%+.1f%%", *summary.Trend*100)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%s\t%s\t%s\t%s\n",
+			summary.Task,
+			summary.Runs,
+			summary.Failures,
+			summary.FailureRate*100,
+			formatMillis(summary.P50Millis),
+			formatMillis(summary.P90Millis),
+			formatMillis(summary.P99Millis),
+			trend,
+		)
+	}
+	_ = w.Flush()
+}
+
+func formatMillis(millis int64) string {
+	return (time.Duration(millis) * time.Millisecond).String()
+}
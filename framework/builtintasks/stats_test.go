@@ -0,0 +1,112 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/builtintasks/history"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsTask(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	t.Setenv("GODEL_HOME", filepath.Join(tmpDir, "godel-home"))
+
+	projectDir := filepath.Join(tmpDir, "project")
+	historyFile, err := history.FilePath()
+	require.NoError(t, err)
+	now := time.Now()
+	for _, record := range []history.Record{
+		{Time: now.Add(-4 * time.Hour), Task: "verify", ProjectDir: projectDir, DurationMillis: 1000},
+		{Time: now.Add(-3 * time.Hour), Task: "verify", ProjectDir: projectDir, DurationMillis: 2000, ExitCode: 1},
+		{Time: now.Add(-2 * time.Hour), Task: "test", ProjectDir: projectDir, DurationMillis: 500},
+		{Time: now.Add(-time.Hour), Task: "test", ProjectDir: filepath.Join(tmpDir, "other"), DurationMillis: 100},
+	} {
+		require.NoError(t, history.Append(historyFile, record, history.Limits{MaxRecords: 10, MaxAge: 24 * time.Hour}))
+	}
+
+	for i, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			"runs of the project",
+			nil,
+			`TASK    RUNS  FAILURES  FAILURE RATE  P50    P90    P99    TREND
+test    1     0         0.0%          500ms  500ms  500ms  -
+verify  2     1         50.0%         1s     2s     2s     -
+`,
+		},
+		{
+			"runs of all projects for a task",
+			[]string{"--all-projects", "--task", "test"},
+			`TASK  RUNS  FAILURES  FAILURE RATE  P50    P90    P99    TREND
+test  2     0         0.0%          100ms  500ms  500ms  -
+`,
+		},
+		{
+			"runs since a duration",
+			[]string{"--since", "150m"},
+			`TASK  RUNS  FAILURES  FAILURE RATE  P50    P90    P99    TREND
+test  1     0         0.0%          500ms  500ms  500ms  -
+`,
+		},
+		{
+			"no runs",
+			[]string{"--task", "dist"},
+			"No task runs recorded\n",
+		},
+	} {
+		task := builtintasks.StatsTask()
+		outputBuf := &bytes.Buffer{}
+		err := task.Run(godellauncher.GlobalConfig{
+			Wrapper:  filepath.Join(projectDir, "godelw"),
+			Task:     task.Name,
+			TaskArgs: tc.args,
+		}, outputBuf)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, outputBuf.String(), "Case %d: %s", i, tc.name)
+	}
+
+	task := builtintasks.StatsTask()
+	outputBuf := &bytes.Buffer{}
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"--format", "json", "--task", "verify"},
+	}, outputBuf)
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(outputBuf.Bytes(), &got), outputBuf.String())
+	require.Len(t, got["tasks"], 1)
+	verifySummary := got["tasks"].([]any)[0].(map[string]any)
+	assert.Equal(t, "verify", verifySummary["task"])
+	assert.Equal(t, float64(2), verifySummary["runs"])
+	assert.Equal(t, 0.5, verifySummary["failureRate"])
+	assert.Equal(t, float64(2000), verifySummary["p90Millis"])
+	assert.Nil(t, verifySummary["trend"])
+}
@@ -28,8 +28,10 @@ func Tasks(tasksCfgInfo config.TasksConfigInfo) []godellauncher.Task {
 		ExecTask(),
 		GitHooksTask(),
 		GitHubWikiTask(),
-		IDEATask(),
 		PackagesTask(),
 		TasksConfigTask(tasksCfgInfo),
+		ConfigProvidersTask(),
+		CompletionTask(),
+		StatsTask(),
 	}
 }
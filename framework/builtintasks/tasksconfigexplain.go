@@ -0,0 +1,191 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/palantir/godel/v2/framework/artifactresolver"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/pkg/yamlnode"
+	"github.com/pkg/errors"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// printTasksCfgInfoExplanation prints the same configuration as printTasksCfgInfo, but annotates every plugin,
+// resolver, default task configuration and verify task ordering with its source. Also prints the plugins that were
+// removed by overrides.
+func printTasksCfgInfoExplanation(tasksCfgInfo config.TasksConfigInfo, stdout io.Writer) error {
+	provenance := tasksCfgInfo.TasksConfigProvenance
+	printPluginVersionConflictWarnings(tasksCfgInfo.PluginVersionConflicts, stdout)
+
+	if err := printAnnotatedWithHeader("Built-in plugin configuration", tasksCfgInfo.BuiltinPluginsConfig, func(root *yamlv3.Node) {
+		annotatePluginsConfigNode(root, func(int, string) string {
+			return config.BuiltinSource
+		}, func(string) string {
+			return config.BuiltinSource
+		})
+	}, stdout); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(stdout)
+
+	if len(tasksCfgInfo.IncludedConfigFiles) > 0 {
+		if err := printWithHeader("Included configuration files", tasksCfgInfo.IncludedConfigFiles, stdout); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(stdout)
+	}
+
+	if err := printAnnotatedWithHeader("Fully resolved godel tasks configuration", tasksCfgInfo.TasksConfig, func(root *yamlv3.Node) {
+		if defaultTasksNode := yamlnode.MappingValue(root, "default-tasks"); defaultTasksNode != nil {
+			annotateSequenceItems(yamlnode.MappingValue(defaultTasksNode, "resolvers"), func(_ int, item *yamlv3.Node) string {
+				return provenance.DefaultTaskResolverSources[item.Value]
+			})
+			annotateMappingKeys(yamlnode.MappingValue(defaultTasksNode, "tasks"), provenance.DefaultTaskSources)
+		}
+		annotatePluginsConfigNode(yamlnode.MappingValue(root, "plugins"), func(i int, _ string) string {
+			if i < len(provenance.PluginSources) {
+				return provenance.PluginSources[i]
+			}
+			return ""
+		}, func(resolver string) string {
+			return provenance.PluginResolverSources[resolver]
+		})
+		if verifyTasksNode := yamlnode.MappingValue(root, "verify-tasks"); verifyTasksNode != nil {
+			annotateMappingKeys(yamlnode.MappingValue(verifyTasksNode, "ordering"), provenance.VerifyOrderingSources)
+		}
+	}, stdout); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(stdout)
+
+	if len(provenance.OverriddenPlugins) > 0 {
+		var overriddenPlugins []any
+		for _, overridden := range provenance.OverriddenPlugins {
+			overriddenPlugins = append(overriddenPlugins, overridden.Plugin)
+		}
+		if err := printAnnotatedWithHeader("Plugins removed by overrides", overriddenPlugins, func(root *yamlv3.Node) {
+			annotatePluginNodes(root, func(i int, _ string) string {
+				overridden := provenance.OverriddenPlugins[i]
+				return fmt.Sprintf("%s, overridden by %s", overridden.Source, overridden.OverriddenBy)
+			})
+		}, stdout); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(stdout)
+	}
+
+	builtinResolvers := make(map[string]struct{})
+	for _, resolver := range tasksCfgInfo.BuiltinPluginsConfig.DefaultResolvers {
+		builtinResolvers[resolver] = struct{}{}
+	}
+	return printAnnotatedWithHeader("Plugin configuration for default tasks", tasksCfgInfo.DefaultTasksPluginsConfig, func(root *yamlv3.Node) {
+		annotatePluginsConfigNode(root, func(_ int, id string) string {
+			if source, ok := provenance.DefaultTaskSources[artifactresolver.LocatorIDWithoutVersion(id)]; ok {
+				return fmt.Sprintf("%s, configured by %s", config.BuiltinSource, source)
+			}
+			return config.BuiltinSource
+		}, func(resolver string) string {
+			if _, ok := builtinResolvers[resolver]; ok {
+				return config.BuiltinSource
+			}
+			return provenance.DefaultTaskResolverSources[resolver]
+		})
+	}, stdout)
+}
+
+// printAnnotatedWithHeader prints the YAML representation of the provided value after the provided header. The
+// provided function is called with the root node of the YAML so that it can add comments before it is printed.
+func printAnnotatedWithHeader(header string, in any, annotate func(root *yamlv3.Node), stdout io.Writer) error {
+	printHeader(header, stdout)
+	ymlBytes, err := yaml.Marshal(in)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal YAML")
+	}
+	doc, err := yamlnode.Parse(ymlBytes)
+	if err != nil {
+		return err
+	}
+	annotate(doc.Content[0])
+	annotatedBytes, err := yamlnode.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprint(stdout, string(annotatedBytes))
+	return nil
+}
+
+// annotatePluginsConfigNode annotates the plugins and resolvers of the provided node, which must be the mapping node of
+// a PluginsConfig. pluginSource is called with the index and locator ID of each plugin and resolverSource is called
+// with each resolver.
+func annotatePluginsConfigNode(pluginsCfgNode *yamlv3.Node, pluginSource func(i int, id string) string, resolverSource func(resolver string) string) {
+	if pluginsCfgNode == nil || pluginsCfgNode.Kind != yamlv3.MappingNode {
+		return
+	}
+	annotateSequenceItems(yamlnode.MappingValue(pluginsCfgNode, "resolvers"), func(_ int, item *yamlv3.Node) string {
+		return resolverSource(item.Value)
+	})
+	annotatePluginNodes(yamlnode.MappingValue(pluginsCfgNode, "plugins"), pluginSource)
+}
+
+// annotatePluginNodes annotates the items of the provided sequence node, which must contain plugin configurations. The
+// comment is added to the locator ID of the plugin.
+func annotatePluginNodes(pluginsNode *yamlv3.Node, pluginSource func(i int, id string) string) {
+	if pluginsNode == nil || pluginsNode.Kind != yamlv3.SequenceNode {
+		return
+	}
+	for i, pluginNode := range pluginsNode.Content {
+		idNode := yamlnode.MappingValue(yamlnode.MappingValue(pluginNode, "locator"), "id")
+		if idNode == nil {
+			continue
+		}
+		setSourceComment(idNode, pluginSource(i, idNode.Value))
+	}
+}
+
+func annotateSequenceItems(seqNode *yamlv3.Node, source func(i int, item *yamlv3.Node) string) {
+	if seqNode == nil || seqNode.Kind != yamlv3.SequenceNode {
+		return
+	}
+	for i, item := range seqNode.Content {
+		setSourceComment(item, source(i, item))
+	}
+}
+
+// annotateMappingKeys annotates the entries of the provided mapping node with the source for their key in the provided
+// map. The comment is added to the value if it is a scalar and to the key otherwise.
+func annotateMappingKeys(mappingNode *yamlv3.Node, sources map[string]string) {
+	if mappingNode == nil || mappingNode.Kind != yamlv3.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		keyNode, valNode := mappingNode.Content[i], mappingNode.Content[i+1]
+		commentNode := keyNode
+		if valNode.Kind == yamlv3.ScalarNode {
+			commentNode = valNode
+		}
+		setSourceComment(commentNode, sources[keyNode.Value])
+	}
+}
+
+func setSourceComment(n *yamlv3.Node, source string) {
+	if n == nil || source == "" {
+		return
+	}
+	n.LineComment = "# " + source
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"context"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// WithTimeouts returns the provided tasks with the timeouts that are configured for them. The context provided to a
+// task with a timeout is cancelled with a *godellauncher.TimeoutError cause once the timeout elapses.
+func WithTimeouts(tasks []godellauncher.Task, param godellauncher.TimeoutsParam) []godellauncher.Task {
+	if len(param.Timeouts) == 0 {
+		return tasks
+	}
+	timedTasks := make([]godellauncher.Task, len(tasks))
+	for i, task := range tasks {
+		timeout, ok := param.Timeouts[task.Name]
+		if !ok {
+			timedTasks[i] = task
+			continue
+		}
+		timedTask := task
+		timedTask.RunImpl = nil
+		timedTask.RunContextImpl = func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			ctx, cancel := context.WithTimeoutCause(ctx, timeout, &godellauncher.TimeoutError{
+				Task:    task.Name,
+				Timeout: timeout,
+			})
+			defer cancel()
+			return task.RunContext(ctx, global, stdout)
+		}
+		timedTasks[i] = timedTask
+	}
+	return timedTasks
+}
+
+// CheckTimeouts returns an error if a timeout is configured for a task that is not one of the provided tasks.
+func CheckTimeouts(param godellauncher.TimeoutsParam, tasks []godellauncher.Task) error {
+	taskNames := make(map[string]struct{})
+	for _, task := range tasks {
+		taskNames[task.Name] = struct{}{}
+	}
+	for _, name := range slices.Sorted(maps.Keys(param.Timeouts)) {
+		if _, ok := taskNames[name]; !ok {
+			return errors.Errorf("timeout is configured for unknown task %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeouts(t *testing.T) {
+	blockingTask := godellauncher.Task{
+		Name: "test",
+		Verify: &godellauncher.VerifyOptions{
+			Ordering: 0,
+		},
+		RunContextImpl: func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		},
+	}
+	formatTask := recordingTask("format", nil)
+	formatTask.Verify = &godellauncher.VerifyOptions{
+		Ordering: 1,
+	}
+
+	tasks := builtintasks.WithTimeouts([]godellauncher.Task{blockingTask, formatTask}, godellauncher.TimeoutsParam{
+		Timeouts: map[string]time.Duration{
+			"test": 10 * time.Millisecond,
+		},
+	})
+	err := tasks[0].Run(godellauncher.GlobalConfig{Task: "test"}, io.Discard)
+	require.EqualError(t, err, "task test timed out after 10ms")
+	assert.Equal(t, 124, godellauncher.ExitCode(err))
+
+	// verify runs the remaining tasks after a task times out and exits with the exit code of the timeout
+	verifyTask := builtintasks.VerifyTask(tasks, config.VerifyTasksConfig{})
+	outputBuf := &bytes.Buffer{}
+	err = verifyTask.Run(godellauncher.GlobalConfig{Task: verifyTask.Name}, outputBuf)
+	require.Error(t, err)
+	assert.Equal(t, 124, godellauncher.ExitCode(err))
+	assert.Equal(t, "Running test...\nRunning format...\nran format []\nFailed tasks:\n\ttest\n", outputBuf.String())
+}
+
+func TestCheckTimeouts(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{Name: "test"},
+	}
+	assert.NoError(t, builtintasks.CheckTimeouts(godellauncher.TimeoutsParam{
+		Timeouts: map[string]time.Duration{"test": time.Minute},
+	}, tasks))
+	assert.EqualError(t, builtintasks.CheckTimeouts(godellauncher.TimeoutsParam{
+		Timeouts: map[string]time.Duration{"test": time.Minute, "tset": time.Minute},
+	}, tasks), "timeout is configured for unknown task tset")
+}
@@ -25,9 +25,12 @@ import (
 
 	"github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/pkg/textdiff"
+	"github.com/palantir/godel/v2/pkg/yamlnode"
 	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	yamlv3 "go.yaml.in/yaml/v3"
 	"gopkg.in/yaml.v2"
 )
 
@@ -38,6 +41,8 @@ func UpgradeConfigTask(upgradeTasks []godellauncher.UpgradeConfigTask) godellaun
 		printContentFlagName = "print-content"
 		legacyFlagName       = "legacy"
 		backupFlagName       = "backup"
+		checkFlagName        = "check"
+		onlyFlagName         = "only"
 	)
 
 	var (
@@ -45,6 +50,8 @@ func UpgradeConfigTask(upgradeTasks []godellauncher.UpgradeConfigTask) godellaun
 		printContentFlagVal bool
 		legacyFlagVal       bool
 		backupFlagVal       bool
+		checkFlagVal        bool
+		onlyFlagVal         []string
 	)
 
 	cmd := &cobra.Command{
@@ -56,12 +63,15 @@ func UpgradeConfigTask(upgradeTasks []godellauncher.UpgradeConfigTask) godellaun
 	cmd.Flags().BoolVar(&printContentFlagVal, printContentFlagName, false, "print the content of the changes to stdout in addition to writing them")
 	cmd.Flags().BoolVar(&legacyFlagVal, legacyFlagName, false, "upgrade pre-2.0 legacy configuration")
 	cmd.Flags().BoolVar(&backupFlagVal, backupFlagName, false, "back up files before overwriting or removing them")
+	cmd.Flags().BoolVar(&checkFlagVal, checkFlagName, false, "print a diff of the changes that the upgrade would make without writing them and exit with a non-zero exit code if any configuration would change")
+	cmd.Flags().StringSliceVar(&onlyFlagVal, onlyFlagName, nil, `only run the upgrade tasks for the specified plugins ("group:product" or "product")`)
 
 	cmd.SilenceErrors = true
 	cmd.SilenceUsage = true
 	return godellauncher.Task{
 		Name:        cmd.Use,
 		Description: cmd.Short,
+		Completion:  godellauncher.CompletionFromCobraCommand(cmd),
 		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
 			args := []string{global.Executable}
 			args = append(args, global.Task)
@@ -78,9 +88,19 @@ func UpgradeConfigTask(upgradeTasks []godellauncher.UpgradeConfigTask) godellaun
 					return err
 				}
 				if legacyFlagVal {
+					if checkFlagVal || len(onlyFlagVal) > 0 {
+						return errors.Errorf("--%s and --%s cannot be used with --%s", checkFlagName, onlyFlagName, legacyFlagName)
+					}
 					return runUpgradeLegacyConfig(upgradeTasks, global, projectDir, configDirPath, backupFlagVal, dryRunFlagVal, printContentFlagVal, cmd.OutOrStdout())
 				}
-				return runUpgradeConfig(upgradeTasks, global, projectDir, configDirPath, backupFlagVal, dryRunFlagVal, printContentFlagVal, cmd.OutOrStdout())
+				selectedUpgradeTasks, err := filterUpgradeTasks(upgradeTasks, onlyFlagVal)
+				if err != nil {
+					return err
+				}
+				if checkFlagVal {
+					return runCheckUpgradeConfig(selectedUpgradeTasks, global, projectDir, configDirPath, cmd.OutOrStdout())
+				}
+				return runUpgradeConfig(selectedUpgradeTasks, global, projectDir, configDirPath, backupFlagVal, dryRunFlagVal, printContentFlagVal, cmd.OutOrStdout())
 			}
 
 			rootCmd := godellauncher.CobraCmdToRootCmd(cmd)
@@ -98,9 +118,15 @@ func runUpgradeConfig(
 	stdout io.Writer,
 ) error {
 
+	// changes are staged in a transaction and output is buffered so that changes are only made (and reported) if every
+	// upgrade succeeds
+	txn := newConfigTransaction(filepath.Dir(configDirPath))
+	defer txn.close()
+	outputBuf := &bytes.Buffer{}
+
 	var failedUpgrades []string
 	for _, upgradeTask := range upgradeTasks {
-		changed, upgradedCfgBytes, err := upgradeConfigFile(upgradeTask, global, configDirPath, backup, dryRun, stdout)
+		changed, _, upgradedCfgBytes, err := upgradeConfigFile(upgradeTask, global, configDirPath, backup, dryRun, txn, outputBuf)
 		if err != nil {
 			failedUpgrades = append(failedUpgrades, upgradeError(projectDir, filepath.Join(configDirPath, upgradeTask.ConfigFile), err))
 			continue
@@ -108,17 +134,114 @@ func runUpgradeConfig(
 		if !changed {
 			continue
 		}
-		printUpgradedConfig(upgradeTask.ConfigFile, upgradedCfgBytes, dryRun, printContent, stdout)
+		printUpgradedConfig(upgradeTask.ConfigFile, upgradedCfgBytes, dryRun, printContent, outputBuf)
 	}
+	return finishUpgrade(txn, failedUpgrades, dryRun, outputBuf, stdout)
+}
 
-	if len(failedUpgrades) == 0 {
-		return nil
+// finishUpgrade commits the provided transaction and prints the buffered output if there were no failed upgrades.
+// Otherwise, the transaction is discarded and the failed upgrades are printed.
+func finishUpgrade(txn *configTransaction, failedUpgrades []string, dryRun bool, outputBuf *bytes.Buffer, stdout io.Writer) error {
+	if len(failedUpgrades) > 0 {
+		dryRunPrintln(stdout, dryRun, "Failed to upgrade configuration:")
+		for _, upgrade := range failedUpgrades {
+			dryRunPrintln(stdout, dryRun, "\t"+upgrade)
+		}
+		dryRunPrintln(stdout, dryRun, "No configuration files were modified.")
+		return fmt.Errorf("")
 	}
-	dryRunPrintln(stdout, dryRun, "Failed to upgrade configuration:")
-	for _, upgrade := range failedUpgrades {
-		dryRunPrintln(stdout, dryRun, "\t"+upgrade)
+	if !dryRun {
+		if err := txn.commit(); err != nil {
+			return err
+		}
 	}
-	return fmt.Errorf("")
+	_, _ = io.Copy(stdout, outputBuf)
+	return nil
+}
+
+// filterUpgradeTasks returns the upgrade tasks that match the provided plugin identifiers, which may be of the form
+// "group:product" or "product". If no identifiers are provided, all of the tasks are returned. Returns an error if an
+// identifier does not match any task.
+func filterUpgradeTasks(upgradeTasks []godellauncher.UpgradeConfigTask, only []string) ([]godellauncher.UpgradeConfigTask, error) {
+	if len(only) == 0 {
+		return upgradeTasks, nil
+	}
+	var filtered []godellauncher.UpgradeConfigTask
+	matched := make(map[string]bool)
+	for _, upgradeTask := range upgradeTasks {
+		include := false
+		for _, id := range only {
+			if upgradeTask.ID == id || strings.HasSuffix(upgradeTask.ID, ":"+id) {
+				matched[id] = true
+				include = true
+			}
+		}
+		if include {
+			filtered = append(filtered, upgradeTask)
+		}
+	}
+	var unmatched []string
+	for _, id := range only {
+		if !matched[id] {
+			unmatched = append(unmatched, id)
+		}
+	}
+	if len(unmatched) > 0 {
+		var ids []string
+		for _, upgradeTask := range upgradeTasks {
+			ids = append(ids, upgradeTask.ID)
+		}
+		sort.Strings(ids)
+		return nil, errors.Errorf("no upgrade tasks match %v: valid values are %v", unmatched, ids)
+	}
+	return filtered, nil
+}
+
+// runCheckUpgradeConfig runs the provided upgrade tasks without writing any changes and prints a unified diff of the
+// changes that each upgrade would make. Returns an error if any configuration would change or if any upgrade fails.
+func runCheckUpgradeConfig(
+	upgradeTasks []godellauncher.UpgradeConfigTask,
+	global godellauncher.GlobalConfig,
+	projectDir, configDirPath string,
+	stdout io.Writer,
+) error {
+
+	// changes are staged in a transaction that is never committed
+	txn := newConfigTransaction(filepath.Dir(configDirPath))
+	defer txn.close()
+
+	var changedFiles, failedUpgrades []string
+	for _, upgradeTask := range upgradeTasks {
+		configFilePath := filepath.Join(configDirPath, upgradeTask.ConfigFile)
+		changed, origCfgBytes, upgradedCfgBytes, err := upgradeConfigFile(upgradeTask, global, configDirPath, false, true, txn, io.Discard)
+		if err != nil {
+			failedUpgrades = append(failedUpgrades, upgradeError(projectDir, configFilePath, err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		relPath := configFilePath
+		if rel, err := filepath.Rel(projectDir, configFilePath); err == nil {
+			relPath = filepath.ToSlash(rel)
+		}
+		changedFiles = append(changedFiles, relPath)
+		_, _ = fmt.Fprint(stdout, textdiff.Unified(relPath, relPath+" (upgraded)", string(origCfgBytes), string(upgradedCfgBytes)))
+	}
+
+	if len(failedUpgrades) > 0 {
+		_, _ = fmt.Fprintln(stdout, "Failed to upgrade configuration:")
+		for _, upgrade := range failedUpgrades {
+			_, _ = fmt.Fprintln(stdout, "\t"+upgrade)
+		}
+	}
+	if len(changedFiles) > 0 {
+		_, _ = fmt.Fprintf(stdout, "Configuration is not up-to-date: run \"upgrade-config\" to upgrade %s\n", strings.Join(changedFiles, ", "))
+	}
+	if len(failedUpgrades) > 0 || len(changedFiles) > 0 {
+		return fmt.Errorf("")
+	}
+	return nil
 }
 
 func dryRunPrintln(w io.Writer, dryRun bool, content string) {
@@ -143,44 +266,47 @@ func printUpgradedConfig(cfgFile string, upgradedCfgBytes []byte, dryRun, printC
 	dryRunPrintln(stdout, dryRun, "---")
 }
 
-func upgradeConfigFile(task godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configDir string, backup, dryRun bool, stdout io.Writer) (bool, []byte, error) {
+// upgradeConfigFile runs the provided upgrade task on its configuration file and stages writing the result in the
+// provided transaction. Returns whether the configuration changed, the original configuration and the upgraded
+// configuration.
+func upgradeConfigFile(task godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configDir string, backup, dryRun bool, txn *configTransaction, stdout io.Writer) (bool, []byte, []byte, error) {
 	configFile := filepath.Join(configDir, task.ConfigFile)
-	origConfigBytes, err := os.ReadFile(configFile)
+	origConfigBytes, err := txn.readFile(configFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// if configuration file does not exist, skip
-			return false, nil, nil
+			return false, nil, nil, nil
 		}
-		return false, nil, errors.Wrapf(err, "failed to read config file")
+		return false, nil, nil, errors.Wrapf(err, "failed to read config file")
 	}
 	upgradedConfigBytes, err := task.Run(origConfigBytes, global, stdout)
 	if err != nil {
-		return false, nil, err
+		return false, nil, nil, err
 	}
 	if changed := !bytes.Equal(origConfigBytes, upgradedConfigBytes); !changed {
-		return false, nil, nil
+		return false, nil, nil, nil
 	}
 
 	if backup {
-		if err := backupConfigFile(configFile, dryRun, stdout); err != nil {
-			return false, nil, err
+		if err := backupConfigFile(configFile, dryRun, txn, stdout); err != nil {
+			return false, nil, nil, err
 		}
 	}
-	if !dryRun {
-		if err := os.WriteFile(configFile, upgradedConfigBytes, 0644); err != nil {
-			return false, nil, errors.Wrapf(err, "failed to write upgraded configuration")
-		}
-	}
-	return true, upgradedConfigBytes, nil
+	txn.writeFile(configFile, upgradedConfigBytes)
+	return true, origConfigBytes, upgradedConfigBytes, nil
 }
 
-func backupConfigFile(cfgFilePath string, dryRun bool, stdout io.Writer) error {
+func backupConfigFile(cfgFilePath string, dryRun bool, txn *configTransaction, stdout io.Writer) error {
 	// if file does not exist or it exists but is empty, no need to back up
-	if fi, err := os.Stat(cfgFilePath); os.IsNotExist(err) || (err == nil && fi.Size() == 0) {
+	if content, err := txn.readFile(cfgFilePath); os.IsNotExist(err) || (err == nil && len(content) == 0) {
 		return nil
 	}
 
 	dstPath := filepath.Join(filepath.Dir(cfgFilePath), filepath.Base(cfgFilePath)+".bak")
+	// file exists: move to backup location
+	if err := txn.rename(cfgFilePath, dstPath); err != nil {
+		return errors.Wrapf(err, "failed to rename configuration file")
+	}
 	if dryRun {
 		if wd, err := os.Getwd(); err == nil {
 			if filepath.IsAbs(cfgFilePath) {
@@ -195,27 +321,20 @@ func backupConfigFile(cfgFilePath string, dryRun bool, stdout io.Writer) error {
 			}
 		}
 		dryRunPrintln(stdout, dryRun, fmt.Sprintf("Run: mv %s %s", cfgFilePath, dstPath))
-	} else {
-		// file exists: move to backup location
-		if err := os.Rename(cfgFilePath, dstPath); err != nil {
-			return errors.Wrapf(err, "failed to rename configuration file")
-		}
 	}
 	return nil
 }
 
-func removeConfigFile(cfgFilePath string, dryRun bool, stdout io.Writer) error {
+func removeConfigFile(cfgFilePath string, dryRun bool, txn *configTransaction, stdout io.Writer) error {
 	// if file does not exist, nothing to do
-	if _, err := os.Stat(cfgFilePath); os.IsNotExist(err) {
+	if !txn.exists(cfgFilePath) {
 		return nil
 	}
-	// remove file or print operation
+	if err := txn.remove(cfgFilePath); err != nil {
+		return errors.Wrapf(err, "failed to remove configuration file %s", cfgFilePath)
+	}
 	if dryRun {
 		dryRunPrintln(stdout, dryRun, fmt.Sprintf("Run: rm %s", cfgFilePath))
-		return nil
-	}
-	if err := os.Remove(cfgFilePath); err != nil {
-		return errors.Wrapf(err, "failed to remove configuration file %s", cfgFilePath)
 	}
 	return nil
 }
@@ -238,6 +357,12 @@ func runUpgradeLegacyConfig(
 	stdout io.Writer,
 ) error {
 
+	// changes are staged in a transaction and output is buffered so that changes are only made (and reported) if every
+	// upgrade succeeds
+	txn := newConfigTransaction(filepath.Dir(configDirPath))
+	defer txn.close()
+	outputBuf := &bytes.Buffer{}
+
 	// record all of the original YML files in the directory
 	originalYMLFiles, err := dirYMLFiles(configDirPath)
 	if err != nil {
@@ -254,7 +379,7 @@ func runUpgradeLegacyConfig(
 	var failedUpgrades []string
 	// perform hard-coded one-time upgrades
 	for _, currUpgrader := range hardCodedLegacyUpgraders {
-		if err := currUpgrader.upgradeConfig(configDirPath, backup, dryRun, printContent, stdout); err != nil {
+		if err := currUpgrader.upgradeConfig(configDirPath, backup, dryRun, printContent, txn, outputBuf); err != nil {
 			failedUpgrades = append(failedUpgrades, upgradeError(projectDir, filepath.Join(configDirPath, currUpgrader.configFileName()), err))
 		}
 		knownConfigFiles[currUpgrader.configFileName()] = struct{}{}
@@ -278,7 +403,7 @@ func runUpgradeLegacyConfig(
 			continue
 		}
 		knownConfigFiles[upgradeTask.LegacyConfigFile] = struct{}{}
-		if err := upgradeLegacyConfig(upgradeTask, configDirPath, global, backup, dryRun, printContent, stdout); err != nil {
+		if err := upgradeLegacyConfig(upgradeTask, configDirPath, global, backup, dryRun, printContent, txn, outputBuf); err != nil {
 			failedUpgrades = append(failedUpgrades, upgradeError(projectDir, filepath.Join(configDirPath, upgradeTask.ConfigFile), err))
 			continue
 		}
@@ -291,32 +416,24 @@ func runUpgradeLegacyConfig(
 		}
 		unhandledYMLFiles = append(unhandledYMLFiles, k)
 	}
-	if err := processUnhandledYMLFiles(configDirPath, unhandledYMLFiles, backup, dryRun, stdout); err != nil {
+	if err := processUnhandledYMLFiles(configDirPath, unhandledYMLFiles, backup, dryRun, txn, outputBuf); err != nil {
 		return err
 	}
-
-	if len(failedUpgrades) == 0 {
-		return nil
-	}
-	dryRunPrintln(stdout, dryRun, "Failed to upgrade configuration:")
-	for _, upgrade := range failedUpgrades {
-		dryRunPrintln(stdout, dryRun, "\t"+upgrade)
-	}
-	return fmt.Errorf("")
+	return finishUpgrade(txn, failedUpgrades, dryRun, outputBuf, stdout)
 }
 
 var hardCodedLegacyUpgraders = []hardCodedLegacyUpgrader{
 	&hardCodedLegacyUpgraderImpl{
 		fileName: "exclude.yml",
-		upgradeConfigFn: func(configDirPath string, backup, dryRun, printContent bool, stdout io.Writer) error {
+		upgradeConfigFn: func(configDirPath string, backup, dryRun, printContent bool, txn *configTransaction, stdout io.Writer) error {
 			// godel.yml itself is compatible. Only work to be performed is if "exclude.yml" exists and contains entries
 			// that differ from godel.yml.
 			legacyExcludeFilePath := filepath.Join(configDirPath, "exclude.yml")
-			if _, err := os.Stat(legacyExcludeFilePath); os.IsNotExist(err) {
+			legacyConfigBytes, err := txn.readFile(legacyExcludeFilePath)
+			if os.IsNotExist(err) {
 				// if legacy file does not exist, there is no upgrade to be performed
 				return nil
 			}
-			legacyConfigBytes, err := os.ReadFile(legacyExcludeFilePath)
 			if err != nil {
 				return errors.Wrapf(err, "failed to read legacy configuration file")
 			}
@@ -325,44 +442,44 @@ var hardCodedLegacyUpgraders = []hardCodedLegacyUpgrader{
 				return errors.Wrapf(err, "failed to unmarshal legacy exclude configuration")
 			}
 
-			currentGodelConfig, err := config.ReadGodelConfigFromFile(filepath.Join(configDirPath, "godel.yml"))
+			godelYMLPath := filepath.Join(configDirPath, "godel.yml")
+			currentGodelConfig, err := config.ReadGodelConfigFromFile(godelYMLPath)
 			if err != nil {
 				return errors.Wrapf(err, "failed to read godel configuration")
 			}
-
-			existingNames := make(map[string]struct{})
-			for _, name := range currentGodelConfig.Exclude.Names {
-				existingNames[name] = struct{}{}
-			}
-			existingPaths := make(map[string]struct{})
-			for _, path := range currentGodelConfig.Exclude.Paths {
-				existingPaths[path] = struct{}{}
+			godelCfgBytes, err := txn.readFile(godelYMLPath)
+			if err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to read godel configuration")
 			}
 
-			modified := false
-			for _, legacyName := range excludeCfg.Names {
-				if _, ok := existingNames[legacyName]; ok {
-					continue
+			// update the node representation of godel.yml so that its comments and formatting are preserved
+			upgradedCfgBytes, err := yamlnode.Update(godelCfgBytes, func(doc *yamlv3.Node) error {
+				root := doc.Content[0]
+				if root.Kind != yamlv3.MappingNode {
+					return errors.Errorf("godel configuration must be a YAML mapping")
 				}
-				currentGodelConfig.Exclude.Names = append(currentGodelConfig.Exclude.Names, legacyName)
-				modified = true
-			}
-			for _, legacyPath := range excludeCfg.Paths {
-				if _, ok := existingPaths[legacyPath]; ok {
-					continue
+				excludeNode := yamlnode.MappingValue(root, "exclude")
+				if excludeNode == nil || excludeNode.Kind != yamlv3.MappingNode {
+					excludeNode = yamlnode.NewMapping()
+					yamlnode.SetMappingValue(root, "exclude", excludeNode)
 				}
-				currentGodelConfig.Exclude.Paths = append(currentGodelConfig.Exclude.Paths, legacyPath)
-				modified = true
+				appendNewSequenceValues(excludeNode, "names", currentGodelConfig.Exclude.Names, excludeCfg.Names)
+				appendNewSequenceValues(excludeNode, "paths", currentGodelConfig.Exclude.Paths, excludeCfg.Paths)
+				return nil
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to upgrade godel configuration")
 			}
+			modified := !bytes.Equal(godelCfgBytes, upgradedCfgBytes)
 
 			if backup {
 				// back up old configuration by moving it
-				if err := backupConfigFile(legacyExcludeFilePath, dryRun, stdout); err != nil {
+				if err := backupConfigFile(legacyExcludeFilePath, dryRun, txn, stdout); err != nil {
 					return errors.Wrapf(err, "failed to back up legacy configuration file")
 				}
 			} else {
 				// remove old configuration file
-				if err := removeConfigFile(legacyExcludeFilePath, dryRun, stdout); err != nil {
+				if err := removeConfigFile(legacyExcludeFilePath, dryRun, txn, stdout); err != nil {
 					return errors.Wrapf(err, "failed to remove legacy configuration file")
 				}
 			}
@@ -372,30 +489,56 @@ var hardCodedLegacyUpgraders = []hardCodedLegacyUpgrader{
 				return nil
 			}
 
-			upgradedCfgBytes, err := yaml.Marshal(currentGodelConfig)
-			if err != nil {
-				return errors.Wrapf(err, "failed to marshal upgraded godel configuration")
-			}
-
-			godelYMLPath := filepath.Join(configDirPath, "godel.yml")
 			if backup {
 				// back up godel.yml because it is about to be overwritten
-				if err := backupConfigFile(godelYMLPath, dryRun, stdout); err != nil {
+				if err := backupConfigFile(godelYMLPath, dryRun, txn, stdout); err != nil {
 					return errors.Wrapf(err, "failed to back up godel.yml")
 				}
 			}
-			if !dryRun {
-				// write migrated configuration
-				if err := os.WriteFile(godelYMLPath, upgradedCfgBytes, 0644); err != nil {
-					return errors.Wrapf(err, "failed to write upgraded configuration")
-				}
-			}
+			// write migrated configuration
+			txn.writeFile(godelYMLPath, upgradedCfgBytes)
 			printUpgradedConfig("godel.yml", upgradedCfgBytes, dryRun, printContent, stdout)
 			return nil
 		},
 	},
 }
 
+// appendNewSequenceValues appends the values that are not in existingVals to the sequence that is the value of the
+// provided key in the provided mapping, creating the sequence if it does not exist. New values use the style of the
+// last value in the sequence.
+func appendNewSequenceValues(mapping *yamlv3.Node, key string, existingVals, vals []string) {
+	existing := make(map[string]struct{})
+	for _, val := range existingVals {
+		existing[val] = struct{}{}
+	}
+	var newVals []string
+	for _, val := range vals {
+		if _, ok := existing[val]; ok {
+			continue
+		}
+		existing[val] = struct{}{}
+		newVals = append(newVals, val)
+	}
+	if len(newVals) == 0 {
+		return
+	}
+
+	seqNode := yamlnode.MappingValue(mapping, key)
+	if seqNode == nil || seqNode.Kind != yamlv3.SequenceNode {
+		seqNode = yamlnode.NewSequence()
+		yamlnode.SetMappingValue(mapping, key, seqNode)
+	}
+	var style yamlv3.Style
+	if len(seqNode.Content) > 0 {
+		style = seqNode.Content[len(seqNode.Content)-1].Style
+	}
+	for _, val := range newVals {
+		valNode := yamlnode.NewString(val)
+		valNode.Style = style
+		seqNode.Content = append(seqNode.Content, valNode)
+	}
+}
+
 func dirYMLFiles(inputDir string) ([]string, error) {
 	fis, err := os.ReadDir(inputDir)
 	if err != nil {
@@ -415,43 +558,56 @@ func dirYMLFiles(inputDir string) ([]string, error) {
 
 type hardCodedLegacyUpgrader interface {
 	configFileName() string
-	upgradeConfig(configDirPath string, backup, dryRun, printContent bool, stdout io.Writer) error
+	upgradeConfig(configDirPath string, backup, dryRun, printContent bool, txn *configTransaction, stdout io.Writer) error
 }
 
 type hardCodedLegacyUpgraderImpl struct {
 	fileName        string
-	upgradeConfigFn func(configDirPath string, backup, dryRun, printContent bool, stdout io.Writer) error
+	upgradeConfigFn func(configDirPath string, backup, dryRun, printContent bool, txn *configTransaction, stdout io.Writer) error
 }
 
 func (u *hardCodedLegacyUpgraderImpl) configFileName() string {
 	return u.fileName
 }
 
-func (u *hardCodedLegacyUpgraderImpl) upgradeConfig(configDirPath string, backup, dryRun, printContent bool, stdout io.Writer) error {
-	return u.upgradeConfigFn(configDirPath, backup, dryRun, printContent, stdout)
+func (u *hardCodedLegacyUpgraderImpl) upgradeConfig(configDirPath string, backup, dryRun, printContent bool, txn *configTransaction, stdout io.Writer) error {
+	return u.upgradeConfigFn(configDirPath, backup, dryRun, printContent, txn, stdout)
 }
 
-func upgradeLegacyConfig(upgradeTask godellauncher.UpgradeConfigTask, configDirPath string, global godellauncher.GlobalConfig, backup, dryRun, printContent bool, stdout io.Writer) error {
+func upgradeLegacyConfig(upgradeTask godellauncher.UpgradeConfigTask, configDirPath string, global godellauncher.GlobalConfig, backup, dryRun, printContent bool, txn *configTransaction, stdout io.Writer) error {
 	legacyConfigFilePath := filepath.Join(configDirPath, upgradeTask.LegacyConfigFile)
-	if _, err := os.Stat(legacyConfigFilePath); os.IsNotExist(err) {
+	legacyConfigBytes, err := txn.readFile(legacyConfigFilePath)
+	if os.IsNotExist(err) {
 		// if legacy file does not exist, there is no upgrade to be performed
 		return nil
 	}
-	legacyConfigBytes, err := os.ReadFile(legacyConfigFilePath)
 	if err != nil {
 		return errors.Wrapf(err, "failed to read legacy configuration file")
 	}
 
-	var ymlConfig yaml.MapSlice
-	if err := yaml.Unmarshal(legacyConfigBytes, &ymlConfig); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal YAML configuration")
-	}
-	// add "legacy-config: true" as a key to indicate that this is a legacy configuration
-	ymlConfig = append([]yaml.MapItem{{Key: "legacy-config", Value: true}}, ymlConfig...)
-
-	ymlCfgBytes, err := yaml.Marshal(ymlConfig)
+	// add "legacy-config: true" as the first key to indicate that this is a legacy configuration. The node
+	// representation is used so that the comments in the legacy configuration are provided to the upgrader.
+	ymlCfgBytes, err := yamlnode.Update(legacyConfigBytes, func(doc *yamlv3.Node) error {
+		root := doc.Content[0]
+		if root.Kind != yamlv3.MappingNode {
+			return errors.Errorf("configuration must be a YAML mapping")
+		}
+		// upgraders expect "legacy-config: true" to be the first line, so move any comment at the start of the
+		// document to the first key of the original configuration
+		if len(root.Content) > 0 && doc.HeadComment != "" {
+			root.Content[0].HeadComment = strings.TrimSpace(doc.HeadComment + "\n" + root.Content[0].HeadComment)
+			doc.HeadComment = ""
+		}
+		root.Style = 0
+		yamlnode.InsertMappingValue(root, 0, "legacy-config", &yamlv3.Node{
+			Kind:  yamlv3.ScalarNode,
+			Tag:   "!!bool",
+			Value: "true",
+		})
+		return nil
+	})
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal YAML")
+		return errors.Wrapf(err, "failed to unmarshal YAML configuration")
 	}
 	upgradedCfgBytes, err := upgradeTask.Run(ymlCfgBytes, global, stdout)
 	if err != nil {
@@ -460,12 +616,12 @@ func upgradeLegacyConfig(upgradeTask godellauncher.UpgradeConfigTask, configDirP
 
 	if backup {
 		// back up old configuration
-		if err := backupConfigFile(legacyConfigFilePath, dryRun, stdout); err != nil {
+		if err := backupConfigFile(legacyConfigFilePath, dryRun, txn, stdout); err != nil {
 			return errors.Wrapf(err, "failed to back up legacy configuration file")
 		}
 	} else {
 		// remove old configuration
-		if err := removeConfigFile(legacyConfigFilePath, dryRun, stdout); err != nil {
+		if err := removeConfigFile(legacyConfigFilePath, dryRun, txn, stdout); err != nil {
 			return errors.Wrapf(err, "failed to remove legacy configuration file")
 		}
 	}
@@ -473,7 +629,7 @@ func upgradeLegacyConfig(upgradeTask godellauncher.UpgradeConfigTask, configDirP
 	dstFilePath := filepath.Join(configDirPath, upgradeTask.ConfigFile)
 	if backup {
 		// back up destination file if it already exists
-		if err := backupConfigFile(dstFilePath, dryRun, stdout); err != nil {
+		if err := backupConfigFile(dstFilePath, dryRun, txn, stdout); err != nil {
 			return errors.Wrapf(err, "failed to back up existing configuration file")
 		}
 	}
@@ -483,17 +639,13 @@ func upgradeLegacyConfig(upgradeTask godellauncher.UpgradeConfigTask, configDirP
 		return nil
 	}
 
-	if !dryRun {
-		// write migrated configuration
-		if err := os.WriteFile(dstFilePath, upgradedCfgBytes, 0644); err != nil {
-			return errors.Wrapf(err, "failed to write upgraded configuration")
-		}
-	}
+	// write migrated configuration
+	txn.writeFile(dstFilePath, upgradedCfgBytes)
 	printUpgradedConfig(upgradeTask.ConfigFile, upgradedCfgBytes, dryRun, printContent, stdout)
 	return nil
 }
 
-func processUnhandledYMLFiles(configDir string, unknownYMLFiles []string, backup, dryRun bool, stdout io.Writer) error {
+func processUnhandledYMLFiles(configDir string, unknownYMLFiles []string, backup, dryRun bool, txn *configTransaction, stdout io.Writer) error {
 	if len(unknownYMLFiles) == 0 {
 		return nil
 	}
@@ -501,18 +653,18 @@ func processUnhandledYMLFiles(configDir string, unknownYMLFiles []string, backup
 	var unknownNonEmptyFiles []string
 	for _, currUnknownFile := range unknownYMLFiles {
 		currPath := filepath.Join(configDir, currUnknownFile)
-		bytes, err := os.ReadFile(currPath)
+		bytes, err := txn.readFile(currPath)
 		if err != nil {
 			return errors.Wrapf(err, "failed to read configuration file")
 		}
 		// if unknown file is empty, just remove it or back it up
 		if string(bytes) == "" {
 			if backup {
-				if err := backupConfigFile(currPath, dryRun, stdout); err != nil {
+				if err := backupConfigFile(currPath, dryRun, txn, stdout); err != nil {
 					return err
 				}
 			} else {
-				if err := removeConfigFile(currPath, dryRun, stdout); err != nil {
+				if err := removeConfigFile(currPath, dryRun, txn, stdout); err != nil {
 					return err
 				}
 			}
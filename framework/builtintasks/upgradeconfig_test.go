@@ -0,0 +1,248 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeConfigCheck(t *testing.T) {
+	upgradeTasks := []godellauncher.UpgradeConfigTask{
+		{
+			ID:         "com.palantir.foo:foo-plugin",
+			ConfigFile: "foo.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return bytes.ReplaceAll(configBytes, []byte("old-key"), []byte("new-key")), nil
+			},
+		},
+		{
+			ID:         "com.palantir.bar:bar-plugin",
+			ConfigFile: "bar.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return append([]byte("version: 1\n"), configBytes...), nil
+			},
+		},
+	}
+
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			"check prints diffs and fails if configuration would change",
+			[]string{"--check"},
+			"",
+			`--- godel/config/foo.yml
++++ godel/config/foo.yml (upgraded)
+@@ -1,2 +1,2 @@
+ # comment
+-old-key: value
++new-key: value
+--- godel/config/bar.yml
++++ godel/config/bar.yml (upgraded)
+@@ -1 +1,2 @@
++version: 1
+ bar: value
+Configuration is not up-to-date: run "upgrade-config" to upgrade godel/config/foo.yml, godel/config/bar.yml
+`,
+		},
+		{
+			"only limits the upgrade tasks that are run",
+			[]string{"--check", "--only", "bar-plugin"},
+			"",
+			`--- godel/config/bar.yml
++++ godel/config/bar.yml (upgraded)
+@@ -1 +1,2 @@
++version: 1
+ bar: value
+Configuration is not up-to-date: run "upgrade-config" to upgrade godel/config/bar.yml
+`,
+		},
+		{
+			"only with unknown plugin fails",
+			[]string{"--check", "--only", "baz-plugin"},
+			"no upgrade tasks match [baz-plugin]: valid values are [com.palantir.bar:bar-plugin com.palantir.foo:foo-plugin]",
+			"",
+		},
+	} {
+		projectDir, cleanup, err := dirs.TempDir("", "")
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		defer cleanup()
+
+		configDir := filepath.Join(projectDir, "godel", "config")
+		require.NoError(t, os.MkdirAll(configDir, 0755), "Case %d: %s", i, tc.name)
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755), "Case %d: %s", i, tc.name)
+		fooCfg := "# comment\nold-key: value\n"
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "foo.yml"), []byte(fooCfg), 0644), "Case %d: %s", i, tc.name)
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "bar.yml"), []byte("bar: value\n"), 0644), "Case %d: %s", i, tc.name)
+
+		task := builtintasks.UpgradeConfigTask(upgradeTasks)
+		outputBuf := &bytes.Buffer{}
+		err = task.Run(godellauncher.GlobalConfig{
+			Wrapper:  filepath.Join(projectDir, "godelw"),
+			Task:     task.Name,
+			TaskArgs: tc.args,
+		}, outputBuf)
+		require.Error(t, err, "Case %d: %s", i, tc.name)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.wantOutput, outputBuf.String(), "Case %d: %s", i, tc.name)
+
+		// check mode does not modify configuration
+		gotFooCfg, err := os.ReadFile(filepath.Join(configDir, "foo.yml"))
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, fooCfg, string(gotFooCfg), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestUpgradeConfigCheckUpToDate(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "godel", "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godel", "config", "foo.yml"), []byte("key: value\n"), 0644))
+
+	task := builtintasks.UpgradeConfigTask([]godellauncher.UpgradeConfigTask{
+		{
+			ID:         "com.palantir.foo:foo-plugin",
+			ConfigFile: "foo.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return configBytes, nil
+			},
+		},
+	})
+	outputBuf := &bytes.Buffer{}
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"--check"},
+	}, outputBuf)
+	require.NoError(t, err)
+	assert.Equal(t, "", strings.TrimSpace(outputBuf.String()))
+}
+
+func TestUpgradeConfigFailureDoesNotModifyConfiguration(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	configDir := filepath.Join(projectDir, "godel", "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "bar.yml"), []byte("bar: value\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "foo.yml"), []byte("foo: value\n"), 0644))
+
+	task := builtintasks.UpgradeConfigTask([]godellauncher.UpgradeConfigTask{
+		{
+			ID:         "com.palantir.bar:bar-plugin",
+			ConfigFile: "bar.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return append([]byte("version: 1\n"), configBytes...), nil
+			},
+		},
+		{
+			ID:         "com.palantir.foo:foo-plugin",
+			ConfigFile: "foo.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return nil, errors.Errorf("unsupported configuration")
+			},
+		},
+	})
+	outputBuf := &bytes.Buffer{}
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"--backup"},
+	}, outputBuf)
+	require.Error(t, err)
+	assert.Equal(t, `Failed to upgrade configuration:
+	godel/config/foo.yml: unsupported configuration
+No configuration files were modified.
+`, outputBuf.String())
+
+	// configuration that was upgraded successfully is not written and is not backed up
+	gotBarCfg, err := os.ReadFile(filepath.Join(configDir, "bar.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "bar: value\n", string(gotBarCfg))
+	fis, err := os.ReadDir(filepath.Join(projectDir, "godel"))
+	require.NoError(t, err)
+	require.Len(t, fis, 1)
+	assert.Equal(t, "config", fis[0].Name())
+	fis, err = os.ReadDir(configDir)
+	require.NoError(t, err)
+	var gotFiles []string
+	for _, fi := range fis {
+		gotFiles = append(gotFiles, fi.Name())
+	}
+	assert.Equal(t, []string{"bar.yml", "foo.yml"}, gotFiles)
+}
+
+func TestUpgradeConfigBackup(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	configDir := filepath.Join(projectDir, "godel", "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "foo.yml"), []byte("foo: value\n"), 0644))
+
+	task := builtintasks.UpgradeConfigTask([]godellauncher.UpgradeConfigTask{
+		{
+			ID:         "com.palantir.foo:foo-plugin",
+			ConfigFile: "foo.yml",
+			RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+				return append([]byte("version: 1\n"), configBytes...), nil
+			},
+		},
+	})
+	outputBuf := &bytes.Buffer{}
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"--backup"},
+	}, outputBuf)
+	require.NoError(t, err, outputBuf.String())
+	assert.Equal(t, "Upgraded configuration for foo.yml\n", outputBuf.String())
+
+	gotCfg, err := os.ReadFile(filepath.Join(configDir, "foo.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1\nfoo: value\n", string(gotCfg))
+	gotBackup, err := os.ReadFile(filepath.Join(configDir, "foo.yml.bak"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo: value\n", string(gotBackup))
+
+	fis, err := os.ReadDir(filepath.Join(projectDir, "godel"))
+	require.NoError(t, err)
+	require.Len(t, fis, 1)
+	assert.Equal(t, "config", fis[0].Name())
+}
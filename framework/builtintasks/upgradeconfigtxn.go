@@ -0,0 +1,282 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// configTransaction stages changes to configuration files so that they can be applied together. Changes are recorded
+// in order and the content of written files is staged in memory, so a transaction that is never committed does not
+// modify the file system. Reads performed through the transaction reflect the changes that have been staged. The
+// changes are only made to the configuration files when commit is called, and if applying any change fails the files
+// are restored to their original state.
+type configTransaction struct {
+	// stagingParentDir is the directory in which the staging directory is created. It should be on the same file system
+	// as the configuration files so that staged files can be moved into place atomically.
+	stagingParentDir string
+	// stagingDir is the staging directory, which is created when the transaction is committed.
+	stagingDir string
+	// files contains the content of the paths modified by the transaction. A nil value indicates that the path has been
+	// removed.
+	files map[string][]byte
+	ops   []configTransactionOp
+}
+
+type configTransactionOpKind int
+
+const (
+	txnWrite configTransactionOpKind = iota
+	txnRename
+	txnRemove
+)
+
+type configTransactionOp struct {
+	kind configTransactionOpKind
+	path string
+	// dst is the destination of a rename operation.
+	dst string
+	// content is the content of a write operation.
+	content []byte
+}
+
+func newConfigTransaction(stagingParentDir string) *configTransaction {
+	return &configTransaction{
+		stagingParentDir: stagingParentDir,
+		files:            make(map[string][]byte),
+	}
+}
+
+// readFile returns the content of the provided file with all of the changes staged in the transaction applied. If the
+// file does not exist, the returned error satisfies os.IsNotExist.
+func (t *configTransaction) readFile(path string) ([]byte, error) {
+	if content, ok := t.files[path]; ok {
+		if content == nil {
+			return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+		}
+		return content, nil
+	}
+	return os.ReadFile(path)
+}
+
+// exists returns true if the provided file exists with all of the changes staged in the transaction applied.
+func (t *configTransaction) exists(path string) bool {
+	_, err := t.readFile(path)
+	return err == nil
+}
+
+// writeFile stages writing the provided content to the provided path.
+func (t *configTransaction) writeFile(path string, content []byte) {
+	if content == nil {
+		content = []byte{}
+	}
+	t.files[path] = content
+	t.ops = append(t.ops, configTransactionOp{
+		kind:    txnWrite,
+		path:    path,
+		content: content,
+	})
+}
+
+// rename stages renaming the provided file. The file must exist.
+func (t *configTransaction) rename(src, dst string) error {
+	content, err := t.readFile(src)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", src)
+	}
+	t.files[dst] = content
+	t.files[src] = nil
+	t.ops = append(t.ops, configTransactionOp{
+		kind: txnRename,
+		path: src,
+		dst:  dst,
+	})
+	return nil
+}
+
+// remove stages removing the provided file. The file must exist.
+func (t *configTransaction) remove(path string) error {
+	if !t.exists(path) {
+		return errors.Errorf("%s does not exist", path)
+	}
+	t.files[path] = nil
+	t.ops = append(t.ops, configTransactionOp{
+		kind: txnRemove,
+		path: path,
+	})
+	return nil
+}
+
+// commit applies the staged changes in the order in which they were staged. Written files are staged in a staging
+// directory and moved into place with the mode of the original file (or 0644 if the file did not exist). If applying
+// any change fails, all of the files modified by the transaction are restored to their original state.
+func (t *configTransaction) commit() error {
+	if len(t.ops) == 0 {
+		return nil
+	}
+	originals, err := t.saveOriginals()
+	if err != nil {
+		return err
+	}
+	stagedPaths, err := t.stageWrites(originals)
+	if err != nil {
+		return err
+	}
+	for i, op := range t.ops {
+		var opErr error
+		switch op.kind {
+		case txnWrite:
+			opErr = os.Rename(stagedPaths[i], op.path)
+		case txnRename:
+			opErr = os.Rename(op.path, op.dst)
+		case txnRemove:
+			opErr = os.Remove(op.path)
+		}
+		if opErr == nil {
+			continue
+		}
+		if restoreErr := restoreOriginals(originals); restoreErr != nil {
+			// keep the staging directory because it contains the original content
+			originalsDir := filepath.Join(t.stagingDir, "original")
+			t.stagingDir = ""
+			return errors.Wrapf(opErr, "failed to apply configuration changes and failed to restore original configuration (%v): original configuration files are in %s", restoreErr, originalsDir)
+		}
+		return errors.Wrapf(opErr, "failed to apply configuration changes: original configuration was restored")
+	}
+	return nil
+}
+
+// stageWrites writes the content of the write operations of the transaction into the staging directory and returns the
+// staged paths keyed by the index of the operation. The staged files have the mode of the original files so that the
+// mode of the configuration files is preserved when the staged files are moved into place.
+func (t *configTransaction) stageWrites(originals []originalFile) (map[int]string, error) {
+	modes := make(map[string]os.FileMode)
+	for _, original := range originals {
+		if original.savedPath != "" {
+			modes[original.path] = original.mode
+		}
+	}
+	stagedPaths := make(map[int]string)
+	for i, op := range t.ops {
+		if op.kind != txnWrite {
+			continue
+		}
+		mode, ok := modes[op.path]
+		if !ok {
+			mode = 0644
+		}
+		stagedPath := filepath.Join(t.stagingDir, fmt.Sprintf("%d-%s", i, filepath.Base(op.path)))
+		if err := os.WriteFile(stagedPath, op.content, mode); err != nil {
+			return nil, errors.Wrapf(err, "failed to stage configuration file")
+		}
+		// the mode provided to os.WriteFile is subject to the umask
+		if err := os.Chmod(stagedPath, mode); err != nil {
+			return nil, errors.Wrapf(err, "failed to set mode of staged configuration file")
+		}
+		stagedPaths[i] = stagedPath
+	}
+	return stagedPaths, nil
+}
+
+// close removes the staging directory of the transaction.
+func (t *configTransaction) close() {
+	if t.stagingDir != "" {
+		_ = os.RemoveAll(t.stagingDir)
+		t.stagingDir = ""
+	}
+}
+
+// originalFile records the original state of a file that is modified by a transaction.
+type originalFile struct {
+	path string
+	// savedPath is the path of the copy of the original content. Empty if the file did not exist.
+	savedPath string
+	mode      os.FileMode
+}
+
+// saveOriginals creates the staging directory and copies the original content of all of the files modified by the
+// transaction into it.
+func (t *configTransaction) saveOriginals() ([]originalFile, error) {
+	stagingDir, err := os.MkdirTemp(t.stagingParentDir, ".upgrade-config-")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create staging directory")
+	}
+	t.stagingDir = stagingDir
+	originalsDir := filepath.Join(t.stagingDir, "original")
+	if err := os.Mkdir(originalsDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for original configuration")
+	}
+
+	seen := make(map[string]struct{})
+	var originals []originalFile
+	for _, op := range t.ops {
+		for _, path := range []string{op.path, op.dst} {
+			if _, ok := seen[path]; ok || path == "" {
+				continue
+			}
+			seen[path] = struct{}{}
+
+			original := originalFile{
+				path: path,
+			}
+			fi, err := os.Stat(path)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrapf(err, "failed to stat %s", path)
+			}
+			if err == nil {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to read %s", path)
+				}
+				original.savedPath = filepath.Join(originalsDir, fmt.Sprintf("%d-%s", len(originals), filepath.Base(path)))
+				original.mode = fi.Mode().Perm()
+				if err := os.WriteFile(original.savedPath, content, 0644); err != nil {
+					return nil, errors.Wrapf(err, "failed to save original content of %s", path)
+				}
+			}
+			originals = append(originals, original)
+		}
+	}
+	return originals, nil
+}
+
+// restoreOriginals restores the provided files to their original state.
+func restoreOriginals(originals []originalFile) error {
+	var failed []string
+	for _, original := range originals {
+		if original.savedPath == "" {
+			if err := os.Remove(original.path); err != nil && !os.IsNotExist(err) {
+				failed = append(failed, original.path)
+			}
+			continue
+		}
+		content, err := os.ReadFile(original.savedPath)
+		if err == nil {
+			err = os.WriteFile(original.path, content, original.mode)
+		}
+		if err != nil {
+			failed = append(failed, original.path)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to restore %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigTransactionCommit(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	fooPath := filepath.Join(tmpDir, "foo.yml")
+	barPath := filepath.Join(tmpDir, "bar.yml")
+	require.NoError(t, os.WriteFile(fooPath, []byte("foo: original\n"), 0644))
+	require.NoError(t, os.WriteFile(barPath, []byte("bar: original\n"), 0644))
+
+	txn := newConfigTransaction(tmpDir)
+	defer txn.close()
+	require.NoError(t, txn.rename(fooPath, fooPath+".bak"))
+	txn.writeFile(fooPath, []byte("foo: upgraded\n"))
+	require.NoError(t, txn.remove(barPath))
+
+	// reads reflect staged changes but files are not modified before commit
+	content, err := txn.readFile(fooPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "foo: original\n", string(content))
+	_, err = txn.readFile(barPath)
+	assert.True(t, os.IsNotExist(err))
+	assertFileContent(t, fooPath, "foo: original\n")
+	assertFileContent(t, barPath, "bar: original\n")
+	// changes are staged in memory, so nothing is written before commit
+	assertNoStagingDirs(t, tmpDir)
+
+	require.NoError(t, txn.commit())
+	assertFileContent(t, fooPath, "foo: upgraded\n")
+	assertFileContent(t, fooPath+".bak", "foo: original\n")
+	_, err = os.Stat(barPath)
+	assert.True(t, os.IsNotExist(err))
+
+	txn.close()
+	assertNoStagingDirs(t, tmpDir)
+}
+
+func TestConfigTransactionCommitPreservesMode(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	fooPath := filepath.Join(tmpDir, "foo.yml")
+	barPath := filepath.Join(tmpDir, "bar.yml")
+	require.NoError(t, os.WriteFile(fooPath, []byte("foo: original\n"), 0600))
+	require.NoError(t, os.Chmod(fooPath, 0600))
+
+	txn := newConfigTransaction(tmpDir)
+	defer txn.close()
+	txn.writeFile(fooPath, []byte("foo: upgraded\n"))
+	txn.writeFile(barPath, []byte("bar: new\n"))
+	require.NoError(t, txn.commit())
+
+	fi, err := os.Stat(fooPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	fi, err = os.Stat(barPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+}
+
+func TestConfigTransactionCommitFailureRestoresOriginals(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	fooPath := filepath.Join(tmpDir, "foo.yml")
+	barPath := filepath.Join(tmpDir, "bar.yml")
+	require.NoError(t, os.WriteFile(fooPath, []byte("foo: original\n"), 0644))
+	require.NoError(t, os.WriteFile(barPath, []byte("bar: original\n"), 0644))
+
+	txn := newConfigTransaction(tmpDir)
+	defer txn.close()
+	require.NoError(t, txn.rename(fooPath, fooPath+".bak"))
+	txn.writeFile(fooPath, []byte("foo: upgraded\n"))
+	require.NoError(t, txn.remove(barPath))
+	// writing to a directory that does not exist fails when the transaction is committed
+	txn.writeFile(filepath.Join(tmpDir, "missing", "baz.yml"), []byte("baz: upgraded\n"))
+
+	err = txn.commit()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "original configuration was restored")
+
+	assertFileContent(t, fooPath, "foo: original\n")
+	assertFileContent(t, barPath, "bar: original\n")
+	_, err = os.Stat(fooPath + ".bak")
+	assert.True(t, os.IsNotExist(err))
+
+	txn.close()
+	assertNoStagingDirs(t, tmpDir)
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(content))
+}
+
+func assertNoStagingDirs(t *testing.T, dir string) {
+	matches, err := filepath.Glob(filepath.Join(dir, ".upgrade-config-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// UserTasks returns the tasks for the provided user-defined tasks. The steps of composite tasks are resolved using the
+// tasks returned by the provided function when the task is run, so steps can run any task (including "verify" and
+// other user-defined tasks).
+func UserTasks(param godellauncher.UserTasksParam, allTasks func() []godellauncher.Task) []godellauncher.Task {
+	var tasks []godellauncher.Task
+	for _, userTask := range param.Tasks {
+		var task godellauncher.Task
+		if len(userTask.Steps) != 0 {
+			task = compositeTask(userTask, allTasks)
+		} else {
+			task = scriptTask(userTask)
+		}
+		task.Verify = userTask.Verify
+		task.Source = godellauncher.TaskSource{
+			Type: godellauncher.GodelConfigTaskSource,
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// CheckUserTasks returns an error if one of the provided user-defined tasks has the same name as one of the provided
+// tasks that is not a user-defined task (such as a builtin task or a plugin task).
+func CheckUserTasks(param godellauncher.UserTasksParam, tasks []godellauncher.Task) error {
+	taskSources := make(map[string]godellauncher.TaskSourceType)
+	for _, task := range tasks {
+		if task.Source.Type == godellauncher.GodelConfigTaskSource {
+			continue
+		}
+		taskSources[task.Name] = task.Source.Type
+	}
+	for _, userTask := range param.Tasks {
+		if sourceType, ok := taskSources[userTask.Name]; ok {
+			return errors.Errorf(`task %s in the "tasks" configuration of godel.yml has the same name as a %s task: user-defined tasks cannot replace tasks`, userTask.Name, sourceType)
+		}
+	}
+	return nil
+}
+
+func compositeTask(userTask godellauncher.UserTaskParam, allTasks func() []godellauncher.Task) godellauncher.Task {
+	var stepNames []string
+	longParts := []string{"Runs the following tasks in order:"}
+	for _, step := range userTask.Steps {
+		stepNames = append(stepNames, step.String())
+		longParts = append(longParts, "  "+step.String())
+	}
+	description := userTask.Description
+	if description == "" {
+		description = "Runs " + strings.Join(stepNames, ", ")
+	}
+
+	var globalCfg godellauncher.GlobalConfig
+	return godellauncher.CobraCLIContextTask(&cobra.Command{
+		Use:   userTask.Name,
+		Short: description,
+		Long:  strings.Join(longParts, "\n"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks := make(map[string]godellauncher.Task)
+			for _, task := range allTasks() {
+				tasks[task.Name] = task
+			}
+			// verify that all of the steps exist before running any of them
+			for _, step := range userTask.Steps {
+				if _, ok := tasks[step.Task]; !ok {
+					return errors.Errorf("task %s runs unknown task %s", userTask.Name, step.Task)
+				}
+			}
+			for _, step := range userTask.Steps {
+				task := tasks[step.Task]
+				stepGlobal := globalCfg
+				stepGlobal.Task = step.Task
+				stepGlobal.TaskArgs = step.Args
+
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Running %s...\n", step)
+				if err := task.RunContext(cmd.Context(), stepGlobal, cmd.OutOrStdout()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, &globalCfg)
+}
+
+func scriptTask(userTask godellauncher.UserTaskParam) godellauncher.Task {
+	description := userTask.Description
+	if description == "" {
+		description = fmt.Sprintf("Runs %q", userTask.Script)
+	}
+
+	var globalCfg godellauncher.GlobalConfig
+	return godellauncher.CobraCLIContextTask(&cobra.Command{
+		Use:   userTask.Name,
+		Short: description,
+		Long:  "Runs the following shell command in the project directory:\n  " + userTask.Script,
+		// all arguments are provided to the script
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectDir, err := globalCfg.ProjectDir()
+			if err != nil {
+				return err
+			}
+			return runShellCommand(cmd.Context(), userTask.Script, userTask.Name, args, projectDir, nil, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}, &globalCfg)
+}
+
+// runShellCommand runs the provided command using "sh -c" in the provided directory. The provided name is used as "$0"
+// so that the provided arguments are "$1", "$2", etc. The provided environment variables are added to the environment
+// of the current process. If the command exits with a non-zero exit code, the returned error is an
+// *godellauncher.ExitCodeError with that exit code. The command is run in its own process group, which is stopped if
+// the provided context is done before the command exits.
+func runShellCommand(ctx context.Context, command, name string, args []string, dir string, env []string, stdout, stderr io.Writer) error {
+	execCmd := exec.Command("sh", append([]string{"-c", command, name}, args...)...)
+	execCmd.Dir = dir
+	if len(env) != 0 {
+		execCmd.Env = append(os.Environ(), env...)
+	}
+	execCmd.Stdout = stdout
+	execCmd.Stderr = stderr
+	execCmd.Stdin = os.Stdin
+	if err := godellauncher.RunCommand(ctx, execCmd); err != nil {
+		if exitCodeErr := godellauncher.ExitCodeErrorFromExec(err, err.Error()); exitCodeErr != nil {
+			return exitCodeErr
+		}
+		return err
+	}
+	return nil
+}
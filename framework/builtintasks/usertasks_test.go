@@ -0,0 +1,177 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTasksComposite(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		steps      []godellauncher.UserTaskStepParam
+		wantErr    string
+		wantOutput string
+	}{
+		{
+			"steps are run in order with their arguments",
+			[]godellauncher.UserTaskStepParam{
+				{Task: "format"},
+				{Task: "test", Args: []string{"./..."}},
+			},
+			"",
+			"Running format...\nran format []\nRunning test ./......\nran test [./...]\n",
+		},
+		{
+			"steps after a failed step are not run",
+			[]godellauncher.UserTaskStepParam{
+				{Task: "fail"},
+				{Task: "test"},
+			},
+			"fail failed",
+			"Running fail...\nran fail []\n",
+		},
+		{
+			"no steps are run if a step is unknown",
+			[]godellauncher.UserTaskStepParam{
+				{Task: "format"},
+				{Task: "unknown"},
+			},
+			"task release runs unknown task unknown",
+			"",
+		},
+	} {
+		var allTasks []godellauncher.Task
+		userTasks := builtintasks.UserTasks(godellauncher.UserTasksParam{
+			Tasks: []godellauncher.UserTaskParam{
+				{
+					Name:  "release",
+					Steps: tc.steps,
+				},
+			},
+		}, func() []godellauncher.Task {
+			return allTasks
+		})
+		require.Len(t, userTasks, 1, "Case %d: %s", i, tc.name)
+		allTasks = append(allTasks, recordingTask("format", nil), recordingTask("test", nil), recordingTask("fail", errors.New("fail failed")))
+		allTasks = append(allTasks, userTasks...)
+
+		task := userTasks[0]
+		outputBuf := &bytes.Buffer{}
+		err := task.Run(godellauncher.GlobalConfig{
+			Task: task.Name,
+		}, outputBuf)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		} else {
+			assert.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.wantOutput, outputBuf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestUserTasksScript(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	userTasks := builtintasks.UserTasks(godellauncher.UserTasksParam{
+		Tasks: []godellauncher.UserTaskParam{
+			{
+				Name:   "lint",
+				Script: `echo "$0 $# $1"; pwd`,
+				Verify: &godellauncher.VerifyOptions{
+					Ordering: 5,
+				},
+			},
+		},
+	}, nil)
+	require.Len(t, userTasks, 1)
+	task := userTasks[0]
+	assert.Equal(t, "lint", task.Name)
+	assert.Equal(t, `Runs "echo \"$0 $# $1\"; pwd"`, task.Description)
+	assert.Equal(t, &godellauncher.VerifyOptions{Ordering: 5}, task.Verify)
+
+	outputBuf := &bytes.Buffer{}
+	err = task.Run(godellauncher.GlobalConfig{
+		Wrapper:  filepath.Join(projectDir, "godelw"),
+		Task:     task.Name,
+		TaskArgs: []string{"--fix", "./..."},
+	}, outputBuf)
+	require.NoError(t, err)
+
+	wantProjectDir, err := filepath.EvalSymlinks(projectDir)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(outputBuf.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "Output: %s", outputBuf.String())
+	assert.Equal(t, "lint 2 --fix", lines[0])
+	gotProjectDir, err := filepath.EvalSymlinks(lines[1])
+	require.NoError(t, err)
+	assert.Equal(t, wantProjectDir, gotProjectDir)
+}
+
+func TestUserTasksScriptExitCode(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "godelw"), nil, 0755))
+
+	userTasks := builtintasks.UserTasks(godellauncher.UserTasksParam{
+		Tasks: []godellauncher.UserTaskParam{
+			{
+				Name:   "lint",
+				Script: "exit 3",
+			},
+		},
+	}, nil)
+	require.Len(t, userTasks, 1)
+
+	err = userTasks[0].Run(godellauncher.GlobalConfig{
+		Wrapper: filepath.Join(projectDir, "godelw"),
+		Task:    "lint",
+	}, &bytes.Buffer{})
+	require.EqualError(t, err, "exit status 3")
+	assert.Equal(t, 3, godellauncher.ExitCode(err))
+}
+
+func TestCheckUserTasks(t *testing.T) {
+	param := godellauncher.UserTasksParam{
+		Tasks: []godellauncher.UserTaskParam{
+			{Name: "test", Script: "echo test"},
+		},
+	}
+	userTasks := builtintasks.UserTasks(param, nil)
+	assert.NoError(t, builtintasks.CheckUserTasks(param, append([]godellauncher.Task{
+		{Name: "verify"},
+	}, userTasks...)))
+	assert.EqualError(t, builtintasks.CheckUserTasks(param, append([]godellauncher.Task{
+		{Name: "test", Source: godellauncher.TaskSource{Type: godellauncher.PluginTaskSource}},
+	}, userTasks...)), `task test in the "tasks" configuration of godel.yml has the same name as a plugin task: user-defined tasks cannot replace tasks`)
+	assert.EqualError(t, builtintasks.CheckUserTasks(param, append([]godellauncher.Task{
+		{Name: "test"},
+	}, userTasks...)), `task test in the "tasks" configuration of godel.yml has the same name as a builtin task: user-defined tasks cannot replace tasks`)
+}
@@ -15,6 +15,7 @@
 package builtintasks
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/trace"
 	"github.com/spf13/cobra"
 )
 
@@ -69,7 +71,8 @@ func VerifyTask(tasks []godellauncher.Task, verifyTasksConfig config.VerifyTasks
 	return godellauncher.Task{
 		Name:        cmd.Use,
 		Description: cmd.Short,
-		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+		Completion:  godellauncher.CompletionFromCobraCommand(cmd),
+		RunContextImpl: func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
 			args := []string{global.Executable}
 			args = append(args, global.Task)
 			args = append(args, global.TaskArgs...)
@@ -77,6 +80,7 @@ func VerifyTask(tasks []godellauncher.Task, verifyTasksConfig config.VerifyTasks
 
 			cmd.RunE = func(cmd *cobra.Command, args []string) error {
 				var failedChecks []string
+				var firstFailedErr error
 				for _, task := range verifyTasks {
 					// skip the task
 					if *skipVerifyTasks[task.Name] {
@@ -104,7 +108,18 @@ func VerifyTask(tasks []godellauncher.Task, verifyTasksConfig config.VerifyTasks
 					taskGlobal.TaskArgs = taskFlagArgs
 
 					_, _ = fmt.Fprintf(stdout, "Running %s...\n", task.Name)
-					if err := task.Run(taskGlobal, stdout); err != nil {
+					span := trace.StartSpan("verify "+task.Name, "verify")
+					span.SetArg("args", taskFlagArgs)
+					err := task.RunContext(ctx, taskGlobal, stdout)
+					span.End()
+					if err != nil {
+						if ctx.Err() != nil {
+							// do not run the remaining tasks if verify was cancelled
+							return context.Cause(ctx)
+						}
+						if firstFailedErr == nil {
+							firstFailedErr = err
+						}
 						var applyArgs []string
 						if *applyVar {
 							applyArgs = task.Verify.ApplyTrueArgs
@@ -122,14 +137,18 @@ func VerifyTask(tasks []godellauncher.Task, verifyTasksConfig config.VerifyTasks
 						msgParts = append(msgParts, "\t"+check)
 					}
 					_, _ = fmt.Fprintln(stdout, strings.Join(msgParts, "\n"))
-					return fmt.Errorf("")
+					// the exit code of verify is the exit code of the first task that failed. The error message is empty
+					// because the failed tasks have already been printed.
+					return &godellauncher.ExitCodeError{
+						Code: godellauncher.ExitCode(firstFailedErr),
+					}
 				}
 				return nil
 			}
 
 			rootCmd := godellauncher.CobraCmdToRootCmd(cmd)
 			rootCmd.SetOutput(stdout)
-			return rootCmd.Execute()
+			return rootCmd.ExecuteContext(ctx)
 		},
 	}
 }
@@ -0,0 +1,81 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyExitCode(t *testing.T) {
+	verifyTask := func(name string, ordering int, runErr error) godellauncher.Task {
+		task := recordingTask(name, runErr)
+		task.Verify = &godellauncher.VerifyOptions{
+			Ordering: ordering,
+		}
+		return task
+	}
+
+	for i, tc := range []struct {
+		name         string
+		tasks        []godellauncher.Task
+		wantExitCode int
+	}{
+		{
+			"all tasks succeed",
+			[]godellauncher.Task{
+				verifyTask("format", 0, nil),
+				verifyTask("test", 1, nil),
+			},
+			0,
+		},
+		{
+			"exit code of first failed task in verify order is used",
+			[]godellauncher.Task{
+				verifyTask("test", 2, &godellauncher.ExitCodeError{Code: 4}),
+				verifyTask("check", 1, &godellauncher.ExitCodeError{Code: 3}),
+				verifyTask("format", 0, nil),
+			},
+			3,
+		},
+		{
+			"failed task without exit code",
+			[]godellauncher.Task{
+				verifyTask("format", 0, errors.New("format failed")),
+				verifyTask("test", 1, &godellauncher.ExitCodeError{Code: 4}),
+			},
+			1,
+		},
+	} {
+		task := builtintasks.VerifyTask(tc.tasks, config.VerifyTasksConfig{})
+		err := task.Run(godellauncher.GlobalConfig{
+			Task: task.Name,
+		}, &bytes.Buffer{})
+		if tc.wantExitCode == 0 {
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.Error(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, "", err.Error(), "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantExitCode, godellauncher.ExitCode(err), "Case %d: %s", i, tc.name)
+	}
+}
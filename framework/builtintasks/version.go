@@ -16,6 +16,8 @@ package builtintasks
 
 import (
 	"fmt"
+	"runtime"
+	"runtime/debug"
 
 	"github.com/palantir/godel/v2/framework/godel"
 	"github.com/palantir/godel/v2/framework/godellauncher"
@@ -24,13 +26,64 @@ import (
 
 var Version = "unspecified"
 
+// versionOutput is the output of "version --format json".
+type versionOutput struct {
+	// Name is the name of the application ("godel").
+	Name string `json:"name"`
+	// Version is the version of gödel.
+	Version string `json:"version"`
+	// GoVersion is the version of Go used to build gödel.
+	GoVersion string `json:"goVersion"`
+	// OS and Arch are the operating system and architecture that gödel was built for.
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// BuildInfo is the build information embedded in the gödel executable. Nil if it is not available.
+	BuildInfo *versionBuildInfo `json:"buildInfo"`
+}
+
+type versionBuildInfo struct {
+	// Path is the package path of the main package.
+	Path string `json:"path"`
+	// Module is the path of the main module.
+	Module string `json:"module"`
+	// Settings are the build settings (for example, "vcs.revision" and "GOOS").
+	Settings map[string]string `json:"settings"`
+}
+
 func VersionTask() godellauncher.Task {
-	return godellauncher.CobraCLITask(&cobra.Command{
+	var formatFlagVal *string
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: fmt.Sprintf("Print %s version", godel.AppName),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), godel.VersionOutput())
-			return nil
+			jsonOutput, err := isJSONFormat(*formatFlagVal)
+			if err != nil {
+				return err
+			}
+			if !jsonOutput {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), godel.VersionOutput())
+				return nil
+			}
+			out := versionOutput{
+				Name:      godel.AppName,
+				Version:   godel.Version,
+				GoVersion: runtime.Version(),
+				OS:        runtime.GOOS,
+				Arch:      runtime.GOARCH,
+			}
+			if buildInfo, ok := debug.ReadBuildInfo(); ok {
+				out.BuildInfo = &versionBuildInfo{
+					Path:     buildInfo.Path,
+					Module:   buildInfo.Main.Path,
+					Settings: make(map[string]string),
+				}
+				for _, setting := range buildInfo.Settings {
+					out.BuildInfo.Settings[setting.Key] = setting.Value
+				}
+			}
+			return printJSON(out, cmd.OutOrStdout())
 		},
-	}, nil)
+	}
+	formatFlagVal = addFormatFlag(cmd.Flags())
+	return godellauncher.CobraCLITask(cmd, nil)
 }
@@ -0,0 +1,46 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtintasks_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionTaskJSON(t *testing.T) {
+	task := builtintasks.VersionTask()
+	outputBuf := &bytes.Buffer{}
+	err := task.Run(godellauncher.GlobalConfig{
+		Task:     task.Name,
+		TaskArgs: []string{"--format", "json"},
+	}, outputBuf)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(outputBuf.Bytes(), &got), outputBuf.String())
+	assert.Equal(t, "godel", got["name"])
+	assert.NotEmpty(t, got["version"])
+	assert.Equal(t, runtime.Version(), got["goVersion"])
+	assert.Equal(t, runtime.GOOS, got["os"])
+	assert.Equal(t, runtime.GOARCH, got["arch"])
+	assert.Contains(t, got, "buildInfo")
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// AliasesConfig is the configuration for the task aliases. The key is the name of the alias and the value is the name
+// of the task followed by its default arguments separated by whitespace.
+type AliasesConfig map[string]string
+
+// ToParam returns the aliases sorted by name. Returns an error if the name of an alias is invalid or if an alias does
+// not specify a task.
+func (c AliasesConfig) ToParam() ([]godellauncher.Alias, error) {
+	var aliases []godellauncher.Alias
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		if err := validateTaskName(name); err != nil {
+			return nil, errors.Wrapf(err, "invalid alias")
+		}
+		parts := strings.Fields(c[name])
+		if len(parts) == 0 {
+			return nil, errors.Errorf("alias %s must specify a task", name)
+		}
+		var args []string
+		if len(parts) > 1 {
+			args = parts[1:]
+		}
+		aliases = append(aliases, godellauncher.Alias{
+			Name: name,
+			Task: parts[0],
+			Args: args,
+		})
+	}
+	return aliases, nil
+}
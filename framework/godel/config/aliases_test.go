@@ -0,0 +1,72 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasesConfig_ToParam(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		cfg     config.AliasesConfig
+		want    []godellauncher.Alias
+		wantErr string
+	}{
+		{
+			"aliases are sorted by name and arguments are split on whitespace",
+			config.AliasesConfig{
+				"it":  "test  --tags=integration ./...",
+				"dry": "dist --dry-run",
+				"t":   "test",
+			},
+			[]godellauncher.Alias{
+				{Name: "dry", Task: "dist", Args: []string{"--dry-run"}},
+				{Name: "it", Task: "test", Args: []string{"--tags=integration", "./..."}},
+				{Name: "t", Task: "test"},
+			},
+			"",
+		},
+		{
+			"alias without task",
+			config.AliasesConfig{
+				"it": " ",
+			},
+			nil,
+			"alias it must specify a task",
+		},
+		{
+			"invalid alias name",
+			config.AliasesConfig{
+				"-it": "test",
+			},
+			nil,
+			`invalid alias: invalid task name "-it": must be non-empty, cannot contain whitespace and cannot start with "-"`,
+		},
+	} {
+		got, err := tc.cfg.ToParam()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
@@ -16,9 +16,11 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/palantir/godel/v2/framework/artifactresolver"
 	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/palantir/godel/v2/pkg/osarch"
 	"github.com/pkg/errors"
 )
@@ -69,19 +71,91 @@ func ToConfigProviderLocatorWithResolverConfig(in ConfigProviderLocatorWithResol
 	return v0.ConfigProviderLocatorWithResolverConfig(in)
 }
 
-// ToParam converts the configuration into a LocatorWithResolverParam. Any checksums that exist are put in a map where
-// the key is the current OS/Arch.
-func (c *ConfigProviderLocatorWithResolverConfig) ToParam() (artifactresolver.LocatorWithResolverParam, error) {
-	providerLocatorCfg := ConfigProviderLocatorConfig(c.Locator)
-	locatorCfg, err := providerLocatorCfg.ToLocatorConfig()
+// ToParam converts the configuration into a ConfigProviderParam. Exactly one of the locator, path and git
+// configuration must be specified. If the locator specifies a checksum, it is used as the OS-independent checksum of
+// the configuration provider.
+func (c *ConfigProviderLocatorWithResolverConfig) ToParam() (godellauncher.ConfigProviderParam, error) {
+	numSources := 0
+	hasLocator := c.Locator != (v0.ConfigProviderLocatorConfig{})
+	for _, isSet := range []bool{hasLocator, c.Path != "", c.Git != nil} {
+		if isSet {
+			numSources++
+		}
+	}
+	if numSources != 1 {
+		return godellauncher.ConfigProviderParam{}, errors.Errorf(`configuration provider must specify exactly one of "locator", "path" or "git", but specified %d`, numSources)
+	}
+	if !hasLocator && c.Resolver != "" {
+		return godellauncher.ConfigProviderParam{}, errors.Errorf(`"resolver" can only be specified for configuration providers that specify a "locator"`)
+	}
+	refresh, err := toConfigProviderRefreshParam(c.Refresh)
 	if err != nil {
-		return artifactresolver.LocatorWithResolverParam{}, err
+		return godellauncher.ConfigProviderParam{}, err
 	}
+
+	switch {
+	case c.Path != "":
+		if c.Refresh != "" {
+			return godellauncher.ConfigProviderParam{}, errors.Errorf(`"refresh" cannot be specified for configuration providers that specify a "path"`)
+		}
+		return godellauncher.ConfigProviderParam{
+			LocalPath: c.Path,
+		}, nil
+	case c.Git != nil:
+		if c.Git.URL == "" || c.Git.Path == "" {
+			return godellauncher.ConfigProviderParam{}, errors.Errorf(`git configuration provider must specify "url" and "path"`)
+		}
+		ref := c.Git.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+		return godellauncher.ConfigProviderParam{
+			Git: &godellauncher.GitConfigProviderParam{
+				URL:  c.Git.URL,
+				Ref:  ref,
+				Path: c.Git.Path,
+			},
+			Refresh: refresh,
+		}, nil
+	}
+
+	// configuration providers are OS-independent, so the checksum is not keyed by OS/Arch in the locator: it is
+	// verified separately by the configuration provider resolution logic
 	cfg := LocatorWithResolverConfig{
-		Locator:  v0.LocatorConfig(locatorCfg),
+		Locator: v0.LocatorConfig{
+			ID: c.Locator.ID,
+		},
 		Resolver: c.Resolver,
 	}
-	return cfg.ToParam()
+	artifactParam, err := cfg.ToParam()
+	if err != nil {
+		return godellauncher.ConfigProviderParam{}, err
+	}
+	return godellauncher.ConfigProviderParam{
+		Artifact: &artifactParam,
+		Checksum: c.Locator.Checksum,
+		Refresh:  refresh,
+	}, nil
+}
+
+// toConfigProviderRefreshParam converts the "refresh" value of a configuration provider, which must be blank, "always"
+// or a positive duration, into a ConfigProviderRefreshParam.
+func toConfigProviderRefreshParam(refresh string) (godellauncher.ConfigProviderRefreshParam, error) {
+	switch refresh {
+	case "":
+		return godellauncher.ConfigProviderRefreshParam{}, nil
+	case "always":
+		return godellauncher.ConfigProviderRefreshParam{
+			Always: true,
+		}, nil
+	}
+	ttl, err := time.ParseDuration(refresh)
+	if err != nil || ttl <= 0 {
+		return godellauncher.ConfigProviderRefreshParam{}, errors.Errorf(`invalid "refresh" value %q: must be "always" or a positive duration such as "24h"`, refresh)
+	}
+	return godellauncher.ConfigProviderRefreshParam{
+		TTL: ttl,
+	}, nil
 }
 
 type LocatorConfig v0.LocatorConfig
@@ -117,9 +191,6 @@ func (c *LocatorConfig) ToParam() (artifactresolver.LocatorParam, error) {
 	return param, nil
 }
 
-// placeholder OS/Arch used for config provider checksums
-var configProviderOSArch = osarch.Current()
-
 // ConfigProviderLocatorConfig is the configuration for a locator for a configuration provider. It differs from a
 // LocatorConfig in that only a single checksum can be specified.
 type ConfigProviderLocatorConfig v0.ConfigProviderLocatorConfig
@@ -127,18 +198,3 @@ type ConfigProviderLocatorConfig v0.ConfigProviderLocatorConfig
 func ToConfigProviderLocatorConfig(in ConfigProviderLocatorConfig) v0.ConfigProviderLocatorConfig {
 	return v0.ConfigProviderLocatorConfig(in)
 }
-
-// ToLocatorConfig translates the ConfigProviderLocatorConfig into a LocatorConfig where the checksum (if any exists) is
-// keyed as the current OS/Arch.
-func (c *ConfigProviderLocatorConfig) ToLocatorConfig() (LocatorConfig, error) {
-	var checksums map[string]string
-	if c.Checksum != "" {
-		checksums = map[string]string{
-			configProviderOSArch.String(): c.Checksum,
-		}
-	}
-	return LocatorConfig{
-		ID:        c.ID,
-		Checksums: checksums,
-	}, nil
-}
@@ -16,8 +16,11 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/palantir/godel/v2/framework/artifactresolver"
 	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
@@ -208,3 +211,147 @@ plugins:
 	_, err = cfg.ToParam()
 	assert.EqualError(t, err, `invalid locator: locator ID must consist of 3 colon-delimited components ([group]:[product]:[version]), but had 2: "tester:1.0.0"`)
 }
+
+func TestTasksConfigProvidersConfig_ToParam(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		cfg     string
+		want    godellauncher.ConfigProviderParam
+		wantErr string
+	}{
+		{
+			"local path",
+			`
+providers:
+  - path: godel/config/provided.yml
+`,
+			godellauncher.ConfigProviderParam{
+				LocalPath: "godel/config/provided.yml",
+			},
+			"",
+		},
+		{
+			"locator with checksum",
+			`
+providers:
+  - locator:
+      id: com.palantir:provider:1.0.0
+      checksum: 2a2ab6a4ab8e7d3c5bb2b6e49a2d6d5be3ea06b7b18d5ad5a2e8d1a4a5b2d8e1
+`,
+			godellauncher.ConfigProviderParam{
+				Artifact: &artifactresolver.LocatorWithResolverParam{
+					LocatorWithChecksums: artifactresolver.LocatorParam{
+						Locator: artifactresolver.Locator{
+							Group:   "com.palantir",
+							Product: "provider",
+							Version: "1.0.0",
+						},
+					},
+				},
+				Checksum: "2a2ab6a4ab8e7d3c5bb2b6e49a2d6d5be3ea06b7b18d5ad5a2e8d1a4a5b2d8e1",
+			},
+			"",
+		},
+		{
+			"git repository with default ref",
+			`
+providers:
+  - git:
+      url: https://github.com/palantir/godel-config.git
+      path: godel.yml
+`,
+			godellauncher.ConfigProviderParam{
+				Git: &godellauncher.GitConfigProviderParam{
+					URL:  "https://github.com/palantir/godel-config.git",
+					Ref:  "HEAD",
+					Path: "godel.yml",
+				},
+			},
+			"",
+		},
+		{
+			"git repository with refresh TTL",
+			`
+providers:
+  - git:
+      url: https://github.com/palantir/godel-config.git
+      ref: main
+      path: godel.yml
+    refresh: 24h
+`,
+			godellauncher.ConfigProviderParam{
+				Git: &godellauncher.GitConfigProviderParam{
+					URL:  "https://github.com/palantir/godel-config.git",
+					Ref:  "main",
+					Path: "godel.yml",
+				},
+				Refresh: godellauncher.ConfigProviderRefreshParam{
+					TTL: 24 * time.Hour,
+				},
+			},
+			"",
+		},
+		{
+			"invalid refresh",
+			`
+providers:
+  - locator:
+      id: com.palantir:provider:1.0.0-SNAPSHOT
+    refresh: sometimes
+`,
+			godellauncher.ConfigProviderParam{},
+			`invalid "refresh" value "sometimes": must be "always" or a positive duration such as "24h"`,
+		},
+		{
+			"refresh for local path",
+			`
+providers:
+  - path: godel/config/provided.yml
+    refresh: always
+`,
+			godellauncher.ConfigProviderParam{},
+			`"refresh" cannot be specified for configuration providers that specify a "path"`,
+		},
+		{
+			"multiple sources",
+			`
+providers:
+  - locator:
+      id: com.palantir:provider:1.0.0
+    path: godel/config/provided.yml
+`,
+			godellauncher.ConfigProviderParam{},
+			`configuration provider must specify exactly one of "locator", "path" or "git", but specified 2`,
+		},
+		{
+			"resolver without locator",
+			`
+providers:
+  - path: godel/config/provided.yml
+    resolver: https://localhost:8080/{{Product}}.yml
+`,
+			godellauncher.ConfigProviderParam{},
+			`"resolver" can only be specified for configuration providers that specify a "locator"`,
+		},
+		{
+			"git repository without path",
+			`
+providers:
+  - git:
+      url: https://github.com/palantir/godel-config.git
+`,
+			godellauncher.ConfigProviderParam{},
+			`git configuration provider must specify "url" and "path"`,
+		},
+	} {
+		var cfg config.TasksConfigProvidersConfig
+		require.NoError(t, yaml.Unmarshal([]byte(tc.cfg), &cfg), "Case %d: %s", i, tc.name)
+		got, err := cfg.ToParam()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, []godellauncher.ConfigProviderParam{tc.want}, got.ConfigProviders, "Case %d: %s", i, tc.name)
+	}
+}
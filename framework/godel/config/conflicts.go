@@ -0,0 +1,169 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/artifactresolver"
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/internal/versionsinternal"
+	"github.com/pkg/errors"
+)
+
+// PluginVersionConflictPolicy specifies how plugins with the same group and product but different versions that are
+// specified by the tasks configuration providers are handled.
+type PluginVersionConflictPolicy string
+
+const (
+	// PluginVersionConflictError leaves conflicting plugins in the configuration, which causes loading the plugins to
+	// fail. This is the default policy.
+	PluginVersionConflictError PluginVersionConflictPolicy = "error"
+	// PluginVersionConflictHighestVersion uses the plugin with the highest version. Versions are compared using the
+	// same rules as gödel versions and must all be orderable.
+	PluginVersionConflictHighestVersion PluginVersionConflictPolicy = "highest-version"
+	// PluginVersionConflictFirst uses the plugin from the first configuration that specifies it.
+	PluginVersionConflictFirst PluginVersionConflictPolicy = "first"
+)
+
+// PluginVersionConflict is a conflict between plugins with the same group and product but different versions that
+// was resolved using a PluginVersionConflictPolicy.
+type PluginVersionConflict struct {
+	// Policy is the policy that was used to resolve the conflict.
+	Policy PluginVersionConflictPolicy
+	// Selected is the plugin that was used.
+	Selected SourcedPluginLocator
+	// Ignored are the plugins that were removed from the configuration.
+	Ignored []SourcedPluginLocator
+}
+
+// SourcedPluginLocator is the locator ID of a plugin along with the source of the configuration that specified it.
+type SourcedPluginLocator struct {
+	ID     string
+	Source string
+}
+
+func (p SourcedPluginLocator) String() string {
+	return fmt.Sprintf("%s from %s", p.ID, p.Source)
+}
+
+// Warning returns a message that describes how the conflict was resolved.
+func (c PluginVersionConflict) Warning() string {
+	var ignored []string
+	for _, plugin := range c.Ignored {
+		ignored = append(ignored, plugin.String())
+	}
+	return fmt.Sprintf("configuration providers specify different versions of plugin %s: using %s (%q policy), ignoring %s", artifactresolver.LocatorIDWithoutVersion(c.Selected.ID), c.Selected, c.Policy, strings.Join(ignored, ", "))
+}
+
+// ResolvePluginVersionConflicts resolves conflicts between plugins with the same group and product but different
+// versions in the provided configurations using the provided policy. Returns copies of the configurations from which
+// the plugins that were not selected have been removed and the conflicts that were resolved. The provided
+// configurations are not modified. If the policy is blank or PluginVersionConflictError, the configurations are
+// returned unmodified. Returns an error if the policy is not valid or if the conflict cannot be resolved using the
+// policy.
+func ResolvePluginVersionConflicts(configs []SourcedTasksConfig, policy PluginVersionConflictPolicy) ([]SourcedTasksConfig, []PluginVersionConflict, error) {
+	switch policy {
+	case "", PluginVersionConflictError:
+		return configs, nil, nil
+	case PluginVersionConflictHighestVersion, PluginVersionConflictFirst:
+	default:
+		return nil, nil, errors.Errorf("invalid version conflict policy %q: must be one of %q, %q or %q", policy, PluginVersionConflictError, PluginVersionConflictHighestVersion, PluginVersionConflictFirst)
+	}
+
+	type pluginEntry struct {
+		cfgIdx, pluginIdx int
+		version           string
+		locator           SourcedPluginLocator
+	}
+	// group the plugins by "group:product", keeping the order in which the groups and plugins are encountered
+	var groupKeys []string
+	groups := make(map[string][]pluginEntry)
+	for cfgIdx, cfg := range configs {
+		for pluginIdx, plugin := range cfg.TasksConfig.Plugins.Plugins {
+			locatorCfg := LocatorConfig(plugin.Locator)
+			locatorParam, err := locatorCfg.ToParam()
+			if err != nil {
+				// if locator cannot be parsed, do not process (will error later anyway)
+				continue
+			}
+			key := locatorParam.GroupAndProductString()
+			if _, ok := groups[key]; !ok {
+				groupKeys = append(groupKeys, key)
+			}
+			groups[key] = append(groups[key], pluginEntry{
+				cfgIdx:    cfgIdx,
+				pluginIdx: pluginIdx,
+				version:   locatorParam.Version,
+				locator: SourcedPluginLocator{
+					ID:     plugin.Locator.ID,
+					Source: cfg.Source,
+				},
+			})
+		}
+	}
+
+	var conflicts []PluginVersionConflict
+	removed := make(map[[2]int]struct{})
+	for _, key := range groupKeys {
+		entries := groups[key]
+		selected := entries[0]
+		for _, entry := range entries[1:] {
+			if entry.version == selected.version || policy != PluginVersionConflictHighestVersion {
+				continue
+			}
+			cmp, ok, err := versionsinternal.CompareVersions(entry.version, selected.version)
+			if err != nil || !ok {
+				return nil, nil, errors.Errorf("failed to resolve conflicting versions of plugin %s using %q policy: versions %s and %s cannot be ordered", key, policy, selected.version, entry.version)
+			}
+			if cmp > 0 {
+				selected = entry
+			}
+		}
+
+		conflict := PluginVersionConflict{
+			Policy:   policy,
+			Selected: selected.locator,
+		}
+		for _, entry := range entries {
+			if entry.version == selected.version {
+				continue
+			}
+			conflict.Ignored = append(conflict.Ignored, entry.locator)
+			removed[[2]int{entry.cfgIdx, entry.pluginIdx}] = struct{}{}
+		}
+		if len(conflict.Ignored) > 0 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	if len(conflicts) == 0 {
+		return configs, nil, nil
+	}
+
+	resolvedConfigs := make([]SourcedTasksConfig, len(configs))
+	for cfgIdx, cfg := range configs {
+		var plugins []v0.SinglePluginConfig
+		for pluginIdx, plugin := range cfg.TasksConfig.Plugins.Plugins {
+			if _, ok := removed[[2]int{cfgIdx, pluginIdx}]; ok {
+				continue
+			}
+			plugins = append(plugins, plugin)
+		}
+		resolvedConfigs[cfgIdx] = cfg
+		resolvedConfigs[cfgIdx].TasksConfig.Plugins.Plugins = plugins
+	}
+	return resolvedConfigs, conflicts, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestResolvePluginVersionConflicts(t *testing.T) {
+	providerA := `
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo:1.2.0
+    - locator:
+        id: com.palantir:bar:1.0.0
+`
+	providerB := `
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo:1.10.0
+`
+	providerC := `
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo:1.0.0-rc1
+`
+	for i, tc := range []struct {
+		name          string
+		policy        config.PluginVersionConflictPolicy
+		wantPlugins   [][]string
+		wantConflicts []config.PluginVersionConflict
+		wantErr       string
+	}{
+		{
+			"default policy does not modify configuration",
+			"",
+			[][]string{{"com.palantir:foo:1.2.0", "com.palantir:bar:1.0.0"}, {"com.palantir:foo:1.10.0"}, {"com.palantir:foo:1.0.0-rc1"}},
+			nil,
+			"",
+		},
+		{
+			"highest version wins",
+			config.PluginVersionConflictHighestVersion,
+			[][]string{{"com.palantir:bar:1.0.0"}, {"com.palantir:foo:1.10.0"}, nil},
+			[]config.PluginVersionConflict{
+				{
+					Policy:   config.PluginVersionConflictHighestVersion,
+					Selected: config.SourcedPluginLocator{ID: "com.palantir:foo:1.10.0", Source: "b"},
+					Ignored: []config.SourcedPluginLocator{
+						{ID: "com.palantir:foo:1.2.0", Source: "a"},
+						{ID: "com.palantir:foo:1.0.0-rc1", Source: "c"},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"first wins",
+			config.PluginVersionConflictFirst,
+			[][]string{{"com.palantir:foo:1.2.0", "com.palantir:bar:1.0.0"}, nil, nil},
+			[]config.PluginVersionConflict{
+				{
+					Policy:   config.PluginVersionConflictFirst,
+					Selected: config.SourcedPluginLocator{ID: "com.palantir:foo:1.2.0", Source: "a"},
+					Ignored: []config.SourcedPluginLocator{
+						{ID: "com.palantir:foo:1.10.0", Source: "b"},
+						{ID: "com.palantir:foo:1.0.0-rc1", Source: "c"},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"invalid policy",
+			"newest",
+			nil,
+			nil,
+			`invalid version conflict policy "newest": must be one of "error", "highest-version" or "first"`,
+		},
+	} {
+		var configs []config.SourcedTasksConfig
+		for j, in := range []string{providerA, providerB, providerC} {
+			var cfg config.TasksConfig
+			require.NoError(t, yaml.Unmarshal([]byte(in), &cfg), "Case %d: %s", i, tc.name)
+			configs = append(configs, config.SourcedTasksConfig{
+				Source:      []string{"a", "b", "c"}[j],
+				TasksConfig: cfg,
+			})
+		}
+
+		got, gotConflicts, err := config.ResolvePluginVersionConflicts(configs, tc.policy)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		var gotPlugins [][]string
+		for _, cfg := range got {
+			var ids []string
+			for _, plugin := range cfg.TasksConfig.Plugins.Plugins {
+				ids = append(ids, plugin.Locator.ID)
+			}
+			gotPlugins = append(gotPlugins, ids)
+		}
+		assert.Equal(t, tc.wantPlugins, gotPlugins, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantConflicts, gotConflicts, "Case %d: %s", i, tc.name)
+		// input is not modified
+		assert.Len(t, configs[0].TasksConfig.Plugins.Plugins, 2, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestResolvePluginVersionConflictsNotOrderable(t *testing.T) {
+	var configs []config.SourcedTasksConfig
+	for _, id := range []string{"com.palantir:foo:1.0.0", "com.palantir:foo:1.1.0-custom"} {
+		var cfg config.TasksConfig
+		require.NoError(t, yaml.Unmarshal([]byte("plugins:\n  plugins:\n    - locator:\n        id: "+id+"\n"), &cfg))
+		configs = append(configs, config.SourcedTasksConfig{
+			Source:      id,
+			TasksConfig: cfg,
+		})
+	}
+	_, _, err := config.ResolvePluginVersionConflicts(configs, config.PluginVersionConflictHighestVersion)
+	assert.EqualError(t, err, `failed to resolve conflicting versions of plugin com.palantir:foo using "highest-version" policy: versions 1.0.0 and 1.1.0-custom cannot be ordered`)
+}
+
+func TestPluginVersionConflictWarning(t *testing.T) {
+	conflict := config.PluginVersionConflict{
+		Policy:   config.PluginVersionConflictHighestVersion,
+		Selected: config.SourcedPluginLocator{ID: "com.palantir:foo:2.0.0", Source: "config provider b"},
+		Ignored: []config.SourcedPluginLocator{
+			{ID: "com.palantir:foo:1.0.0", Source: "config provider a"},
+		},
+	}
+	assert.Equal(t, `configuration providers specify different versions of plugin com.palantir:foo: using com.palantir:foo:2.0.0 from config provider b ("highest-version" policy), ignoring com.palantir:foo:1.0.0 from config provider a`, conflict.Warning())
+}
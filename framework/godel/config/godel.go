@@ -34,9 +34,17 @@ type TasksConfigInfo struct {
 	BuiltinPluginsConfig PluginsConfig
 	// TasksConfig is the fully resolved user-provided tasks configuration.
 	TasksConfig TasksConfig
+	// IncludedConfigFiles are the project-relative paths of the files included by the gödel configuration whose
+	// configuration is part of TasksConfig, ordered from lowest to highest precedence.
+	IncludedConfigFiles []string
 	// DefaultTasksPluginsConfig is the plugin configuration used to load the default tasks. It is a result of combining
 	// the BuiltinPluginsConfig with the DefaultTasks config of TasksConfig.
 	DefaultTasksPluginsConfig PluginsConfig
+	// TasksConfigProvenance records the sources of the entries of TasksConfig.
+	TasksConfigProvenance TasksConfigProvenance
+	// PluginVersionConflicts are the conflicts between the plugins specified by the tasks configuration providers that
+	// were resolved using the version conflict policy.
+	PluginVersionConflicts []PluginVersionConflict
 }
 
 func ToTasksConfig(in TasksConfig) v0.TasksConfig {
@@ -46,15 +54,42 @@ func ToTasksConfig(in TasksConfig) v0.TasksConfig {
 // Combine combines the provided TasksConfig configurations with the base configuration. In cases where values are
 // overwritten, the last (most recent) values in the inputs will take precedence.
 func (c *TasksConfig) Combine(configs ...TasksConfig) {
+	sourcedConfigs := make([]SourcedTasksConfig, len(configs))
+	for i, cfg := range configs {
+		sourcedConfigs[i] = SourcedTasksConfig{
+			TasksConfig: cfg,
+		}
+	}
+	c.combine(nil, sourcedConfigs)
+}
+
+// CombineWithProvenance combines the provided configurations with the base configuration in the same manner as Combine
+// and records the sources of the entries of the combined configuration in the provided provenance.
+func (c *TasksConfig) CombineWithProvenance(provenance *TasksConfigProvenance, configs ...SourcedTasksConfig) {
+	c.combine(provenance, configs)
+}
+
+// combine combines the provided configurations with the base configuration. If provenance is non-nil, the sources of
+// the entries of the combined configuration are recorded in it.
+func (c *TasksConfig) combine(provenance *TasksConfigProvenance, configs []SourcedTasksConfig) {
 	if c.DefaultTasks.Tasks == nil {
 		c.DefaultTasks.Tasks = make(map[string]v0.SingleDefaultTaskConfig)
 	}
 	if c.VerifyTasks.Ordering == nil {
 		c.VerifyTasks.Ordering = make(map[string]int)
 	}
+	if provenance != nil {
+		provenance.init(len(c.Plugins.Plugins))
+	}
 
 	var pluginsFromConfigs []v0.SinglePluginConfig
-	for _, cfg := range configs {
+	var pluginSourcesFromConfigs []string
+	for _, sourcedCfg := range configs {
+		cfg := sourcedCfg.TasksConfig
+		if provenance != nil {
+			provenance.recordConfig(sourcedCfg)
+		}
+
 		// DefaultTask resolvers are appended and uniquified
 		c.DefaultTasks.DefaultResolvers = pluginsinternal.Uniquify(append(c.DefaultTasks.DefaultResolvers, cfg.DefaultTasks.DefaultResolvers...))
 
@@ -66,37 +101,56 @@ func (c *TasksConfig) Combine(configs ...TasksConfig) {
 
 		// Append provided plugins to "pluginsFromConfigs" list
 		pluginsFromConfigs = append(pluginsFromConfigs, cfg.Plugins.Plugins...)
+		for range cfg.Plugins.Plugins {
+			pluginSourcesFromConfigs = append(pluginSourcesFromConfigs, sourcedCfg.Source)
+		}
 
 		// Add all "VerifyTasks.Ordering" values from config
 		maps.Insert(c.VerifyTasks.Ordering, maps.All(cfg.VerifyTasks.Ordering))
 	}
 
 	// determine all of the provided plugins that specify overrides
-	pluginsFromConfigsWithOverride := matchingPluginConfigs(pluginsFromConfigs, func(in v0.SinglePluginConfig) bool { return in.Override })
+	pluginsFromConfigsWithOverride := matchingPluginConfigs(pluginsFromConfigs, pluginSourcesFromConfigs, func(in v0.SinglePluginConfig) bool { return in.Override })
 
 	// remove any of the original plugins that match override locators (because they will be overridden)
 	var originalConfigsWithoutOverridenPlugins []v0.SinglePluginConfig
-	for _, originalCfg := range c.Plugins.Plugins {
+	var originalSourcesWithoutOverriddenPlugins []string
+	for i, originalCfg := range c.Plugins.Plugins {
 		locatorCfg := LocatorConfig(originalCfg.Locator)
 		if locatorParam, err := locatorCfg.ToParam(); err == nil {
 			// if locator can be parsed and matches a plugin for which an override was specified, omit it (it will be overridden)
-			if _, ok := pluginsFromConfigsWithOverride[locatorParam.GroupAndProductString()]; ok {
+			if overrideSource, ok := pluginsFromConfigsWithOverride[locatorParam.GroupAndProductString()]; ok {
+				if provenance != nil {
+					provenance.OverriddenPlugins = append(provenance.OverriddenPlugins, OverriddenPlugin{
+						Plugin:       originalCfg,
+						Source:       provenance.PluginSources[i],
+						OverriddenBy: overrideSource,
+					})
+				}
 				continue
 			}
 		}
 		// plugin was not overridden or locator could not be parsed: keep it
 		originalConfigsWithoutOverridenPlugins = append(originalConfigsWithoutOverridenPlugins, originalCfg)
+		if provenance != nil {
+			originalSourcesWithoutOverriddenPlugins = append(originalSourcesWithoutOverriddenPlugins, provenance.PluginSources[i])
+		}
 	}
 
 	// update plugins list. Any of the original plugins that match an override from an input plugin will be removed.
 	// Note that no deduplication/override processing is done for the provided configurations -- if the input
 	// configurations specifies duplicate plugins, that will be handled later when determining plugin compatibility.
 	c.Plugins.Plugins = append(originalConfigsWithoutOverridenPlugins, pluginsFromConfigs...)
+	if provenance != nil {
+		provenance.PluginSources = append(originalSourcesWithoutOverriddenPlugins, pluginSourcesFromConfigs...)
+	}
 }
 
-func matchingPluginConfigs(in []v0.SinglePluginConfig, predicate func(v0.SinglePluginConfig) bool) map[string]struct{} {
-	matches := make(map[string]struct{})
-	for _, pluginCfg := range in {
+// matchingPluginConfigs returns a map from the "group:product" of the plugins that match the provided predicate to the
+// source of the first matching plugin. sources contains the sources of the plugins in the same order as the plugins.
+func matchingPluginConfigs(in []v0.SinglePluginConfig, sources []string, predicate func(v0.SinglePluginConfig) bool) map[string]string {
+	matches := make(map[string]string)
+	for i, pluginCfg := range in {
 		if predicate == nil || !predicate(pluginCfg) {
 			continue
 		}
@@ -106,7 +160,9 @@ func matchingPluginConfigs(in []v0.SinglePluginConfig, predicate func(v0.SingleP
 			// if locator cannot be parsed, do not process as override (will error later anyway)
 			continue
 		}
-		matches[locatorParam.GroupAndProductString()] = struct{}{}
+		if _, ok := matches[locatorParam.GroupAndProductString()]; !ok {
+			matches[locatorParam.GroupAndProductString()] = sources[i]
+		}
 	}
 	return matches
 }
@@ -128,7 +184,7 @@ func (c *TasksConfigProvidersConfig) ToParam() (godellauncher.TasksConfigProvide
 		}
 		defaultResolvers = append(defaultResolvers, resolver)
 	}
-	var configProviders []artifactresolver.LocatorWithResolverParam
+	var configProviders []godellauncher.ConfigProviderParam
 	for _, provider := range c.ConfigProviders {
 		provider := ConfigProviderLocatorWithResolverConfig(provider)
 		providerVal, err := provider.ToParam()
@@ -0,0 +1,31 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+)
+
+// HistoryConfig is the configuration for the history of task runs.
+type HistoryConfig v0.HistoryConfig
+
+// ToParam returns the parameters for the task run history of the project. The limits of the history are not part of
+// the configuration because the history file is shared by all of the projects that use the same gödel home directory.
+func (c HistoryConfig) ToParam() godellauncher.HistoryParam {
+	return godellauncher.HistoryParam{
+		Disabled: c.Disabled,
+	}
+}
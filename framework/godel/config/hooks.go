@@ -0,0 +1,110 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// HooksConfig is the configuration for the hooks of tasks. The key is the name of the task.
+type HooksConfig map[string]v0.TaskHooksConfig
+
+const (
+	hookOnFailureFail = "fail"
+	hookOnFailureWarn = "warn"
+)
+
+// ToParam returns the parameters for the hooks. Returns an error if any of the hooks are invalid or if the tasks run by
+// the hooks would run the hooks again.
+func (c HooksConfig) ToParam() (godellauncher.HooksParam, error) {
+	if len(c) == 0 {
+		return godellauncher.HooksParam{}, nil
+	}
+	hooks := make(map[string]godellauncher.TaskHooksParam)
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		if err := validateTaskName(name); err != nil {
+			return godellauncher.HooksParam{}, errors.Wrapf(err, "invalid hooks")
+		}
+		pre, err := taskHooksToParam(name, "pre", c[name].Pre)
+		if err != nil {
+			return godellauncher.HooksParam{}, err
+		}
+		post, err := taskHooksToParam(name, "post", c[name].Post)
+		if err != nil {
+			return godellauncher.HooksParam{}, err
+		}
+		hooks[name] = godellauncher.TaskHooksParam{
+			Pre:  pre,
+			Post: post,
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		if err := c.checkHookCycles(name, nil); err != nil {
+			return godellauncher.HooksParam{}, err
+		}
+	}
+	return godellauncher.HooksParam{
+		Hooks: hooks,
+	}, nil
+}
+
+func taskHooksToParam(name, kind string, hooks []v0.TaskHookConfig) ([]godellauncher.TaskHookParam, error) {
+	var params []godellauncher.TaskHookParam
+	for i, hook := range hooks {
+		if (hook.Task == "") == (hook.Script == "") {
+			return nil, errors.Errorf(`%s hook %d of task %s must specify exactly one of "task" or "script"`, kind, i+1, name)
+		}
+		if hook.Script != "" && len(hook.Args) != 0 {
+			return nil, errors.Errorf(`%s hook %d of task %s: "args" can only be specified for hooks that specify a "task"`, kind, i+1, name)
+		}
+		switch hook.OnFailure {
+		case "", hookOnFailureFail, hookOnFailureWarn:
+		default:
+			return nil, errors.Errorf(`%s hook %d of task %s: invalid "on-failure" value %q: must be %q or %q`, kind, i+1, name, hook.OnFailure, hookOnFailureFail, hookOnFailureWarn)
+		}
+		params = append(params, godellauncher.TaskHookParam{
+			Task:   hook.Task,
+			Args:   hook.Args,
+			Script: hook.Script,
+			Warn:   hook.OnFailure == hookOnFailureWarn,
+		})
+	}
+	return params, nil
+}
+
+// checkHookCycles returns an error if the hooks of the specified task (including the hooks of the tasks that they run)
+// run a task that is already in the provided path.
+func (c HooksConfig) checkHookCycles(name string, path []string) error {
+	path = append(path, name)
+	hooks := c[name]
+	for _, hook := range append(append([]v0.TaskHookConfig{}, hooks.Pre...), hooks.Post...) {
+		if hook.Task == "" {
+			continue
+		}
+		if slices.Contains(path, hook.Task) {
+			return errors.Errorf("hooks of task %s form a cycle: %s", path[0], strings.Join(append(path, hook.Task), " -> "))
+		}
+		if err := c.checkHookCycles(hook.Task, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
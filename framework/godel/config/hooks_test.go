@@ -0,0 +1,143 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestHooksConfig_ToParam(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		cfg     string
+		want    godellauncher.HooksParam
+		wantErr string
+	}{
+		{
+			"task and script hooks",
+			`
+test:
+  pre:
+    - script: docker compose up -d db
+  post:
+    - script: docker compose down
+      on-failure: warn
+publish:
+  post:
+    - task: notify
+      args: ["--channel", "releases"]
+      on-failure: fail
+`,
+			godellauncher.HooksParam{
+				Hooks: map[string]godellauncher.TaskHooksParam{
+					"test": {
+						Pre: []godellauncher.TaskHookParam{
+							{Script: "docker compose up -d db"},
+						},
+						Post: []godellauncher.TaskHookParam{
+							{Script: "docker compose down", Warn: true},
+						},
+					},
+					"publish": {
+						Post: []godellauncher.TaskHookParam{
+							{Task: "notify", Args: []string{"--channel", "releases"}},
+						},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"no hooks",
+			``,
+			godellauncher.HooksParam{},
+			"",
+		},
+		{
+			"task and script",
+			`
+test:
+  pre:
+    - task: generate
+      script: echo
+`,
+			godellauncher.HooksParam{},
+			`pre hook 1 of task test must specify exactly one of "task" or "script"`,
+		},
+		{
+			"args for script",
+			`
+test:
+  post:
+    - script: echo
+    - script: echo
+      args: ["foo"]
+`,
+			godellauncher.HooksParam{},
+			`post hook 2 of task test: "args" can only be specified for hooks that specify a "task"`,
+		},
+		{
+			"invalid failure policy",
+			`
+test:
+  post:
+    - script: echo
+      on-failure: ignore
+`,
+			godellauncher.HooksParam{},
+			`post hook 1 of task test: invalid "on-failure" value "ignore": must be "fail" or "warn"`,
+		},
+		{
+			"hook that runs its own task",
+			`
+test:
+  pre:
+    - task: test
+`,
+			godellauncher.HooksParam{},
+			`hooks of task test form a cycle: test -> test`,
+		},
+		{
+			"hooks that form a cycle",
+			`
+dist:
+  post:
+    - task: license
+license:
+  pre:
+    - task: dist
+`,
+			godellauncher.HooksParam{},
+			`hooks of task dist form a cycle: dist -> license -> dist`,
+		},
+	} {
+		var cfg config.HooksConfig
+		require.NoError(t, yaml.UnmarshalStrict([]byte(tc.cfg), &cfg), "Case %d: %s", i, tc.name)
+
+		got, err := cfg.ToParam()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/internal/pluginsinternal"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+)
+
+// IncludedConfig is the configuration in a file that is included by the gödel configuration.
+type IncludedConfig struct {
+	// Path is the project-relative path of the file.
+	Path string
+	// TasksConfig is the tasks configuration in the file.
+	TasksConfig TasksConfig
+	// Environment is the environment variables specified in the file.
+	Environment map[string]string
+	// Exclude is the exclude configuration specified in the file.
+	Exclude matcher.NamesPathsCfg
+}
+
+// ReadIncludedConfigs reads the configuration from the files included by the provided gödel configuration (and the
+// files that they include). Paths are resolved relative to the provided project directory. The returned configurations
+// are ordered from lowest to highest precedence: a file that includes other files is ordered after the files it
+// includes, and files included later are ordered after files included earlier. A file that is included more than once
+// is only read the first time that it is included. Returns an error if an included file cannot be read or if the
+// includes form a cycle.
+func ReadIncludedConfigs(projectDir string, godelCfg GodelConfig) ([]IncludedConfig, error) {
+	r := &includeReader{
+		projectDir: projectDir,
+		strict:     godelCfg.StrictConfig == nil || *godelCfg.StrictConfig,
+		read:       make(map[string]struct{}),
+	}
+	if err := r.readIncludes(godelCfg.Include, []string{godellauncher.GodelConfigYML}); err != nil {
+		return nil, err
+	}
+	return r.configs, nil
+}
+
+// ReadIncludedTasksConfigs returns the tasks configuration of the configurations returned by ReadIncludedConfigs and
+// the project-relative paths of the files in the same order.
+func ReadIncludedTasksConfigs(projectDir string, godelCfg GodelConfig) ([]TasksConfig, []string, error) {
+	includedCfgs, err := ReadIncludedConfigs(projectDir, godelCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tasksCfgs []TasksConfig
+	var paths []string
+	for _, includedCfg := range includedCfgs {
+		tasksCfgs = append(tasksCfgs, includedCfg.TasksConfig)
+		paths = append(paths, includedCfg.Path)
+	}
+	return tasksCfgs, paths, nil
+}
+
+// CombinedEnvironment returns the environment variables specified by the provided gödel configuration and the provided
+// included configurations, which must be ordered from lowest to highest precedence. Values in the gödel configuration
+// take precedence over values in the included configurations.
+func CombinedEnvironment(godelCfg GodelConfig, includedCfgs []IncludedConfig) map[string]string {
+	env := make(map[string]string)
+	for _, includedCfg := range includedCfgs {
+		maps.Copy(env, includedCfg.Environment)
+	}
+	maps.Copy(env, godelCfg.Environment)
+	return env
+}
+
+// CombinedExclude returns the union of the exclude configuration of the provided gödel configuration and the provided
+// included configurations.
+func CombinedExclude(godelCfg GodelConfig, includedCfgs []IncludedConfig) matcher.NamesPathsCfg {
+	exclude := matcher.NamesPathsCfg{
+		Names: append([]string{}, godelCfg.Exclude.Names...),
+		Paths: append([]string{}, godelCfg.Exclude.Paths...),
+	}
+	for _, includedCfg := range includedCfgs {
+		exclude.Names = append(exclude.Names, includedCfg.Exclude.Names...)
+		exclude.Paths = append(exclude.Paths, includedCfg.Exclude.Paths...)
+	}
+	exclude.Names = pluginsinternal.Uniquify(exclude.Names)
+	exclude.Paths = pluginsinternal.Uniquify(exclude.Paths)
+	return exclude
+}
+
+type includeReader struct {
+	projectDir string
+	strict     bool
+	// read contains the paths of the files that have been read
+	read    map[string]struct{}
+	configs []IncludedConfig
+}
+
+// readIncludes reads the provided included files. includeStack contains the names of the files that are currently
+// being read, starting with the gödel configuration file.
+func (r *includeReader) readIncludes(includes, includeStack []string) error {
+	for _, include := range includes {
+		if filepath.IsAbs(include) {
+			return errors.Errorf("%s: included file %s must be a path relative to the project directory", includeStack[len(includeStack)-1], include)
+		}
+		relPath := filepath.ToSlash(filepath.Clean(include))
+		for i, currPath := range includeStack {
+			if currPath == relPath {
+				cycle := append(append([]string{}, includeStack[i:]...), relPath)
+				return errors.Errorf("included files form a cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+		if _, ok := r.read[relPath]; ok {
+			continue
+		}
+
+		cfgBytes, err := os.ReadFile(filepath.Join(r.projectDir, filepath.FromSlash(relPath)))
+		if err != nil {
+			return errors.Wrapf(err, "%s: failed to read included file %s", includeStack[len(includeStack)-1], relPath)
+		}
+		var includeCfg v0.IncludeConfig
+		if err := v0.UnmarshalIncludeConfig(cfgBytes, &includeCfg, r.strict); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal included file %s", relPath)
+		}
+		if err := r.readIncludes(includeCfg.Include, append(includeStack, relPath)); err != nil {
+			return err
+		}
+		r.read[relPath] = struct{}{}
+		r.configs = append(r.configs, IncludedConfig{
+			Path:        relPath,
+			TasksConfig: TasksConfig(includeCfg.TasksConfig),
+			Environment: includeCfg.Environment,
+			Exclude:     includeCfg.Exclude,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/godel/config"
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/pkg/matcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIncludedTasksConfigs(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	writeFiles(t, projectDir, map[string]string{
+		"godel/config/godel.yml": `include:
+  - godel/config/plugins.yml
+  - godel/config/verify.yml
+verify-tasks:
+  ordering:
+    check: 3
+`,
+		"godel/config/plugins.yml": `include:
+  - godel/config/resolvers.yml
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo-plugin:1.0.0
+`,
+		"godel/config/resolvers.yml": `plugins:
+  resolvers:
+    - https://localhost:8080/{{Product}}.tgz
+`,
+		"godel/config/verify.yml": `include:
+  - godel/config/resolvers.yml
+verify-tasks:
+  ordering:
+    check: 1
+    test: 2
+`,
+	})
+
+	godelCfg, err := config.ReadGodelConfigFromProjectDir(projectDir)
+	require.NoError(t, err)
+
+	includedCfgs, includedFiles, err := config.ReadIncludedTasksConfigs(projectDir, godelCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"godel/config/resolvers.yml", "godel/config/plugins.yml", "godel/config/verify.yml"}, includedFiles)
+	require.Len(t, includedCfgs, 3)
+
+	var tasksCfg config.TasksConfig
+	tasksCfg.Combine(includedCfgs...)
+	tasksCfg.Combine(config.TasksConfig(godelCfg.TasksConfig))
+
+	assert.Equal(t, []string{"https://localhost:8080/{{Product}}.tgz"}, tasksCfg.Plugins.DefaultResolvers)
+	require.Len(t, tasksCfg.Plugins.Plugins, 1)
+	assert.Equal(t, "com.palantir:foo-plugin:1.0.0", tasksCfg.Plugins.Plugins[0].Locator.ID)
+	// configuration in godel.yml takes precedence over included configuration
+	assert.Equal(t, map[string]int{"check": 3, "test": 2}, tasksCfg.VerifyTasks.Ordering)
+}
+
+func TestReadIncludedTasksConfigsErrors(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			"cycle",
+			map[string]string{
+				"godel/config/godel.yml": `include:
+  - godel/config/a.yml
+`,
+				"godel/config/a.yml": `include:
+  - godel/config/b.yml
+`,
+				"godel/config/b.yml": `include:
+  - ./godel/config/a.yml
+`,
+			},
+			"included files form a cycle: godel/config/a.yml -> godel/config/b.yml -> godel/config/a.yml",
+		},
+		{
+			"missing file",
+			map[string]string{
+				"godel/config/godel.yml": `include:
+  - godel/config/missing.yml
+`,
+			},
+			"godel.yml: failed to read included file godel/config/missing.yml",
+		},
+		{
+			"unknown key",
+			map[string]string{
+				"godel/config/godel.yml": `include:
+  - godel/config/a.yml
+`,
+				"godel/config/a.yml": `plugin:
+  resolvers: []
+`,
+			},
+			`failed to unmarshal included file godel/config/a.yml: invalid configuration:
+  line 1: unknown key "plugin"`,
+		},
+		{
+			"absolute path",
+			map[string]string{
+				"godel/config/godel.yml": `include:
+  - /etc/godel.yml
+`,
+			},
+			"godel.yml: included file /etc/godel.yml must be a path relative to the project directory",
+		},
+	} {
+		func() {
+			projectDir, cleanup, err := dirs.TempDir("", "")
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+			defer cleanup()
+			writeFiles(t, projectDir, tc.files)
+
+			godelCfg, err := config.ReadGodelConfigFromProjectDir(projectDir)
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+			_, _, err = config.ReadIncludedTasksConfigs(projectDir, godelCfg)
+			require.Error(t, err, "Case %d: %s", i, tc.name)
+			assert.Contains(t, err.Error(), tc.wantErr, "Case %d: %s", i, tc.name)
+		}()
+	}
+}
+
+func TestReadIncludedTasksConfigsNotStrict(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	writeFiles(t, projectDir, map[string]string{
+		"godel/config/godel.yml": `strict-config: false
+include:
+  - godel/config/a.yml
+`,
+		"godel/config/a.yml": `plugin:
+  resolvers: []
+verify-tasks:
+  ordering:
+    check: 1
+`,
+	})
+
+	godelCfg, err := config.ReadGodelConfigFromProjectDir(projectDir)
+	require.NoError(t, err)
+	includedCfgs, _, err := config.ReadIncludedTasksConfigs(projectDir, godelCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []config.TasksConfig{
+		config.TasksConfig(v0.TasksConfig{
+			VerifyTasks: v0.VerifyTasksConfig{
+				Ordering: map[string]int{
+					"check": 1,
+				},
+			},
+		}),
+	}, includedCfgs)
+}
+
+func TestReadIncludedConfigsEnvironmentAndExclude(t *testing.T) {
+	projectDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	writeFiles(t, projectDir, map[string]string{
+		"godel/config/godel.yml": `include:
+  - godel/config/env.yml
+  - godel/config/env-override.yml
+environment:
+  FROM_GODEL_YML: godel.yml
+  OVERRIDDEN: godel.yml
+exclude:
+  names:
+    - vendor
+`,
+		"godel/config/env.yml": `environment:
+  FROM_INCLUDE: env.yml
+  OVERRIDDEN: env.yml
+  OVERRIDDEN_BY_LATER_INCLUDE: env.yml
+exclude:
+  names:
+    - generated
+  paths:
+    - testdata
+`,
+		"godel/config/env-override.yml": `environment:
+  OVERRIDDEN_BY_LATER_INCLUDE: env-override.yml
+exclude:
+  names:
+    - vendor
+`,
+	})
+
+	godelCfg, err := config.ReadGodelConfigFromProjectDir(projectDir)
+	require.NoError(t, err)
+	includedCfgs, err := config.ReadIncludedConfigs(projectDir, godelCfg)
+	require.NoError(t, err)
+
+	// godel.yml takes precedence over included files and later included files take precedence over earlier ones
+	assert.Equal(t, map[string]string{
+		"FROM_GODEL_YML":              "godel.yml",
+		"FROM_INCLUDE":                "env.yml",
+		"OVERRIDDEN":                  "godel.yml",
+		"OVERRIDDEN_BY_LATER_INCLUDE": "env-override.yml",
+	}, config.CombinedEnvironment(godelCfg, includedCfgs))
+
+	wantExclude := matcher.NamesPathsCfg{
+		Names: []string{"vendor", "generated"},
+		Paths: []string{"testdata"},
+	}
+	assert.Equal(t, wantExclude, config.CombinedExclude(godelCfg, includedCfgs))
+	assert.Equal(t, []string{"vendor"}, godelCfg.Exclude.Names, "gödel configuration should not be modified")
+
+	exclude, err := config.ReadGodelConfigExcludesFromFile(filepath.Join(projectDir, "godel", "config", "godel.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, wantExclude, exclude)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for k, v := range files {
+		path := filepath.Join(dir, k)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(v), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "godelw"), nil, 0755))
+}
@@ -15,26 +15,58 @@
 package v0
 
 type LocatorWithResolverConfig struct {
-	Locator  LocatorConfig `yaml:"locator,omitempty"`
-	Resolver string        `yaml:"resolver,omitempty"`
+	// Locator specifies the locator of the artifact.
+	Locator LocatorConfig `yaml:"locator,omitempty"`
+	// Resolver specifies the resolver used to resolve the artifact. If blank, the default resolvers are used.
+	Resolver string `yaml:"resolver,omitempty"`
 }
 
 // ConfigProviderLocatorWithResolverConfig is the configuration for a locator with resolver for a configuration
 // provider. It differs from a LocatorWithResolverConfig in that the locator is a ConfigProviderLocatorConfig rather
 // than a LocatorConfig.
 type ConfigProviderLocatorWithResolverConfig struct {
-	Locator  ConfigProviderLocatorConfig `yaml:"locator,omitempty"`
-	Resolver string                      `yaml:"resolver,omitempty"`
+	// Locator specifies the locator of the configuration provider.
+	Locator ConfigProviderLocatorConfig `yaml:"locator,omitempty"`
+	// Resolver specifies the resolver used to resolve the configuration provider. If blank, the default resolvers
+	// are used.
+	Resolver string `yaml:"resolver,omitempty"`
+	// Path specifies the path to a local configuration provider file. Relative paths are resolved against the project
+	// directory. Cannot be specified if Locator or Git is specified.
+	Path string `yaml:"path,omitempty"`
+	// Git specifies a configuration provider file stored in a git repository. Cannot be specified if Locator or Path is
+	// specified.
+	Git *ConfigProviderGitConfig `yaml:"git,omitempty"`
+	// Refresh specifies when a configuration provider that has been cached in the gödel home directory is resolved
+	// again. Must be "always" or a duration such as "24h". If blank, cached configuration providers (and the commits
+	// that the refs of configuration providers specified using Git resolved to) are only resolved again when they are
+	// refreshed explicitly. Cannot be specified if Path is specified.
+	Refresh string `yaml:"refresh,omitempty"`
+}
+
+// ConfigProviderGitConfig is the configuration for a configuration provider file stored in a git repository.
+type ConfigProviderGitConfig struct {
+	// URL is the URL of the git repository. Any URL supported by "git fetch" can be used.
+	URL string `yaml:"url,omitempty"`
+	// Ref is the branch, tag or commit SHA of the revision of the file. If blank, "HEAD" is used.
+	Ref string `yaml:"ref,omitempty"`
+	// Path is the path of the configuration provider file within the repository.
+	Path string `yaml:"path,omitempty"`
 }
 
 type LocatorConfig struct {
-	ID        string            `yaml:"id,omitempty"`
+	// ID is the identifier of the artifact in the form "group:product:version".
+	ID string `yaml:"id,omitempty"`
+	// Checksums specifies the expected SHA-256 checksums of the artifact. The key is the OS/architecture of the
+	// artifact in the form "GOOS-GOARCH".
 	Checksums map[string]string `yaml:"checksums,omitempty"`
 }
 
 // ConfigProviderLocatorConfig is the configuration for a locator for a configuration provider. It differs from a
 // LocatorConfig in that only a single checksum can be specified.
 type ConfigProviderLocatorConfig struct {
-	ID       string `yaml:"id,omitempty"`
+	// ID is the identifier of the configuration provider in the form "group:product:version".
+	ID string `yaml:"id,omitempty"`
+	// Checksum specifies the expected SHA-256 checksum of the configuration provider. Configuration providers are
+	// OS-independent, so the same checksum is used on all platforms.
 	Checksum string `yaml:"checksum,omitempty"`
 }
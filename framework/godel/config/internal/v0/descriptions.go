@@ -0,0 +1,78 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v0
+
+import (
+	"embed"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// sourceFiles contains the source of the configuration structs so that their documentation comments are available at
+// runtime.
+//
+//go:embed artifactresolver.go godel.go
+var sourceFiles embed.FS
+
+// FieldDescriptions returns the documentation comments of the fields of the structs defined in this package. The key
+// of the returned map is "<struct name>.<field name>" and the value is the text of the comment with whitespace
+// collapsed into single spaces. Fields without a documentation comment are not included.
+func FieldDescriptions() (map[string]string, error) {
+	descriptions := make(map[string]string)
+	fset := token.NewFileSet()
+	err := fs.WalkDir(sourceFiles, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		src, err := sourceFiles.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s", path)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range structType.Fields.List {
+				if field.Doc == nil {
+					continue
+				}
+				description := strings.Join(strings.Fields(field.Doc.Text()), " ")
+				for _, name := range field.Names {
+					descriptions[typeSpec.Name.Name+"."+name.Name] = description
+				}
+			}
+			return false
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return descriptions, nil
+}
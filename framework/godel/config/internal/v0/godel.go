@@ -29,11 +29,62 @@ type GodelConfig struct {
 	// Environment specifies the environment variables that are set by gödel when it is run.
 	Environment map[string]string `yaml:"environment,omitempty"`
 
+	// Include specifies the paths to YAML files that contain additional tasks configuration ("default-tasks",
+	// "plugins" and "verify-tasks"). Paths are relative to the project directory. Configuration in included files takes
+	// precedence over the configuration from the tasks configuration providers, configuration in later files takes
+	// precedence over earlier ones and the configuration in this file takes precedence over all included files.
+	Include []string `yaml:"include,omitempty"`
+
 	// TasksConfig contains the configuration for the tasks (default and plugin).
 	TasksConfig `yaml:",inline,omitempty"`
 
+	// Tasks specifies user-defined tasks. The key is the name of the task, which is used to invoke it in the same
+	// manner as the built-in and plugin tasks.
+	Tasks map[string]UserTaskConfig `yaml:"tasks,omitempty"`
+
+	// Aliases specifies alternative names for tasks. The key is the name of the alias and the value is the name of the
+	// task followed by the default arguments for the task separated by whitespace (for example, "test
+	// --tags=integration"). The arguments provided to an alias are appended to its default arguments. An alias cannot
+	// have the same name as a task.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+
+	// Hooks specifies the hooks that are run before and after tasks. The key is the name of the task. Hooks are run
+	// both when the task is run directly and when it is run by another task such as "verify".
+	Hooks map[string]TaskHooksConfig `yaml:"hooks,omitempty"`
+
+	// Timeouts specifies the maximum amount of time that tasks can run for. The key is the name of the task and the
+	// value is a duration such as "10m". A task that runs for longer than its timeout is stopped and fails.
+	Timeouts map[string]string `yaml:"timeouts,omitempty"`
+
+	// History specifies the configuration for the history of task runs. gödel records every task that is run in the
+	// project in the task run history in the gödel home directory, which is summarized by the "stats" task.
+	History HistoryConfig `yaml:"history,omitempty"`
+
 	// Exclude specifies the files and directories that should be excluded from gödel operations.
 	Exclude matcher.NamesPathsCfg `yaml:"exclude,omitempty"`
+
+	// StrictConfig specifies whether unknown keys in the configuration are treated as errors. Defaults to true if not
+	// specified. Setting this value to false causes unknown keys to be ignored.
+	StrictConfig *bool `yaml:"strict-config,omitempty"`
+}
+
+// IncludeConfig is the configuration in a file that is included by the gödel configuration.
+type IncludeConfig struct {
+	// Include specifies the paths to YAML files that are included by this file. Paths are relative to the project
+	// directory. The configuration in this file takes precedence over the configuration in the files it includes.
+	Include []string `yaml:"include,omitempty"`
+
+	// Environment specifies the environment variables that are set by gödel when it is run. Values in godel.yml take
+	// precedence over values in included files, and values in files included later take precedence over values in
+	// files included earlier.
+	Environment map[string]string `yaml:"environment,omitempty"`
+
+	// TasksConfig contains the configuration for the tasks (default and plugin).
+	TasksConfig `yaml:",inline,omitempty"`
+
+	// Exclude specifies the files and directories that should be excluded from gödel operations in addition to the
+	// ones excluded by godel.yml and the other included files.
+	Exclude matcher.NamesPathsCfg `yaml:"exclude,omitempty"`
 }
 
 type TasksConfig struct {
@@ -45,6 +96,69 @@ type TasksConfig struct {
 	VerifyTasks VerifyTasksConfig `yaml:"verify-tasks,omitempty"`
 }
 
+// UserTaskConfig is the configuration for a user-defined task. Exactly one of Steps and Script must be specified.
+type UserTaskConfig struct {
+	// Description is the description of the task that is shown in the help output.
+	Description string `yaml:"description,omitempty"`
+	// Steps specifies the tasks that are run in order by a composite task. A composite task stops at the first step
+	// that fails.
+	Steps []UserTaskStepConfig `yaml:"steps,omitempty"`
+	// Script specifies the shell command that is run by a script task. The command is run using "sh -c" in the project
+	// directory and the arguments provided to the task are available as positional parameters ("$1", "$@", etc.).
+	Script string `yaml:"script,omitempty"`
+	// Verify specifies that the task is run as part of the "verify" task. The task is not run by "verify" if this value
+	// is not specified.
+	Verify *UserTaskVerifyConfig `yaml:"verify,omitempty"`
+}
+
+// UserTaskStepConfig is the configuration for a single step of a composite task.
+type UserTaskStepConfig struct {
+	// Task is the name of the task that is run by the step. May be a built-in task, a plugin task or another
+	// user-defined task.
+	Task string `yaml:"task,omitempty"`
+	// Args are the arguments that are provided to the task.
+	Args []string `yaml:"args,omitempty"`
+}
+
+// UserTaskVerifyConfig is the configuration for running a user-defined task as part of the "verify" task.
+type UserTaskVerifyConfig struct {
+	// Ordering is the ordering of the task in the "verify" task. Can be overridden using the "ordering" of
+	// "verify-tasks".
+	Ordering int `yaml:"ordering,omitempty"`
+}
+
+// TaskHooksConfig is the configuration for the hooks of a task.
+type TaskHooksConfig struct {
+	// Pre specifies the hooks that are run in order before the task. The task is not run if a hook fails unless the
+	// "on-failure" value of the hook is "warn".
+	Pre []TaskHookConfig `yaml:"pre,omitempty"`
+	// Post specifies the hooks that are run in order after the task. Post hooks are run whether or not the task
+	// succeeds and the exit status of the task is provided to script hooks in the GODEL_TASK_EXIT_STATUS environment
+	// variable.
+	Post []TaskHookConfig `yaml:"post,omitempty"`
+}
+
+// TaskHookConfig is the configuration for a single hook. Exactly one of Task and Script must be specified.
+type TaskHookConfig struct {
+	// Task is the name of the task that is run by the hook.
+	Task string `yaml:"task,omitempty"`
+	// Args are the arguments that are provided to the task.
+	Args []string `yaml:"args,omitempty"`
+	// Script specifies the shell command that is run by the hook. The command is run using "sh -c" in the project
+	// directory and the name of the task is provided in the GODEL_TASK environment variable.
+	Script string `yaml:"script,omitempty"`
+	// OnFailure specifies how a failure of the hook is handled. Must be "fail" or "warn". If "fail", the task fails if
+	// the hook fails. If "warn", a warning is printed and the failure is otherwise ignored. If blank, "fail" is used.
+	OnFailure string `yaml:"on-failure,omitempty"`
+}
+
+// HistoryConfig is the configuration for the history of task runs.
+type HistoryConfig struct {
+	// Disabled specifies that the task runs of the project are not recorded in the history. Runs are also not recorded
+	// if the GODEL_DISABLE_HISTORY environment variable is "true".
+	Disabled bool `yaml:"disabled,omitempty"`
+}
+
 type VerifyTasksConfig struct {
 	// Ordering the value for the ordering for a verify task to be set/overridden by configuration. The key of the map
 	// is the name of the "verify" task and the value is the value that should be set. This configuration overrides the
@@ -53,13 +167,22 @@ type VerifyTasksConfig struct {
 }
 
 type DefaultTasksConfig struct {
-	DefaultResolvers []string                           `yaml:"resolvers,omitempty"`
-	Tasks            map[string]SingleDefaultTaskConfig `yaml:"tasks,omitempty"`
+	// DefaultResolvers specifies the default resolvers used to resolve the default tasks and their assets.
+	DefaultResolvers []string `yaml:"resolvers,omitempty"`
+	// Tasks specifies the configuration for the default tasks. The key is the "group:product" identifier of the
+	// plugin that provides the default task.
+	Tasks map[string]SingleDefaultTaskConfig `yaml:"tasks,omitempty"`
 }
 
 type TasksConfigProvidersConfig struct {
-	DefaultResolvers []string                                  `yaml:"resolvers,omitempty"`
-	ConfigProviders  []ConfigProviderLocatorWithResolverConfig `yaml:"providers,omitempty"`
+	// DefaultResolvers specifies the default resolvers used to resolve the configuration providers.
+	DefaultResolvers []string `yaml:"resolvers,omitempty"`
+	// ConfigProviders specifies the configuration providers.
+	ConfigProviders []ConfigProviderLocatorWithResolverConfig `yaml:"providers,omitempty"`
+	// VersionConflictPolicy specifies how plugins with the same group and product but different versions that are
+	// specified by the configuration providers are handled. Must be "error", "highest-version" or "first". If blank,
+	// "error" is used.
+	VersionConflictPolicy string `yaml:"version-conflict-policy,omitempty"`
 }
 
 type SingleDefaultTaskConfig struct {
@@ -77,8 +200,10 @@ type SingleDefaultTaskConfig struct {
 }
 
 type PluginsConfig struct {
-	DefaultResolvers []string             `yaml:"resolvers,omitempty"`
-	Plugins          []SinglePluginConfig `yaml:"plugins,omitempty"`
+	// DefaultResolvers specifies the default resolvers used to resolve the plugins and their assets.
+	DefaultResolvers []string `yaml:"resolvers,omitempty"`
+	// Plugins specifies the plugins.
+	Plugins []SinglePluginConfig `yaml:"plugins,omitempty"`
 }
 
 type SinglePluginConfig struct {
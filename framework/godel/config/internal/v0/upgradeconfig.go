@@ -15,14 +15,77 @@
 package v0
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
 
 func UpgradeConfig(cfgBytes []byte) ([]byte, error) {
 	var cfg GodelConfig
-	if err := yaml.Unmarshal(cfgBytes, &cfg); err != nil {
+	if err := Unmarshal(cfgBytes, &cfg); err != nil {
 		return nil, errors.Wrapf(err, "failed to unmarshal godel v0 configuration")
 	}
 	return cfgBytes, nil
 }
+
+// Unmarshal unmarshals the provided YAML into the provided configuration. Unless the configuration sets
+// "strict-config" to false, keys that do not correspond to a field in the configuration (including keys in nested
+// and inlined structs) are treated as errors.
+func Unmarshal(cfgBytes []byte, cfg *GodelConfig) error {
+	var strictCfg struct {
+		StrictConfig *bool `yaml:"strict-config"`
+	}
+	if err := yaml.Unmarshal(cfgBytes, &strictCfg); err != nil {
+		return err
+	}
+	return unmarshal(cfgBytes, cfg, strictCfg.StrictConfig == nil || *strictCfg.StrictConfig)
+}
+
+// UnmarshalIncludeConfig unmarshals the provided YAML into the provided included configuration. If strict is true,
+// keys that do not correspond to a field in the configuration are treated as errors.
+func UnmarshalIncludeConfig(cfgBytes []byte, cfg *IncludeConfig, strict bool) error {
+	return unmarshal(cfgBytes, cfg, strict)
+}
+
+func unmarshal(cfgBytes []byte, out interface{}, strict bool) error {
+	if !strict {
+		return yaml.Unmarshal(cfgBytes, out)
+	}
+	if err := yaml.UnmarshalStrict(cfgBytes, out); err != nil {
+		return unknownKeyError(err)
+	}
+	return nil
+}
+
+// yamlUnknownFieldRegexp matches the message that yaml.UnmarshalStrict uses to report a key that does not correspond
+// to a field.
+var yamlUnknownFieldRegexp = regexp.MustCompile(`^line ([0-9]+): field (.+) not found in type \S+$`)
+
+// unknownKeyError returns an error that describes the unknown keys reported in the provided error. If the provided
+// error does not report any unknown keys, it is returned unmodified.
+func unknownKeyError(err error) error {
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		return err
+	}
+	var hasUnknownKey bool
+	var msgs []string
+	for _, msg := range typeErr.Errors {
+		if match := yamlUnknownFieldRegexp.FindStringSubmatch(msg); match != nil {
+			hasUnknownKey = true
+			msg = fmt.Sprintf("line %s: unknown key %q", match[1], match[2])
+		}
+		msgs = append(msgs, msg)
+	}
+	if !hasUnknownKey {
+		return err
+	}
+	errMsg := "invalid configuration:"
+	for _, msg := range msgs {
+		errMsg += "\n  " + msg
+	}
+	return errors.Errorf(`%s
+Correct the keys or set "strict-config: false" in godel.yml to ignore unknown keys`, errMsg)
+}
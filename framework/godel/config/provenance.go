@@ -0,0 +1,104 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+)
+
+const (
+	// BuiltinSource is the source of configuration that is built into gödel.
+	BuiltinSource = "built-in"
+)
+
+// SourcedTasksConfig is a TasksConfig along with a description of its source, such as the locator of the configuration
+// provider or the path of the configuration file that specified it.
+type SourcedTasksConfig struct {
+	Source      string
+	TasksConfig TasksConfig
+}
+
+// TasksConfigProvenance records the sources of the entries of a TasksConfig that was combined using
+// CombineWithProvenance. The source of an entry is the Source of the SourcedTasksConfig that determined its value.
+type TasksConfigProvenance struct {
+	// PluginSources contains the sources of the plugins. The source of a plugin is at the same index as the plugin in
+	// Plugins.Plugins of the combined configuration.
+	PluginSources []string
+	// OverriddenPlugins contains the plugins that were removed from the combined configuration because a configuration
+	// that was combined later specified a plugin with the same group and product with "override: true".
+	OverriddenPlugins []OverriddenPlugin
+	// PluginResolverSources maps the plugin resolvers to the source that first specified them.
+	PluginResolverSources map[string]string
+	// DefaultTaskResolverSources maps the default task resolvers to the source that first specified them.
+	DefaultTaskResolverSources map[string]string
+	// DefaultTaskSources maps the keys of the default task configurations to their source.
+	DefaultTaskSources map[string]string
+	// VerifyOrderingSources maps the names of the verify tasks whose ordering is configured to their source.
+	VerifyOrderingSources map[string]string
+}
+
+// OverriddenPlugin is a plugin that was removed from a combined configuration by an override.
+type OverriddenPlugin struct {
+	// Plugin is the configuration of the plugin that was removed.
+	Plugin v0.SinglePluginConfig
+	// Source is the source of the plugin that was removed.
+	Source string
+	// OverriddenBy is the source of the plugin that specified the override.
+	OverriddenBy string
+}
+
+// init initializes the maps of the provenance and ensures that PluginSources has an entry for each of the numPlugins
+// plugins of the base configuration. Plugins of the base configuration whose source was not recorded have an empty
+// source.
+func (p *TasksConfigProvenance) init(numPlugins int) {
+	if p.PluginResolverSources == nil {
+		p.PluginResolverSources = make(map[string]string)
+	}
+	if p.DefaultTaskResolverSources == nil {
+		p.DefaultTaskResolverSources = make(map[string]string)
+	}
+	if p.DefaultTaskSources == nil {
+		p.DefaultTaskSources = make(map[string]string)
+	}
+	if p.VerifyOrderingSources == nil {
+		p.VerifyOrderingSources = make(map[string]string)
+	}
+	for len(p.PluginSources) < numPlugins {
+		p.PluginSources = append(p.PluginSources, "")
+	}
+	p.PluginSources = p.PluginSources[:numPlugins]
+}
+
+// recordConfig records the source of the entries of the provided configuration other than its plugins, which are
+// recorded when the plugins are combined. Resolvers keep the source that first specified them, while default tasks
+// and verify orderings take the source of the last configuration that specified them.
+func (p *TasksConfigProvenance) recordConfig(cfg SourcedTasksConfig) {
+	for _, resolver := range cfg.TasksConfig.DefaultTasks.DefaultResolvers {
+		if _, ok := p.DefaultTaskResolverSources[resolver]; !ok {
+			p.DefaultTaskResolverSources[resolver] = cfg.Source
+		}
+	}
+	for _, resolver := range cfg.TasksConfig.Plugins.DefaultResolvers {
+		if _, ok := p.PluginResolverSources[resolver]; !ok {
+			p.PluginResolverSources[resolver] = cfg.Source
+		}
+	}
+	for k := range cfg.TasksConfig.DefaultTasks.Tasks {
+		p.DefaultTaskSources[k] = cfg.Source
+	}
+	for k := range cfg.TasksConfig.VerifyTasks.Ordering {
+		p.VerifyOrderingSources[k] = cfg.Source
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCombineWithProvenance(t *testing.T) {
+	providedCfg := `
+default-tasks:
+  resolvers:
+    - https://provider.example.com/{{Product}}
+  tasks:
+    com.palantir.godel:check-plugin:
+      exclude-all-default-assets: true
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo:1.0.0
+    - locator:
+        id: com.palantir:bar:1.0.0
+verify-tasks:
+  ordering:
+    license: 10
+`
+	includedCfg := `
+default-tasks:
+  resolvers:
+    - https://provider.example.com/{{Product}}
+plugins:
+  plugins:
+    - locator:
+        id: com.palantir:foo:2.0.0
+      override: true
+`
+	godelCfg := `
+default-tasks:
+  tasks:
+    com.palantir.godel:check-plugin:
+      exclude-all-default-assets: false
+verify-tasks:
+  ordering:
+    license: 20
+`
+	var cfgs []config.TasksConfig
+	for _, in := range []string{providedCfg, includedCfg, godelCfg} {
+		var cfg config.TasksConfig
+		require.NoError(t, yaml.Unmarshal([]byte(in), &cfg))
+		cfgs = append(cfgs, cfg)
+	}
+
+	var want config.TasksConfig
+	for _, cfg := range cfgs {
+		want.Combine(cfg)
+	}
+
+	var got config.TasksConfig
+	var provenance config.TasksConfigProvenance
+	for i, source := range []string{"provider", "include.yml", "godel.yml"} {
+		got.CombineWithProvenance(&provenance, config.SourcedTasksConfig{
+			Source:      source,
+			TasksConfig: cfgs[i],
+		})
+	}
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, []string{"provider", "include.yml"}, provenance.PluginSources)
+	require.Len(t, provenance.OverriddenPlugins, 1)
+	assert.Equal(t, "com.palantir:foo:1.0.0", provenance.OverriddenPlugins[0].Plugin.Locator.ID)
+	assert.Equal(t, "provider", provenance.OverriddenPlugins[0].Source)
+	assert.Equal(t, "include.yml", provenance.OverriddenPlugins[0].OverriddenBy)
+	assert.Equal(t, map[string]string{"https://provider.example.com/{{Product}}": "provider"}, provenance.DefaultTaskResolverSources)
+	assert.Equal(t, map[string]string{"com.palantir.godel:check-plugin": "godel.yml"}, provenance.DefaultTaskSources)
+	assert.Equal(t, map[string]string{"license": "godel.yml"}, provenance.VerifyOrderingSources)
+	assert.Empty(t, provenance.PluginResolverSources)
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
 	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
@@ -51,24 +52,28 @@ func ReadGodelConfigFromFile(cfgFile string) (GodelConfig, error) {
 	if err != nil {
 		return GodelConfig{}, errors.Wrapf(err, "failed to upgrade configuration")
 	}
-	if err := yaml.Unmarshal(upgradedBytes, &godelCfg); err != nil {
-		return GodelConfig{}, errors.Wrapf(err, "failed to unmarshal gödel config YAML")
+	if err := v0.Unmarshal(upgradedBytes, (*v0.GodelConfig)(&godelCfg)); err != nil {
+		return GodelConfig{}, errors.Wrapf(err, "failed to unmarshal gödel config YAML from %s", cfgFile)
 	}
 	return godelCfg, nil
 }
 
 // ReadGodelConfigExcludesFromFile reads the excludes specified in the gödel godel configuration from the provided file
-// and returns the loaded configuration. Returns an empty configuration if the file does not exist. Callers that only
+// and returns the loaded configuration. The excludes specified in the files included by the configuration are also
+// returned: if the configuration includes other files, the configuration file must be in the gödel configuration
+// directory of the project ("<project>/godel/config") so that the paths of the included files can be resolved, and an
+// error is returned otherwise. Returns an empty configuration if the file does not exist. Callers that only
 // require the exclude configuration should prefer this function to using the ReadGodelConfigFrom* functions and
 // accessing the exclude configuration there, as this function is more robust to configuration changes (for example, the
 // ReadGodelConfigFrom* functions will return an error if the configuration has an unrecognized key, while this function
-// only considers the "exclude" portion of configuration).
+// only considers the "exclude" and "include" portions of configuration).
 func ReadGodelConfigExcludesFromFile(cfgFilePath string) (matcher.NamesPathsCfg, error) {
 	if _, err := os.Stat(cfgFilePath); os.IsNotExist(err) {
 		return matcher.NamesPathsCfg{}, nil
 	}
 
 	type excludeConfig struct {
+		Include []string              `yaml:"include,omitempty"`
 		Exclude matcher.NamesPathsCfg `yaml:"exclude,omitempty"`
 	}
 	cfgBytes, err := os.ReadFile(cfgFilePath)
@@ -79,5 +84,35 @@ func ReadGodelConfigExcludesFromFile(cfgFilePath string) (matcher.NamesPathsCfg,
 	if err := yaml.Unmarshal(cfgBytes, &exclude); err != nil {
 		return matcher.NamesPathsCfg{}, errors.WithStack(err)
 	}
-	return exclude.Exclude, nil
+	if len(exclude.Include) == 0 {
+		return exclude.Exclude, nil
+	}
+
+	projectDir, err := projectDirFromConfigFile(cfgFilePath)
+	if err != nil {
+		return matcher.NamesPathsCfg{}, err
+	}
+	strictCfg := false
+	godelCfg := GodelConfig{
+		Include:      exclude.Include,
+		Exclude:      exclude.Exclude,
+		StrictConfig: &strictCfg,
+	}
+	includedCfgs, err := ReadIncludedConfigs(projectDir, godelCfg)
+	if err != nil {
+		return matcher.NamesPathsCfg{}, err
+	}
+	return CombinedExclude(godelCfg, includedCfgs), nil
+}
+
+// projectDirFromConfigFile returns the path to the project directory given the path to a configuration file in the
+// gödel configuration directory of the project ("<project>/godel/config/godel.yml"). Returns an error if the path does
+// not match this layout.
+func projectDirFromConfigFile(cfgFilePath string) (string, error) {
+	cfgDir := filepath.Dir(cfgFilePath)
+	godelDir := filepath.Dir(cfgDir)
+	if filepath.Base(cfgDir) != "config" || filepath.Base(godelDir) != "godel" {
+		return "", errors.Errorf("cannot resolve included configuration files of %s: configuration file must be in the godel/config directory of the project", cfgFilePath)
+	}
+	return filepath.Dir(godelDir), nil
 }
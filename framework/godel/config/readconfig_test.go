@@ -89,6 +89,93 @@ func TestReadGodelConfigFromFileError(t *testing.T) {
 	assert.Equal(t, wantCfg, gotCfg)
 }
 
+func TestReadGodelConfigFromFileUnknownKeys(t *testing.T) {
+	testDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	for i, tc := range []struct {
+		name     string
+		ymlInput string
+		wantErr  []string
+	}{
+		{
+			"unknown top-level key",
+			`plugin:
+  resolvers:
+    - "https://localhost:8080/{{Product}}.tgz"
+`,
+			[]string{`line 1: unknown key "plugin"`},
+		},
+		{
+			"unknown key in inlined tasks configuration",
+			`version: 0
+verify-task:
+  foo: bar
+`,
+			[]string{`line 2: unknown key "verify-task"`},
+		},
+		{
+			"unknown keys in nested configuration",
+			`plugins:
+  plugins:
+    - locator:
+        id: "com.palantir:plugin:1.0.0"
+        checksum:
+          linux-amd64: "abc"
+exclude:
+  name:
+    - "vendor"
+`,
+			[]string{
+				`line 5: unknown key "checksum"`,
+				`line 8: unknown key "name"`,
+			},
+		},
+	} {
+		inputFile := filepath.Join(testDir, fmt.Sprintf("test_%d.yml", i))
+		err := os.WriteFile(inputFile, []byte(tc.ymlInput), 0644)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+
+		_, err = config.ReadGodelConfigFromFile(inputFile)
+		require.Error(t, err, "Case %d: %s", i, tc.name)
+		for _, want := range tc.wantErr {
+			assert.Contains(t, err.Error(), want, "Case %d: %s", i, tc.name)
+		}
+		assert.Contains(t, err.Error(), `set "strict-config: false"`, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestReadGodelConfigFromFileStrictConfigDisabled(t *testing.T) {
+	testDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	inputFile := filepath.Join(testDir, "godel.yml")
+	err = os.WriteFile(inputFile, []byte(`strict-config: false
+plugin:
+  resolvers:
+    - "https://localhost:8080/{{Product}}.tgz"
+exclude:
+  names:
+    - "vendor"
+`), 0644)
+	require.NoError(t, err)
+
+	gotCfg, err := config.ReadGodelConfigFromFile(inputFile)
+	require.NoError(t, err)
+
+	strictConfig := false
+	assert.Equal(t, config.GodelConfig(v0.GodelConfig{
+		Exclude: matcher.NamesPathsCfg{
+			Names: []string{
+				"vendor",
+			},
+		},
+		StrictConfig: &strictConfig,
+	}), gotCfg)
+}
+
 func TestReadGodelConfigExcludesFromFile(t *testing.T) {
 	testDir, cleanup, err := dirs.TempDir("", "")
 	require.NoError(t, err)
@@ -140,3 +227,16 @@ func TestReadGodelConfigExcludesFromFileError(t *testing.T) {
 
 	assert.Equal(t, wantCfg, gotCfg)
 }
+
+func TestReadGodelConfigExcludesFromFileIncludeOutsideConfigDir(t *testing.T) {
+	testDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	cfgFile := filepath.Join(testDir, "godel.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("include:\n  - exclude.yml\n"), 0644))
+
+	_, err = config.ReadGodelConfigExcludesFromFile(cfgFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "configuration file must be in the godel/config directory of the project")
+}
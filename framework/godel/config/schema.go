@@ -0,0 +1,51 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/pkg/configschema"
+	"github.com/pkg/errors"
+)
+
+// externalFieldDescriptions are the descriptions of the fields of structs that are part of the configuration but are
+// defined outside of the configuration package.
+var externalFieldDescriptions = map[string]string{
+	"ConfigWithVersion.Version": "Version is the version of the configuration format.",
+	"NamesPathsCfg.Names":       "Names specifies regular expressions that are matched against the names of files and directories.",
+	"NamesPathsCfg.Paths":       "Paths specifies the paths of files and directories relative to the project directory.",
+}
+
+// GodelConfigSchema returns the JSON Schema for the gödel configuration file ("godel.yml"). The schema is derived
+// from the structure of the current version of the configuration and the descriptions of its properties are the
+// documentation comments of the corresponding struct fields.
+func GodelConfigSchema() (*configschema.Schema, error) {
+	descriptions, err := v0.FieldDescriptions()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to determine configuration field descriptions")
+	}
+	for k, v := range externalFieldDescriptions {
+		descriptions[k] = v
+	}
+	schema := configschema.ForTypeWithDescriptions(reflect.TypeOf(v0.GodelConfig{}), func(structType reflect.Type, field reflect.StructField) string {
+		return descriptions[structType.Name()+"."+field.Name]
+	})
+	schema.SchemaVersion = configschema.DraftVersion
+	schema.Title = godellauncher.GodelConfigYML
+	return schema, nil
+}
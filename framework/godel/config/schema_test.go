@@ -0,0 +1,39 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGodelConfigSchema(t *testing.T) {
+	schema, err := config.GodelConfigSchema()
+	require.NoError(t, err)
+
+	var keys []string
+	for k := range schema.Properties {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"version", "tasks-config-providers", "environment", "default-tasks", "plugins", "verify-tasks", "include", "tasks", "aliases", "hooks", "timeouts", "history", "exclude", "strict-config"}, keys)
+
+	assert.Equal(t, "Plugins specifies the configuration for the plugins configured for gödel.", schema.Properties["plugins"].Description)
+	assert.Equal(t, "DefaultResolvers specifies the default resolvers used to resolve the plugins and their assets.", schema.Properties["plugins"].Properties["resolvers"].Description)
+	assert.Equal(t, "ID is the identifier of the artifact in the form \"group:product:version\".", schema.Properties["plugins"].Properties["plugins"].Items.Properties["locator"].Properties["id"].Description)
+	assert.Equal(t, "Names specifies regular expressions that are matched against the names of files and directories.", schema.Properties["exclude"].Properties["names"].Description)
+}
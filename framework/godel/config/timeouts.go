@@ -0,0 +1,50 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// TimeoutsConfig is the configuration for the timeouts of tasks. The key is the name of the task and the value is a
+// duration.
+type TimeoutsConfig map[string]string
+
+// ToParam returns the parameters for the timeouts. Returns an error if the name of a task is invalid or if a timeout is
+// not a positive duration.
+func (c TimeoutsConfig) ToParam() (godellauncher.TimeoutsParam, error) {
+	if len(c) == 0 {
+		return godellauncher.TimeoutsParam{}, nil
+	}
+	timeouts := make(map[string]time.Duration)
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		if err := validateTaskName(name); err != nil {
+			return godellauncher.TimeoutsParam{}, errors.Wrapf(err, "invalid timeouts")
+		}
+		timeout, err := time.ParseDuration(c[name])
+		if err != nil || timeout <= 0 {
+			return godellauncher.TimeoutsParam{}, errors.Errorf(`invalid timeout %q for task %s: must be a positive duration such as "10m"`, c[name], name)
+		}
+		timeouts[name] = timeout
+	}
+	return godellauncher.TimeoutsParam{
+		Timeouts: timeouts,
+	}, nil
+}
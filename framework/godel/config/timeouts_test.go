@@ -0,0 +1,79 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutsConfig_ToParam(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		cfg     config.TimeoutsConfig
+		want    godellauncher.TimeoutsParam
+		wantErr string
+	}{
+		{
+			"valid timeouts",
+			config.TimeoutsConfig{
+				"test": "10m",
+				"dist": "1h30m",
+			},
+			godellauncher.TimeoutsParam{
+				Timeouts: map[string]time.Duration{
+					"test": 10 * time.Minute,
+					"dist": 90 * time.Minute,
+				},
+			},
+			"",
+		},
+		{
+			"no timeouts",
+			nil,
+			godellauncher.TimeoutsParam{},
+			"",
+		},
+		{
+			"invalid duration",
+			config.TimeoutsConfig{
+				"test": "10",
+			},
+			godellauncher.TimeoutsParam{},
+			`invalid timeout "10" for task test: must be a positive duration such as "10m"`,
+		},
+		{
+			"negative duration",
+			config.TimeoutsConfig{
+				"test": "-1m",
+			},
+			godellauncher.TimeoutsParam{},
+			`invalid timeout "-1m" for task test: must be a positive duration such as "10m"`,
+		},
+	} {
+		got, err := tc.cfg.ToParam()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
@@ -80,14 +80,15 @@ paths:
 `,
 				WantFiles: map[string]string{
 					"godel/config/godel.yml": `exclude:
+  # comment
   names:
-  - \..+
-  - vendor
-  - mocks
-  - .*\.pb\.go
+    - "\\..+"
+    - "vendor"
+    - "mocks"
+    - ".*\\.pb\\.go"
   paths:
-  - godel
-  - internal/conjure/sls
+    - "godel"
+    - "internal/conjure/sls"
 `,
 				},
 			},
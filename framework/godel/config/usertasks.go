@@ -0,0 +1,131 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	v0 "github.com/palantir/godel/v2/framework/godel/config/internal/v0"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+)
+
+// UserTasksConfig is the configuration for the user-defined tasks. The key is the name of the task.
+type UserTasksConfig map[string]v0.UserTaskConfig
+
+// ToParam returns the parameters for the user-defined tasks sorted by name. Returns an error if any of the tasks are
+// invalid or if the steps of the composite tasks form a cycle.
+func (c UserTasksConfig) ToParam() (godellauncher.UserTasksParam, error) {
+	names := slices.Sorted(maps.Keys(c))
+	var tasks []godellauncher.UserTaskParam
+	for _, name := range names {
+		task, err := userTaskToParam(name, c[name])
+		if err != nil {
+			return godellauncher.UserTasksParam{}, err
+		}
+		tasks = append(tasks, task)
+	}
+	for _, name := range names {
+		if err := c.checkStepCycles(name, nil); err != nil {
+			return godellauncher.UserTasksParam{}, err
+		}
+	}
+	for _, name := range names {
+		// a task that is run by "verify" and that runs "verify" would recurse indefinitely
+		if c[name].Verify != nil && c.runsTask(name, verifyTaskName) {
+			return godellauncher.UserTasksParam{}, errors.Errorf(`task %s is run by "verify" and cannot run the "verify" task`, name)
+		}
+	}
+	return godellauncher.UserTasksParam{
+		Tasks: tasks,
+	}, nil
+}
+
+const verifyTaskName = "verify"
+
+func userTaskToParam(name string, cfg v0.UserTaskConfig) (godellauncher.UserTaskParam, error) {
+	if err := validateTaskName(name); err != nil {
+		return godellauncher.UserTaskParam{}, err
+	}
+	if (len(cfg.Steps) == 0) == (cfg.Script == "") {
+		return godellauncher.UserTaskParam{}, errors.Errorf(`task %s must specify exactly one of "steps" or "script"`, name)
+	}
+	var steps []godellauncher.UserTaskStepParam
+	for i, step := range cfg.Steps {
+		if step.Task == "" {
+			return godellauncher.UserTaskParam{}, errors.Errorf(`task %s: step %d does not specify a "task"`, name, i+1)
+		}
+		steps = append(steps, godellauncher.UserTaskStepParam{
+			Task: step.Task,
+			Args: step.Args,
+		})
+	}
+	var verify *godellauncher.VerifyOptions
+	if cfg.Verify != nil {
+		verify = &godellauncher.VerifyOptions{
+			Ordering: cfg.Verify.Ordering,
+		}
+	}
+	return godellauncher.UserTaskParam{
+		Name:        name,
+		Description: cfg.Description,
+		Steps:       steps,
+		Script:      cfg.Script,
+		Verify:      verify,
+	}, nil
+}
+
+// validateTaskName returns an error if the provided name cannot be used to invoke a task.
+func validateTaskName(name string) error {
+	if name == "" || strings.IndexFunc(name, unicode.IsSpace) != -1 || strings.HasPrefix(name, "-") {
+		return errors.Errorf(`invalid task name %q: must be non-empty, cannot contain whitespace and cannot start with "-"`, name)
+	}
+	return nil
+}
+
+// checkStepCycles returns an error if the steps of the specified task (including the steps of the user-defined tasks
+// that it runs) run a task that is already in the provided path.
+func (c UserTasksConfig) checkStepCycles(name string, path []string) error {
+	path = append(path, name)
+	for _, step := range c[name].Steps {
+		if _, ok := c[step.Task]; !ok {
+			continue
+		}
+		if slices.Contains(path, step.Task) {
+			return errors.Errorf("steps of task %s form a cycle: %s", path[0], strings.Join(append(path, step.Task), " -> "))
+		}
+		if err := c.checkStepCycles(step.Task, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runsTask returns true if the steps of the specified task (including the steps of the user-defined tasks that it runs)
+// run the target task. Must only be called after verifying that the steps do not form a cycle.
+func (c UserTasksConfig) runsTask(name, target string) bool {
+	for _, step := range c[name].Steps {
+		if step.Task == target {
+			return true
+		}
+		if _, ok := c[step.Task]; ok && c.runsTask(step.Task, target) {
+			return true
+		}
+	}
+	return false
+}
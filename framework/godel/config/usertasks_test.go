@@ -0,0 +1,181 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestUserTasksConfig_ToParam(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		cfg     string
+		want    godellauncher.UserTasksParam
+		wantErr string
+	}{
+		{
+			"composite and script tasks are sorted by name",
+			`
+release:
+  description: Build and publish
+  steps:
+    - task: format
+    - task: test
+      args: ["./..."]
+    - task: dist
+lint:
+  script: golangci-lint run "$@"
+  verify:
+    ordering: 5
+`,
+			godellauncher.UserTasksParam{
+				Tasks: []godellauncher.UserTaskParam{
+					{
+						Name:   "lint",
+						Script: `golangci-lint run "$@"`,
+						Verify: &godellauncher.VerifyOptions{
+							Ordering: 5,
+						},
+					},
+					{
+						Name:        "release",
+						Description: "Build and publish",
+						Steps: []godellauncher.UserTaskStepParam{
+							{Task: "format"},
+							{Task: "test", Args: []string{"./..."}},
+							{Task: "dist"},
+						},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"composite task can run other user-defined tasks",
+			`
+ci:
+  steps:
+    - task: lint
+    - task: verify
+lint:
+  script: golangci-lint run
+`,
+			godellauncher.UserTasksParam{
+				Tasks: []godellauncher.UserTaskParam{
+					{
+						Name: "ci",
+						Steps: []godellauncher.UserTaskStepParam{
+							{Task: "lint"},
+							{Task: "verify"},
+						},
+					},
+					{
+						Name:   "lint",
+						Script: "golangci-lint run",
+					},
+				},
+			},
+			"",
+		},
+		{
+			"steps and script",
+			`
+release:
+  steps:
+    - task: dist
+  script: echo release
+`,
+			godellauncher.UserTasksParam{},
+			`task release must specify exactly one of "steps" or "script"`,
+		},
+		{
+			"neither steps nor script",
+			`
+release:
+  description: Build and publish
+`,
+			godellauncher.UserTasksParam{},
+			`task release must specify exactly one of "steps" or "script"`,
+		},
+		{
+			"step without task",
+			`
+release:
+  steps:
+    - task: dist
+    - args: ["--dry-run"]
+`,
+			godellauncher.UserTasksParam{},
+			`task release: step 2 does not specify a "task"`,
+		},
+		{
+			"name with whitespace",
+			`
+"my task":
+  script: echo
+`,
+			godellauncher.UserTasksParam{},
+			`invalid task name "my task": must be non-empty, cannot contain whitespace and cannot start with "-"`,
+		},
+		{
+			"steps that form a cycle",
+			`
+a:
+  steps:
+    - task: b
+b:
+  steps:
+    - task: test
+    - task: c
+c:
+  steps:
+    - task: a
+`,
+			godellauncher.UserTasksParam{},
+			`steps of task a form a cycle: a -> b -> c -> a`,
+		},
+		{
+			"verify task that runs verify",
+			`
+ci:
+  steps:
+    - task: check
+  verify: {}
+check:
+  steps:
+    - task: verify
+`,
+			godellauncher.UserTasksParam{},
+			`task ci is run by "verify" and cannot run the "verify" task`,
+		},
+	} {
+		var cfg config.UserTasksConfig
+		require.NoError(t, yaml.UnmarshalStrict([]byte(tc.cfg), &cfg), "Case %d: %s", i, tc.name)
+
+		got, err := cfg.ToParam()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
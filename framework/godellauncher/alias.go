@@ -0,0 +1,103 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Alias is an alternative name for a task that runs the task with default arguments.
+type Alias struct {
+	// Name is the name that is used to invoke the alias.
+	Name string
+	// Task is the name of the task that is run by the alias.
+	Task string
+	// Args are the arguments that are provided to the task before the arguments provided to the alias.
+	Args []string
+}
+
+// String returns the expansion of the alias.
+func (a Alias) String() string {
+	return strings.Join(append([]string{a.Task}, a.Args...), " ")
+}
+
+// ExpandAlias returns the provided global configuration with its task expanded if it is the name of an alias: the task
+// is replaced with the task of the alias and the arguments of the alias are inserted before the provided arguments.
+// Returns an error if any alias has the same name as one of the provided tasks or if the expanded alias refers to a
+// task that does not exist.
+func ExpandAlias(global GlobalConfig, aliases []Alias, tasks []Task) (GlobalConfig, error) {
+	taskNames := make(map[string]struct{})
+	for _, task := range tasks {
+		taskNames[task.Name] = struct{}{}
+	}
+	for _, alias := range aliases {
+		if _, ok := taskNames[alias.Name]; ok {
+			return GlobalConfig{}, errors.Errorf("alias %s has the same name as a task: aliases cannot replace tasks", alias.Name)
+		}
+	}
+	for _, alias := range aliases {
+		if alias.Name != global.Task {
+			continue
+		}
+		if _, ok := taskNames[alias.Task]; !ok {
+			return GlobalConfig{}, errors.Errorf("alias %s refers to unknown task %s", alias.Name, alias.Task)
+		}
+		global.Task = alias.Task
+		global.TaskArgs = append(append([]string{}, alias.Args...), global.TaskArgs...)
+		break
+	}
+	return global, nil
+}
+
+// AliasTasks returns a task for each of the provided aliases so that the aliases are listed in the help output. Running
+// an alias task runs the provided task that the alias refers to.
+func AliasTasks(aliases []Alias, tasks []Task) []Task {
+	var aliasTasks []Task
+	for _, alias := range aliases {
+		// the arguments provided to an alias are provided to its task, so the alias is completed in the same manner as
+		// its task
+		var completion *Completion
+		for _, task := range tasks {
+			if task.Name == alias.Task {
+				completion = task.Completion
+				break
+			}
+		}
+		aliasTasks = append(aliasTasks, Task{
+			Name:        alias.Name,
+			Description: "Alias for " + alias.String(),
+			Completion:  completion,
+			Source: TaskSource{
+				Type: GodelConfigTaskSource,
+			},
+			RunContextImpl: func(ctx context.Context, t *Task, global GlobalConfig, stdout io.Writer) error {
+				expandedGlobal, err := ExpandAlias(global, []Alias{alias}, tasks)
+				if err != nil {
+					return err
+				}
+				task, err := TaskForInput(expandedGlobal, tasks)
+				if err != nil {
+					return err
+				}
+				return task.RunContext(ctx, expandedGlobal, stdout)
+			},
+		})
+	}
+	return aliasTasks
+}
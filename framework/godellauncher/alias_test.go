@@ -0,0 +1,109 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAlias(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{Name: "test"},
+		{Name: "dist"},
+	}
+	aliases := []godellauncher.Alias{
+		{Name: "it", Task: "test", Args: []string{"--tags=integration"}},
+		{Name: "dry", Task: "dist", Args: []string{"--dry-run"}},
+		{Name: "missing", Task: "missing-task"},
+	}
+
+	for i, tc := range []struct {
+		name    string
+		global  godellauncher.GlobalConfig
+		aliases []godellauncher.Alias
+		want    godellauncher.GlobalConfig
+		wantErr string
+	}{
+		{
+			"alias is expanded and arguments are appended to its arguments",
+			godellauncher.GlobalConfig{Wrapper: "/project/godelw", Task: "it", TaskArgs: []string{"./foo"}},
+			aliases,
+			godellauncher.GlobalConfig{Wrapper: "/project/godelw", Task: "test", TaskArgs: []string{"--tags=integration", "./foo"}},
+			"",
+		},
+		{
+			"task that is not an alias is not modified",
+			godellauncher.GlobalConfig{Task: "test", TaskArgs: []string{"./foo"}},
+			aliases,
+			godellauncher.GlobalConfig{Task: "test", TaskArgs: []string{"./foo"}},
+			"",
+		},
+		{
+			"alias for unknown task is an error when it is used",
+			godellauncher.GlobalConfig{Task: "missing"},
+			aliases,
+			godellauncher.GlobalConfig{},
+			"alias missing refers to unknown task missing-task",
+		},
+		{
+			"alias cannot have the same name as a task",
+			godellauncher.GlobalConfig{Task: "dist"},
+			append([]godellauncher.Alias{{Name: "test", Task: "dist"}}, aliases...),
+			godellauncher.GlobalConfig{},
+			"alias test has the same name as a task: aliases cannot replace tasks",
+		},
+	} {
+		got, err := godellauncher.ExpandAlias(tc.global, tc.aliases, tasks)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestAliasTasks(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{
+			Name:        "test",
+			Description: "Test packages",
+			RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+				_, _ = fmt.Fprintf(stdout, "ran %s %v\n", global.Task, global.TaskArgs)
+				return nil
+			},
+		},
+	}
+	aliasTasks := godellauncher.AliasTasks([]godellauncher.Alias{
+		{Name: "it", Task: "test", Args: []string{"--tags=integration"}},
+	}, tasks)
+	require.Len(t, aliasTasks, 1)
+	assert.Contains(t, godellauncher.UsageString(append(tasks, aliasTasks...)), "Alias for test --tags=integration")
+
+	outputBuf := &bytes.Buffer{}
+	err := aliasTasks[0].Run(godellauncher.GlobalConfig{
+		Task:     "it",
+		TaskArgs: []string{"./foo"},
+	}, outputBuf)
+	require.NoError(t, err)
+	assert.Equal(t, "ran test [--tags=integration ./foo]\n", outputBuf.String())
+}
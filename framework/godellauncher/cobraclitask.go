@@ -15,6 +15,7 @@
 package godellauncher
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -36,8 +37,11 @@ import (
 func CobraCLITask(cmd *cobra.Command, globalConfigPtr *GlobalConfig) Task {
 	rootCmd := CobraCmdToRootCmd(cmd)
 	return Task{
-		Name:        cmd.Use,
-		Description: cmd.Short,
+		Name:            cmd.Name(),
+		Description:     cmd.Short,
+		LongDescription: cmd.Long,
+		Usage:           CobraCommandUsage(cmd),
+		Completion:      CompletionFromCobraCommand(cmd),
 		RunImpl: func(t *Task, global GlobalConfig, stdout io.Writer) error {
 			if globalConfigPtr != nil {
 				*globalConfigPtr = global
@@ -53,6 +57,32 @@ func CobraCLITask(cmd *cobra.Command, globalConfigPtr *GlobalConfig) Task {
 	}
 }
 
+// CobraCLIContextTask creates a new Task in the same manner as CobraCLITask, but the task is run with the context that
+// is provided to the task, which is available to the command using cmd.Context(). Should be used for commands that stop
+// when their context is done.
+func CobraCLIContextTask(cmd *cobra.Command, globalConfigPtr *GlobalConfig) Task {
+	rootCmd := CobraCmdToRootCmd(cmd)
+	return Task{
+		Name:            cmd.Name(),
+		Description:     cmd.Short,
+		LongDescription: cmd.Long,
+		Usage:           CobraCommandUsage(cmd),
+		Completion:      CompletionFromCobraCommand(cmd),
+		RunContextImpl: func(ctx context.Context, t *Task, global GlobalConfig, stdout io.Writer) error {
+			if globalConfigPtr != nil {
+				*globalConfigPtr = global
+			}
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(os.Stderr)
+			args := []string{global.Executable}
+			args = append(args, global.Task)
+			args = append(args, global.TaskArgs...)
+			os.Args = args
+			return rootCmd.ExecuteContext(ctx)
+		},
+	}
+}
+
 // CobraCmdToRootCmd takes the provided *cobra.Command and returns a new *cobra.Command that acts as its "root" command.
 // The root command has "godel" as its command name and is configured to silence the built-in Cobra error printing.
 // However, it has custom logic to match the standard Cobra error output for unrecognized flags.
@@ -73,6 +103,12 @@ func CobraCmdToRootCmd(cmd *cobra.Command) *cobra.Command {
 	return rootCmd
 }
 
+// CobraCommandUsage returns the usage of the provided *cobra.Command in the form used by Task.Usage: the text of its
+// "Use" field that follows the name of the command.
+func CobraCommandUsage(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.Use, cmd.Name()))
+}
+
 func UnknownCommandError(cmd *cobra.Command, args []string) error {
 	errTmpl := `unknown command "%s" for "%s"
 Run '%v --help' for usage.`
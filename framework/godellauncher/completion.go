@@ -0,0 +1,305 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/godel"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// CompletionTaskAnnotation is the key of the annotation that specifies the gödel task that prints the completion values
+// for a flag or for the arguments of a command. When set on a flag (using pflag.FlagSet.SetAnnotation), the value is the
+// task and its arguments. When set on a *cobra.Command (using its Annotations map), the value is the task and its
+// arguments separated by whitespace. For example, "products" completes the product names of the project.
+const CompletionTaskAnnotation = "godel_completion_task"
+
+// Completion describes the flags, arguments and subcommands of a task. It is used to complete the input for the task
+// in a shell.
+type Completion struct {
+	// Flags are the flags supported by the task.
+	Flags []CompletionFlag
+	// Args specifies the values that are completed for the arguments of the task. If nil, file names are completed.
+	Args *CompletionValues
+	// Subcommands are the subcommands of the task.
+	Subcommands []CompletionCommand
+}
+
+// CompletionCommand describes a subcommand of a task.
+type CompletionCommand struct {
+	Name        string
+	Description string
+	Completion
+}
+
+// CompletionFlag describes a flag of a task or subcommand.
+type CompletionFlag struct {
+	Name        string
+	Shorthand   string
+	Description string
+	Type        FlagType
+	// Values specifies the values that are completed for the flag. Only used for string flags. If nil, file names are
+	// completed.
+	Values *CompletionValues
+}
+
+// CompletionValues specifies the values that are completed for a flag or for the arguments of a command.
+type CompletionValues struct {
+	// Values are the static values that are completed.
+	Values []string
+	// Task is the gödel task (followed by its arguments) that is run to determine the values that are completed. Every
+	// non-empty line of the output of the task is a value. For example, []string{"packages"}. If empty, only the static
+	// values are completed.
+	Task []string
+}
+
+// CompletionFromCobraCommand returns the Completion that describes the flags, arguments and subcommands of the provided
+// *cobra.Command. The static arguments of a command are determined using its ValidArgs, and the values of flags and
+// arguments that are printed by a task are determined using the CompletionTaskAnnotation annotation. Returns nil if the
+// command disables flag parsing, as the flags of such a command cannot be determined.
+func CompletionFromCobraCommand(cmd *cobra.Command) *Completion {
+	if cmd.DisableFlagParsing {
+		return nil
+	}
+	completion := completionFromCobraCommand(cmd)
+	return &completion
+}
+
+func completionFromCobraCommand(cmd *cobra.Command) Completion {
+	var completion Completion
+	addFlag := func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		flag := CompletionFlag{
+			Name:        f.Name,
+			Shorthand:   f.Shorthand,
+			Description: f.Usage,
+			Type:        StringFlag,
+		}
+		if f.Value.Type() == "bool" {
+			flag.Type = BoolFlag
+		}
+		if task := f.Annotations[CompletionTaskAnnotation]; len(task) > 0 {
+			flag.Values = &CompletionValues{
+				Task: task,
+			}
+		}
+		completion.Flags = append(completion.Flags, flag)
+	}
+	cmd.LocalFlags().VisitAll(addFlag)
+	cmd.InheritedFlags().VisitAll(addFlag)
+
+	if len(cmd.ValidArgs) > 0 {
+		completion.Args = &CompletionValues{
+			Values: cmd.ValidArgs,
+		}
+	}
+	if task := strings.Fields(cmd.Annotations[CompletionTaskAnnotation]); len(task) > 0 {
+		if completion.Args == nil {
+			completion.Args = &CompletionValues{}
+		}
+		completion.Args.Task = task
+	}
+	for _, subCmd := range cmd.Commands() {
+		if !subCmd.IsAvailableCommand() {
+			continue
+		}
+		completion.Subcommands = append(completion.Subcommands, CompletionCommand{
+			Name:        subCmd.Name(),
+			Description: subCmd.Short,
+			Completion:  completionFromCobraCommand(subCmd),
+		})
+	}
+	return completion
+}
+
+// completeTask returns the task that is run for the hidden "__complete" command that is invoked by the scripts printed
+// by the "completion" task. The launcher is a custom CLI, but the scripts use the Cobra completion protocol. In order to
+// support it, the task creates a new Cobra CLI that has the provided tasks as its commands (with the flags and
+// subcommands described by their Completion) and runs it with the "__complete" command and the task arguments.
+func completeTask(tasks []Task) Task {
+	return Task{
+		Name: cobra.ShellCompRequestCmd,
+		RunContextImpl: func(ctx context.Context, t *Task, global GlobalConfig, stdout io.Writer) error {
+			rootCmd := completionCobraCmd(tasks, global)
+			rootCmd.SetArgs(append([]string{global.Task}, withoutGlobalFlags(global.TaskArgs)...))
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(io.Discard)
+			return rootCmd.ExecuteContext(ctx)
+		},
+	}
+}
+
+func completionCobraCmd(tasks []Task, global GlobalConfig) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:           godel.AppName,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	// the "completion" command is provided by a task rather than by Cobra
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	for _, f := range globalFlags() {
+		f.addFlag(rootCmd.Flags())
+	}
+
+	valuesFn := func(values CompletionValues) cobra.CompletionFunc {
+		return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			completions := append([]string(nil), values.Values...)
+			if len(values.Task) > 0 {
+				taskValues, err := completionValuesFromTask(cmd.Context(), values.Task, tasks, global)
+				if err != nil {
+					return nil, cobra.ShellCompDirectiveError
+				}
+				completions = append(completions, taskValues...)
+			}
+			var matches []cobra.Completion
+			for _, v := range completions {
+				if strings.HasPrefix(v, toComplete) {
+					matches = append(matches, v)
+				}
+			}
+			return matches, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	for _, t := range tasks {
+		rootCmd.AddCommand(taskCobraCmd(t, valuesFn))
+	}
+	rootCmd.SetHelpCommand(&cobra.Command{
+		Use:   helpTaskName + " [task]",
+		Short: helpTaskDescription,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var completions []cobra.Completion
+			for _, t := range tasks {
+				if strings.HasPrefix(t.Name, toComplete) {
+					completions = append(completions, cobra.CompletionWithDesc(t.Name, t.Description))
+				}
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		},
+		Run: func(cmd *cobra.Command, args []string) {},
+	})
+	return rootCmd
+}
+
+// withoutGlobalFlags returns the provided arguments without the global flags that precede the task. The global flags
+// are only valid before the task, so they are not completed for tasks. The last argument is the one being completed
+// and is always retained.
+func withoutGlobalFlags(args []string) []string {
+	for len(args) > 1 {
+		switch args[0] {
+		case "--version", "--help", "-h", "--debug":
+			args = args[1:]
+		case "--wrapper", "--trace":
+			if len(args) == 2 {
+				// the value of the flag is being completed
+				return args
+			}
+			args = args[2:]
+		default:
+			return args
+		}
+	}
+	return args
+}
+
+// taskCobraCmd returns the *cobra.Command that has the name, description, usage, flags and subcommands of the provided
+// task. valuesFn returns the function that completes the provided values and may be nil if the command is not used for
+// completion.
+func taskCobraCmd(t Task, valuesFn func(CompletionValues) cobra.CompletionFunc) *cobra.Command {
+	var cmd *cobra.Command
+	if t.Completion == nil {
+		// the flags of the task are not known, so do not parse them (and complete file names)
+		cmd = &cobra.Command{
+			Use:                t.Name,
+			Short:              t.Description,
+			DisableFlagParsing: true,
+			Run:                func(cmd *cobra.Command, args []string) {},
+		}
+	} else {
+		cmd = completionCommandCobraCmd(CompletionCommand{
+			Name:        t.Name,
+			Description: t.Description,
+			Completion:  *t.Completion,
+		}, valuesFn)
+	}
+	if t.Usage != "" {
+		cmd.Use = t.Name + " " + t.Usage
+	}
+	cmd.Long = t.LongDescription
+	return cmd
+}
+
+func completionCommandCobraCmd(completionCmd CompletionCommand, valuesFn func(CompletionValues) cobra.CompletionFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   completionCmd.Name,
+		Short: completionCmd.Description,
+		// provide a non-nil (but no-op) command so that the command is completed
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	for _, f := range completionCmd.Flags {
+		// flags that are defined multiple times or that have conflicting shorthands are ignored
+		if cmd.Flags().Lookup(f.Name) != nil || (f.Shorthand != "" && cmd.Flags().ShorthandLookup(f.Shorthand) != nil) {
+			continue
+		}
+		if f.Type == BoolFlag {
+			cmd.Flags().BoolP(f.Name, f.Shorthand, false, f.Description)
+			continue
+		}
+		cmd.Flags().StringP(f.Name, f.Shorthand, "", f.Description)
+		if f.Values != nil && valuesFn != nil {
+			_ = cmd.RegisterFlagCompletionFunc(f.Name, valuesFn(*f.Values))
+		}
+	}
+	if completionCmd.Args != nil && valuesFn != nil {
+		cmd.ValidArgsFunction = valuesFn(*completionCmd.Args)
+	}
+	for _, subCmd := range completionCmd.Subcommands {
+		cmd.AddCommand(completionCommandCobraCmd(subCmd, valuesFn))
+	}
+	return cmd
+}
+
+// completionValuesFromTask runs the provided task (the first element of taskArgs) with the remaining elements of
+// taskArgs as its arguments and returns the non-empty lines of its output.
+func completionValuesFromTask(ctx context.Context, taskArgs []string, tasks []Task, global GlobalConfig) ([]string, error) {
+	taskGlobal := global
+	taskGlobal.Task = taskArgs[0]
+	taskGlobal.TaskArgs = taskArgs[1:]
+	task, err := TaskForInput(taskGlobal, tasks)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := task.RunContext(ctx, taskGlobal, buf); err != nil {
+		return nil, errors.Wrapf(err, "failed to run task %s", task.Name)
+	}
+	var values []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionFromCobraCommand(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:   "dist",
+		Short: "Create distributions",
+		Annotations: map[string]string{
+			godellauncher.CompletionTaskAnnotation: "products",
+		},
+	}
+	cmd.Flags().BoolP("dry-run", "n", false, "print the operations without performing them")
+	cmd.Flags().String("output", "", "output directory")
+	cmd.Flags().String("product", "", "product to build")
+	require.NoError(t, cmd.Flags().SetAnnotation("product", godellauncher.CompletionTaskAnnotation, []string{"products"}))
+	cmd.Flags().String("secret", "", "hidden flag")
+	require.NoError(t, cmd.Flags().MarkHidden("secret"))
+	cmd.AddCommand(&cobra.Command{
+		Use:       "clean [target]",
+		Short:     "Remove distributions",
+		ValidArgs: []string{"all", "dist"},
+		Run:       func(cmd *cobra.Command, args []string) {},
+	}, &cobra.Command{
+		Use:    "internal",
+		Hidden: true,
+		Run:    func(cmd *cobra.Command, args []string) {},
+	})
+
+	assert.Equal(t, &godellauncher.Completion{
+		Flags: []godellauncher.CompletionFlag{
+			{Name: "dry-run", Shorthand: "n", Description: "print the operations without performing them", Type: godellauncher.BoolFlag},
+			{Name: "output", Description: "output directory", Type: godellauncher.StringFlag},
+			{Name: "product", Description: "product to build", Type: godellauncher.StringFlag, Values: &godellauncher.CompletionValues{Task: []string{"products"}}},
+		},
+		Args: &godellauncher.CompletionValues{
+			Task: []string{"products"},
+		},
+		Subcommands: []godellauncher.CompletionCommand{
+			{
+				Name:        "clean",
+				Description: "Remove distributions",
+				Completion: godellauncher.Completion{
+					Args: &godellauncher.CompletionValues{Values: []string{"all", "dist"}},
+				},
+			},
+		},
+	}, godellauncher.CompletionFromCobraCommand(cmd))
+
+	assert.Nil(t, godellauncher.CompletionFromCobraCommand(&cobra.Command{
+		Use:                "script",
+		DisableFlagParsing: true,
+	}))
+}
+
+func TestComplete(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{
+			Name:        "dist",
+			Description: "Create distributions",
+			Completion: &godellauncher.Completion{
+				Flags: []godellauncher.CompletionFlag{
+					{Name: "dry-run", Description: "print the operations without performing them", Type: godellauncher.BoolFlag},
+					{Name: "product", Description: "product to build", Type: godellauncher.StringFlag, Values: &godellauncher.CompletionValues{Task: []string{"products", "--all"}}},
+				},
+				Args: &godellauncher.CompletionValues{
+					Values: []string{"default"},
+					Task:   []string{"products", "--all"},
+				},
+				Subcommands: []godellauncher.CompletionCommand{
+					{Name: "clean", Description: "Remove distributions"},
+				},
+			},
+		},
+		{
+			Name:        "products",
+			Description: "Print products",
+			RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+				if len(global.TaskArgs) != 1 || global.TaskArgs[0] != "--all" {
+					return fmt.Errorf("unexpected arguments: %v", global.TaskArgs)
+				}
+				_, _ = fmt.Fprintln(stdout, "bar\nfoo\n\nfoo-cli")
+				return nil
+			},
+		},
+		{
+			Name:        "fail",
+			Description: "Fails",
+			Completion: &godellauncher.Completion{
+				Args: &godellauncher.CompletionValues{Task: []string{"fail"}},
+			},
+			RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+				return fmt.Errorf("failed")
+			},
+		},
+		{
+			Name:        "script",
+			Description: "Runs a script",
+		},
+	}
+
+	for i, tc := range []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			"tasks are completed",
+			[]string{"d"},
+			[]string{"dist\tCreate distributions", ":4"},
+		},
+		{
+			"subcommands and argument values from static values and tasks are completed",
+			[]string{"dist", ""},
+			[]string{"clean\tRemove distributions", "default", "bar", "foo", "foo-cli", ":4"},
+		},
+		{
+			"argument values are filtered by prefix",
+			[]string{"dist", "fo"},
+			[]string{"foo", "foo-cli", ":4"},
+		},
+		{
+			"flags are completed",
+			[]string{"dist", "--d"},
+			[]string{"--dry-run\tprint the operations without performing them", ":4"},
+		},
+		{
+			"global flags are completed before the task",
+			[]string{"--d"},
+			[]string{"--debug\trun in debug mode (print full stack traces on failures and include other debugging output)", ":4"},
+		},
+		{
+			"global flags before the task are ignored",
+			[]string{"--debug", "--wrapper", "godelw", "dist", "--d"},
+			[]string{"--dry-run\tprint the operations without performing them", ":4"},
+		},
+		{
+			"flag values are completed",
+			[]string{"dist", "--product", "b"},
+			[]string{"bar", ":4"},
+		},
+		{
+			"failure of task that provides values results in error directive",
+			[]string{"fail", ""},
+			[]string{":1"},
+		},
+		{
+			"file names are completed for tasks without completion information",
+			[]string{"script", "--flag", ""},
+			[]string{":0"},
+		},
+	} {
+		global := godellauncher.GlobalConfig{
+			Task:     cobra.ShellCompRequestCmd,
+			TaskArgs: tc.args,
+		}
+		task, err := godellauncher.TaskForInput(global, tasks)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, task.Run(global, buf), "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), "Case %d: %s", i, tc.name)
+	}
+}
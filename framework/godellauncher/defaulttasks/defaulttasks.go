@@ -16,8 +16,8 @@ package defaulttasks
 
 import (
 	"sort"
-	"strings"
 
+	"github.com/palantir/godel/v2/framework/artifactresolver"
 	"github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/internal/pluginsinternal"
 	"github.com/pkg/errors"
@@ -166,7 +166,7 @@ func PluginsConfig(cfg config.DefaultTasksConfig) (config.PluginsConfig, error)
 
 	defaultPluginKeys := make(map[string]struct{})
 	for _, currPlugin := range defaultPluginsConfig.Plugins {
-		currKey := locatorIDWithoutVersion(currPlugin.Locator.ID)
+		currKey := artifactresolver.LocatorIDWithoutVersion(currPlugin.Locator.ID)
 		defaultPluginKeys[currKey] = struct{}{}
 
 		var assets []config.LocatorWithResolverConfig
@@ -228,15 +228,10 @@ func assetConfigFromDefault(baseCfg []config.LocatorWithResolverConfig, cfg conf
 	}
 	var out []config.LocatorWithResolverConfig
 	for _, asset := range baseCfg {
-		if _, ok := exclude[locatorIDWithoutVersion(asset.Locator.ID)]; ok {
+		if _, ok := exclude[artifactresolver.LocatorIDWithoutVersion(asset.Locator.ID)]; ok {
 			continue
 		}
 		out = append(out, asset)
 	}
 	return out
 }
-
-func locatorIDWithoutVersion(locatorID string) string {
-	parts := strings.Split(locatorID, ":")
-	return strings.Join(parts[:2], ":")
-}
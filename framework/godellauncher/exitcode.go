@@ -0,0 +1,73 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// ExitCodeError is an error that indicates that a task failed with a specific exit code, such as a plugin process that
+// exited with a non-zero exit code. The launcher exits with the exit code of the error.
+type ExitCodeError struct {
+	// Code is the exit code. Must be greater than 0.
+	Code int
+	// Message is the message of the error. Empty if the process that failed is expected to have printed its own error
+	// output.
+	Message string
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Message
+}
+
+func (e *ExitCodeError) ExitCode() int {
+	return e.Code
+}
+
+// ExitCodeErrorFromExec returns an *ExitCodeError with the exit code of the provided error if it is an *exec.ExitError
+// for a process that exited with a positive exit code and nil otherwise. The message of the returned error is the
+// provided message.
+func ExitCodeErrorFromExec(err error, message string) *ExitCodeError {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() <= 0 {
+		return nil
+	}
+	return &ExitCodeError{
+		Code:    exitErr.ExitCode(),
+		Message: message,
+	}
+}
+
+// exitCoder is implemented by errors that specify the exit code of gödel, such as *ExitCodeError, *SignalError and
+// *TimeoutError.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the exit code for the provided error that is returned by a task: 0 if the error is nil, the exit code
+// of the first error in the chain of the error that specifies a positive exit code (such as an *ExitCodeError) if one
+// exists and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitCodeErr exitCoder
+	if errors.As(err, &exitCodeErr) && exitCodeErr.ExitCode() > 0 {
+		return exitCodeErr.ExitCode()
+	}
+	return 1
+}
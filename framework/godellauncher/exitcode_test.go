@@ -0,0 +1,53 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher_test
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	for i, tc := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil error", nil, 0},
+		{"error without exit code", errors.New("failed"), 1},
+		{"exit code error", &godellauncher.ExitCodeError{Code: 3}, 3},
+		{"wrapped exit code error", errors.Wrapf(&godellauncher.ExitCodeError{Code: 4}, "hook failed"), 4},
+		{"exit code error with invalid code", &godellauncher.ExitCodeError{Code: -1}, 1},
+	} {
+		assert.Equal(t, tc.want, godellauncher.ExitCode(tc.err), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestExitCodeErrorFromExec(t *testing.T) {
+	err := exec.Command("sh", "-c", "exit 5").Run()
+	require.Error(t, err)
+	exitCodeErr := godellauncher.ExitCodeErrorFromExec(err, err.Error())
+	require.NotNil(t, exitCodeErr)
+	assert.Equal(t, 5, exitCodeErr.Code)
+	assert.EqualError(t, exitCodeErr, "exit status 5")
+
+	assert.Nil(t, godellauncher.ExitCodeErrorFromExec(fmt.Errorf("not an exit error"), ""))
+}
@@ -26,6 +26,9 @@ type GlobalConfig struct {
 	Executable string
 	// The value of the "--wrapper" flag provided to the gödel invocation.
 	Wrapper string
+	// The value of the "--trace" flag provided to the gödel invocation: the path to which the Chrome trace of the
+	// invocation is written. Empty if the invocation is not traced.
+	Trace string
 	// True if the "--debug" flag was provided to the gödel invocation.
 	Debug bool
 	// True if the "--version" flag was provided to the gödel invocation.
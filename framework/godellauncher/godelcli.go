@@ -21,6 +21,7 @@ import (
 
 	"github.com/palantir/godel/v2/framework/godel"
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
 // ParseAppArgs parses the arguments provided to the gödel launcher application into a GlobalConfig struct. Returns an
@@ -29,9 +30,9 @@ import (
 //
 // [executable] [<global flags>] [<task>] [<task flags/args>]
 //
-// <global flags> can be one of [--version], [--help|-h], [--debug] or [--wrapper <path>]. Note that, unlike the
-// behavior of some other CLI programs, the flags can only be specified exactly as described: for example, inputs of the
-// form "--version=true", "--debug false" and "--wrapper=<path>" are not valid.
+// <global flags> can be one of [--version], [--help|-h], [--debug], [--wrapper <path>] or [--trace <path>]. Note that,
+// unlike the behavior of some other CLI programs, the flags can only be specified exactly as described: for example,
+// inputs of the form "--version=true", "--debug false" and "--wrapper=<path>" are not valid.
 func ParseAppArgs(args []string) (GlobalConfig, error) {
 	// executable name must be specified
 	if len(args) == 0 {
@@ -73,6 +74,13 @@ func ParseAppArgs(args []string) (GlobalConfig, error) {
 				currArg = remainingArgs[0]
 				remainingArgs = remainingArgs[1:]
 				cfg.Wrapper = currArg
+			case "--trace":
+				if len(remainingArgs) == 0 {
+					return GlobalConfig{}, errors.Errorf("flag '--trace' must specify a value")
+				}
+				currArg = remainingArgs[0]
+				remainingArgs = remainingArgs[1:]
+				cfg.Trace = currArg
 			default:
 				return GlobalConfig{}, errors.Errorf("unknown flag: %s", currArg)
 			}
@@ -97,6 +105,12 @@ func ParseAppArgs(args []string) (GlobalConfig, error) {
 // * If global.Help is false and global.Version is true, the version is printed
 // * If global.Help and global.Version are both false, the help output is printed
 //
+// If the "Task" field of GlobalConfig is "help", a task that prints the help for the task specified by the task
+// arguments (or the help output if no task is specified) is returned.
+//
+// If the "Task" field of GlobalConfig is the hidden "__complete" command that is invoked by shell completion scripts,
+// a task that prints the completions for the task arguments is returned.
+//
 // If the "Task" field of GlobalConfig is non-empty, then the task in the provided "tasks" slice with the name that
 // matches the "Task" field is returned.
 //
@@ -109,6 +123,12 @@ func TaskForInput(global GlobalConfig, tasks []Task) (Task, error) {
 		}
 		return helpFlagTask(tasks), nil
 	}
+	if global.Task == helpTaskName {
+		return helpTask(tasks), nil
+	}
+	if global.Task == cobra.ShellCompRequestCmd || global.Task == cobra.ShellCompNoDescRequestCmd {
+		return completeTask(tasks), nil
+	}
 
 	tasksMap := make(map[string]Task)
 	for _, t := range tasks {
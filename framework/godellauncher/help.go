@@ -15,12 +15,15 @@
 package godellauncher
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/palantir/godel/v2/framework/godel"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -60,6 +63,153 @@ func helpFlagTask(tasks []Task) Task {
 	}
 }
 
+// helpTask returns the task that is run for the "help" command. If no arguments are provided, it prints the same
+// output as the "--help" flag. Otherwise, the arguments are the name of a task followed by the names of any of its
+// subcommands and the detailed help for the task or subcommand is printed. The help is rendered using the information
+// in the Task (which includes the information provided by plugins in their plugin information, which is cached), so
+// plugins are not run to print help. If the "--format json" flag is provided, the tasks (or the specified task) are
+// printed as JSON.
+func helpTask(tasks []Task) Task {
+	return Task{
+		Name:        helpTaskName,
+		Description: helpTaskDescription,
+		RunImpl: func(t *Task, global GlobalConfig, stdout io.Writer) error {
+			fset := pflag.NewFlagSet(helpTaskName, pflag.ContinueOnError)
+			fset.SetOutput(io.Discard)
+			format := fset.String("format", "text", `output format: "text" or "json"`)
+			if err := fset.Parse(global.TaskArgs); err != nil {
+				return err
+			}
+			args := fset.Args()
+			switch *format {
+			case "text":
+			case "json":
+				return printHelpJSON(append(tasks, helpTask(nil)), args, stdout)
+			default:
+				return errors.Errorf(`invalid format %q: must be "text" or "json"`, *format)
+			}
+
+			if len(args) == 0 {
+				flagTask := helpFlagTask(tasks)
+				return flagTask.Run(global, stdout)
+			}
+			rootCmd := &cobra.Command{
+				Use: godel.AppName,
+			}
+			for _, task := range tasks {
+				if task.Name == args[0] {
+					rootCmd.AddCommand(taskCobraCmd(task, nil))
+					break
+				}
+			}
+			if !rootCmd.HasSubCommands() {
+				return fmt.Errorf(`unknown command "%s" for "%s"`, args[0], godel.AppName)
+			}
+			cmd, _, err := rootCmd.Find(args)
+			if err != nil {
+				return err
+			}
+			cmd.SetOut(stdout)
+			return cmd.Help()
+		},
+	}
+}
+
+// helpTaskOutput describes a task in the output of "help --format json".
+type helpTaskOutput struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	LongDescription string `json:"longDescription"`
+	Usage           string `json:"usage"`
+	Source          struct {
+		// Type is one of "builtin", "default-plugin", "plugin" or "godel-config".
+		Type string `json:"type"`
+		// Plugin is the locator of the plugin that provides the task. Empty if the task is not provided by a plugin.
+		Plugin string `json:"plugin"`
+	} `json:"source"`
+	// Verify is nil if the task is not run by the "verify" task.
+	Verify *helpVerifyOutput `json:"verify"`
+}
+
+type helpVerifyOutput struct {
+	Ordering       int                    `json:"ordering"`
+	Flags          []helpVerifyFlagOutput `json:"flags"`
+	ApplyTrueArgs  []string               `json:"applyTrueArgs"`
+	ApplyFalseArgs []string               `json:"applyFalseArgs"`
+}
+
+type helpVerifyFlagOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Type is either "string" or "bool".
+	Type string `json:"type"`
+}
+
+// printHelpJSON prints the JSON description of the provided tasks sorted by name. If a task name is provided in args,
+// only the description of that task is printed.
+func printHelpJSON(tasks []Task, args []string, stdout io.Writer) error {
+	var outputs []helpTaskOutput
+	for _, task := range tasks {
+		if len(args) > 0 && task.Name != args[0] {
+			continue
+		}
+		output := helpTaskOutput{
+			Name:            task.Name,
+			Description:     task.Description,
+			LongDescription: task.LongDescription,
+			Usage:           task.Usage,
+		}
+		output.Source.Type = task.Source.Type.String()
+		output.Source.Plugin = task.Source.Plugin
+		if task.Verify != nil {
+			output.Verify = &helpVerifyOutput{
+				Ordering:       task.Verify.Ordering,
+				Flags:          []helpVerifyFlagOutput{},
+				ApplyTrueArgs:  append([]string{}, task.Verify.ApplyTrueArgs...),
+				ApplyFalseArgs: append([]string{}, task.Verify.ApplyFalseArgs...),
+			}
+			for _, f := range task.Verify.VerifyTaskFlags {
+				flagType := "string"
+				if f.Type == BoolFlag {
+					flagType = "bool"
+				}
+				output.Verify.Flags = append(output.Verify.Flags, helpVerifyFlagOutput{
+					Name:        f.Name,
+					Description: f.Description,
+					Type:        flagType,
+				})
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return outputs[i].Name < outputs[j].Name
+	})
+
+	var out any = struct {
+		Tasks []helpTaskOutput `json:"tasks"`
+	}{
+		Tasks: append([]helpTaskOutput{}, outputs...),
+	}
+	if len(args) > 0 {
+		if len(outputs) == 0 {
+			return fmt.Errorf(`unknown command "%s" for "%s"`, args[0], godel.AppName)
+		}
+		out = outputs[0]
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return errors.Wrapf(err, "failed to marshal JSON")
+	}
+	return nil
+}
+
+const (
+	helpTaskName        = "help"
+	helpTaskDescription = "Print help for a task"
+)
+
 // UsageString returns the usage string for the launcher application with the specified tasks. The returned string does
 // not have a trailing newline.
 func UsageString(tasks []Task) string {
@@ -70,10 +220,23 @@ func helpCobraCmd(tasks []Task) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use: godel.AppName,
 	}
+	// tasks are listed in groups based on their source. The groups are ordered by the first task from each source, and
+	// the built-in tasks are always listed first.
+	builtinGroup := helpGroup(TaskSource{})
+	rootCmd.AddGroup(builtinGroup)
+	groupIDs := map[string]struct{}{
+		builtinGroup.ID: {},
+	}
 	for _, t := range tasks {
+		group := helpGroup(t.Source)
+		if _, ok := groupIDs[group.ID]; !ok {
+			rootCmd.AddGroup(group)
+			groupIDs[group.ID] = struct{}{}
+		}
 		rootCmd.AddCommand(&cobra.Command{
-			Use:   t.Name,
-			Short: t.Description,
+			Use:     t.Name,
+			Short:   t.Description,
+			GroupID: group.ID,
 			// provide a non-nil (but no-op) command so that the command is listed in help
 			Run: func(cmd *cobra.Command, args []string) {},
 		})
@@ -82,14 +245,49 @@ func helpCobraCmd(tasks []Task) *cobra.Command {
 		f.addFlag(rootCmd.Flags())
 	}
 
-	// set help command to be empty hidden command to effectively remove the built-in help command
+	// replace the built-in help command with a no-op command that describes the "help" task
 	rootCmd.SetHelpCommand(&cobra.Command{
-		Hidden: true,
+		Use:     helpTaskName + " [task]",
+		Short:   helpTaskDescription,
+		GroupID: builtinGroup.ID,
+		Run:     func(cmd *cobra.Command, args []string) {},
 	})
+	// add the help command now (rather than when the command is executed) so that it is included in UsageString
+	rootCmd.InitDefaultHelpCmd()
 
 	return rootCmd
 }
 
+// helpGroup returns the group in the help output for the tasks with the provided source.
+func helpGroup(source TaskSource) *cobra.Group {
+	pluginSuffix := ""
+	if source.Plugin != "" {
+		pluginSuffix = " " + source.Plugin
+	}
+	switch source.Type {
+	case DefaultPluginTaskSource:
+		return &cobra.Group{
+			ID:    "default-plugin" + pluginSuffix,
+			Title: fmt.Sprintf("Tasks provided by default plugin%s:", pluginSuffix),
+		}
+	case PluginTaskSource:
+		return &cobra.Group{
+			ID:    "plugin" + pluginSuffix,
+			Title: fmt.Sprintf("Tasks provided by plugin%s:", pluginSuffix),
+		}
+	case GodelConfigTaskSource:
+		return &cobra.Group{
+			ID:    "godel-config",
+			Title: fmt.Sprintf("Tasks and aliases defined in %s:", GodelConfigYML),
+		}
+	default:
+		return &cobra.Group{
+			ID:    "builtin",
+			Title: "Built-in tasks:",
+		}
+	}
+}
+
 func globalFlags() []flagDescProvider {
 	return []flagDescProvider{
 		boolFlagDesc{
@@ -104,5 +302,9 @@ func globalFlags() []flagDescProvider {
 			name:  "wrapper",
 			usage: "path to the wrapper script for this invocation",
 		},
+		stringFlagDesc{
+			name:  "trace",
+			usage: "write a Chrome trace of the timing of loading and running tasks to the specified file",
+		},
 	}
 }
@@ -0,0 +1,286 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageStringGroupsTasksBySource(t *testing.T) {
+	usage := godellauncher.UsageString([]godellauncher.Task{
+		{Name: "version", Description: "Print version"},
+		{Name: "format", Description: "Format files", Source: godellauncher.TaskSource{Type: godellauncher.DefaultPluginTaskSource, Plugin: "com.palantir.godel:format-plugin:1.0.0"}},
+		{Name: "license", Description: "Add license headers", Source: godellauncher.TaskSource{Type: godellauncher.PluginTaskSource, Plugin: "com.palantir:license-plugin:1.0.0"}},
+		{Name: "ci", Description: "Runs verify", Source: godellauncher.TaskSource{Type: godellauncher.GodelConfigTaskSource}},
+		{Name: "verify", Description: "Run verify tasks"},
+	})
+	var titles []string
+	for _, line := range strings.Split(usage, "\n") {
+		if strings.HasSuffix(line, ":") && !strings.HasPrefix(line, " ") {
+			titles = append(titles, line)
+		}
+	}
+	assert.Equal(t, []string{
+		"Usage:",
+		"Built-in tasks:",
+		"Tasks provided by default plugin com.palantir.godel:format-plugin:1.0.0:",
+		"Tasks provided by plugin com.palantir:license-plugin:1.0.0:",
+		"Tasks and aliases defined in godel.yml:",
+		"Flags:",
+	}, titles)
+	assert.Regexp(t, `Built-in tasks:\n  help +Print help for a task\n  verify +Run verify tasks\n  version +Print version\n`, usage)
+}
+
+func TestHelpTask(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{
+			Name:            "dist",
+			Description:     "Create distributions",
+			LongDescription: "Creates the distributions for the products of the project.",
+			Usage:           "[flags] [products...]",
+			Completion: &godellauncher.Completion{
+				Flags: []godellauncher.CompletionFlag{
+					{Name: "dry-run", Shorthand: "n", Description: "print the operations without performing them", Type: godellauncher.BoolFlag},
+					{Name: "output", Description: "output directory", Type: godellauncher.StringFlag, Values: &godellauncher.CompletionValues{Task: []string{"products"}}},
+				},
+				Subcommands: []godellauncher.CompletionCommand{
+					{
+						Name:        "clean",
+						Description: "Remove distributions",
+						Completion: godellauncher.Completion{
+							Flags: []godellauncher.CompletionFlag{
+								{Name: "all", Description: "remove all distributions", Type: godellauncher.BoolFlag},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "script",
+			Description: "Runs a script",
+		},
+	}
+
+	for i, tc := range []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			"help for task",
+			[]string{"dist"},
+			`Creates the distributions for the products of the project.
+
+Usage:
+  godel dist [flags] [products...]
+  godel dist [command]
+
+Available Commands:
+  clean       Remove distributions
+
+Flags:
+  -n, --dry-run         print the operations without performing them
+      --output string   output directory
+
+Use "godel dist [command] --help" for more information about a command.
+`,
+			"",
+		},
+		{
+			"help for subcommand",
+			[]string{"dist", "clean"},
+			`Remove distributions
+
+Usage:
+  godel dist clean [flags]
+
+Flags:
+      --all   remove all distributions
+`,
+			"",
+		},
+		{
+			"help for task without completion information",
+			[]string{"script"},
+			`Runs a script
+
+Usage:
+  godel script
+`,
+			"",
+		},
+		{
+			"help for unknown task",
+			[]string{"unknown"},
+			"",
+			`unknown command "unknown" for "godel"`,
+		},
+	} {
+		global := godellauncher.GlobalConfig{
+			Task:     "help",
+			TaskArgs: tc.args,
+		}
+		task, err := godellauncher.TaskForInput(global, tasks)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+
+		buf := &bytes.Buffer{}
+		err = task.Run(global, buf)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestHelpTaskJSON(t *testing.T) {
+	tasks := []godellauncher.Task{
+		{
+			Name:        "verify",
+			Description: "Run verify tasks",
+		},
+		{
+			Name:        "format",
+			Description: "Format files",
+			Usage:       "[files...]",
+			Source:      godellauncher.TaskSource{Type: godellauncher.DefaultPluginTaskSource, Plugin: "com.palantir.godel:format-plugin:1.0.0"},
+			Verify: &godellauncher.VerifyOptions{
+				Ordering: 10,
+				VerifyTaskFlags: []godellauncher.VerifyFlag{
+					{Name: "skip-format", Description: "skip formatting", Type: godellauncher.BoolFlag},
+				},
+				ApplyFalseArgs: []string{"--verify"},
+			},
+		},
+	}
+
+	for i, tc := range []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			"all tasks",
+			[]string{"--format", "json"},
+			`{
+  "tasks": [
+    {
+      "name": "format",
+      "description": "Format files",
+      "longDescription": "",
+      "usage": "[files...]",
+      "source": {
+        "type": "default-plugin",
+        "plugin": "com.palantir.godel:format-plugin:1.0.0"
+      },
+      "verify": {
+        "ordering": 10,
+        "flags": [
+          {
+            "name": "skip-format",
+            "description": "skip formatting",
+            "type": "bool"
+          }
+        ],
+        "applyTrueArgs": [],
+        "applyFalseArgs": [
+          "--verify"
+        ]
+      }
+    },
+    {
+      "name": "help",
+      "description": "Print help for a task",
+      "longDescription": "",
+      "usage": "",
+      "source": {
+        "type": "builtin",
+        "plugin": ""
+      },
+      "verify": null
+    },
+    {
+      "name": "verify",
+      "description": "Run verify tasks",
+      "longDescription": "",
+      "usage": "",
+      "source": {
+        "type": "builtin",
+        "plugin": ""
+      },
+      "verify": null
+    }
+  ]
+}
+`,
+			"",
+		},
+		{
+			"single task",
+			[]string{"verify", "--format=json"},
+			`{
+  "name": "verify",
+  "description": "Run verify tasks",
+  "longDescription": "",
+  "usage": "",
+  "source": {
+    "type": "builtin",
+    "plugin": ""
+  },
+  "verify": null
+}
+`,
+			"",
+		},
+		{
+			"unknown task",
+			[]string{"--format", "json", "unknown"},
+			"",
+			`unknown command "unknown" for "godel"`,
+		},
+		{
+			"invalid format",
+			[]string{"--format", "yaml"},
+			"",
+			`invalid format "yaml": must be "text" or "json"`,
+		},
+	} {
+		global := godellauncher.GlobalConfig{
+			Task:     "help",
+			TaskArgs: tc.args,
+		}
+		task, err := godellauncher.TaskForInput(global, tasks)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+
+		buf := &bytes.Buffer{}
+		err = task.Run(global, buf)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
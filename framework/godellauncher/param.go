@@ -15,6 +15,10 @@
 package godellauncher
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/palantir/godel/v2/framework/artifactresolver"
 	"github.com/palantir/godel/v2/framework/builtintasks/installupdate/layout"
 	"github.com/palantir/pkg/specdir"
@@ -27,7 +31,55 @@ const (
 
 type TasksConfigProvidersParam struct {
 	DefaultResolvers []artifactresolver.Resolver
-	ConfigProviders  []artifactresolver.LocatorWithResolverParam
+	ConfigProviders  []ConfigProviderParam
+}
+
+// ConfigProviderParam specifies the source of a single configuration provider. Exactly one of Artifact, LocalPath and
+// Git is set.
+type ConfigProviderParam struct {
+	// Artifact is the locator and resolver for a configuration provider that is resolved as an artifact.
+	Artifact *artifactresolver.LocatorWithResolverParam
+	// LocalPath is the path to a local configuration provider file. Relative paths are resolved against the project
+	// directory.
+	LocalPath string
+	// Git specifies a configuration provider file stored in a git repository.
+	Git *GitConfigProviderParam
+	// Checksum is the expected SHA-256 checksum of the configuration provider file specified by Artifact. Configuration
+	// providers are OS-independent, so the checksum is the same on all platforms.
+	Checksum string
+	// Refresh specifies when the cached configuration provider is resolved again.
+	Refresh ConfigProviderRefreshParam
+}
+
+// ConfigProviderRefreshParam specifies when a configuration provider that has been cached in the gödel home directory
+// is resolved again. If neither field is set, the default behavior for the source of the configuration provider is used.
+type ConfigProviderRefreshParam struct {
+	// Always specifies that the configuration provider is resolved every time that it is loaded.
+	Always bool
+	// TTL specifies that the configuration provider is resolved if it was last resolved at least TTL ago.
+	TTL time.Duration
+}
+
+// String returns a description of the configuration provider that is suitable for use in messages.
+func (p ConfigProviderParam) String() string {
+	switch {
+	case p.Artifact != nil:
+		return p.Artifact.LocatorWithChecksums.Locator.String()
+	case p.Git != nil:
+		return p.Git.String()
+	default:
+		return p.LocalPath
+	}
+}
+
+type GitConfigProviderParam struct {
+	URL  string
+	Ref  string
+	Path string
+}
+
+func (p GitConfigProviderParam) String() string {
+	return fmt.Sprintf("%s (ref %s, path %s)", p.URL, p.Ref, p.Path)
 }
 
 type PluginsParam struct {
@@ -40,6 +92,76 @@ type SinglePluginParam struct {
 	Assets []artifactresolver.LocatorWithResolverParam
 }
 
+// UserTasksParam specifies the user-defined tasks.
+type UserTasksParam struct {
+	// Tasks are the user-defined tasks sorted by name.
+	Tasks []UserTaskParam
+}
+
+// UserTaskParam specifies a single user-defined task. Exactly one of Steps and Script is set.
+type UserTaskParam struct {
+	Name        string
+	Description string
+	// Steps are the tasks that are run in order by a composite task.
+	Steps []UserTaskStepParam
+	// Script is the shell command that is run by a script task.
+	Script string
+	// Verify is non-nil if the task is run as part of the "verify" task.
+	Verify *VerifyOptions
+}
+
+type UserTaskStepParam struct {
+	Task string
+	Args []string
+}
+
+// String returns the command line for the step.
+func (p UserTaskStepParam) String() string {
+	return strings.Join(append([]string{p.Task}, p.Args...), " ")
+}
+
+// HooksParam specifies the hooks that are run before and after tasks.
+type HooksParam struct {
+	// Hooks are the hooks for the tasks. The key is the name of the task.
+	Hooks map[string]TaskHooksParam
+}
+
+type TaskHooksParam struct {
+	Pre  []TaskHookParam
+	Post []TaskHookParam
+}
+
+// TaskHookParam specifies a single hook. Exactly one of Task and Script is set.
+type TaskHookParam struct {
+	// Task is the name of the task that is run by the hook.
+	Task string
+	Args []string
+	// Script is the shell command that is run by the hook.
+	Script string
+	// Warn specifies that a failure of the hook is printed as a warning rather than failing the task.
+	Warn bool
+}
+
+// String returns the command line of the task or the script that is run by the hook.
+func (p TaskHookParam) String() string {
+	if p.Script != "" {
+		return p.Script
+	}
+	return strings.Join(append([]string{p.Task}, p.Args...), " ")
+}
+
+// TimeoutsParam specifies the maximum amount of time that tasks can run for.
+type TimeoutsParam struct {
+	// Timeouts are the timeouts for the tasks. The key is the name of the task.
+	Timeouts map[string]time.Duration
+}
+
+// HistoryParam specifies how the task runs of the project are recorded in the task run history.
+type HistoryParam struct {
+	// Disabled is true if task runs are not recorded.
+	Disabled bool
+}
+
 // ConfigDirPath returns the path to the gödel configuration directory given the path to the project directory. Returns
 // an error if the directory structure does not match what is expected.
 func ConfigDirPath(projectDirPath string) (string, error) {
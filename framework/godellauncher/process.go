@@ -0,0 +1,82 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// shutdownGracePeriod is the amount of time that a process group is given to exit after it is signaled to stop before
+// it is killed.
+var shutdownGracePeriod = 10 * time.Second
+
+// SetProcessGroup configures the provided command to start in its own process group so that the signals sent to the
+// process group by StopProcessGroup reach the process and all of its descendants. If gödel is running in the
+// foreground of a terminal, the command is not configured and the process shares the process group of gödel: a
+// process in its own process group would be in the background of the terminal and would be stopped if it read from the
+// terminal. In that case, StopProcessGroup only signals the process itself, but the terminal sends the signals
+// generated by keys such as Ctrl-C to all of the processes in the foreground process group. Has no effect on Windows.
+func SetProcessGroup(cmd *exec.Cmd) {
+	setProcessGroup(cmd)
+}
+
+// StopProcessGroup stops the process group of the provided process, which must have been started using a command
+// configured by SetProcessGroup (if the process shares the process group of gödel, only the process is signaled). The
+// signal that gödel received is sent to the process group if the provided context was cancelled because of a signal
+// and SIGTERM is sent otherwise. If the provided exited channel is not closed within the grace period, the process
+// group is killed. On Windows, the process is killed immediately.
+func StopProcessGroup(ctx context.Context, process *os.Process, exited <-chan struct{}) {
+	var sig os.Signal = syscall.SIGTERM
+	if signalErr, ok := context.Cause(ctx).(*SignalError); ok {
+		sig = signalErr.Signal
+	}
+	if err := signalProcessGroup(process, sig); err != nil {
+		_ = killProcessGroup(process)
+		return
+	}
+	select {
+	case <-exited:
+	case <-time.After(shutdownGracePeriod):
+		_ = killProcessGroup(process)
+	}
+}
+
+// RunCommand runs the provided command in its own process group (see SetProcessGroup) and waits for it to complete. If
+// the provided context is done before the command exits, the process group is stopped using StopProcessGroup and the
+// cause of the context is returned.
+func RunCommand(ctx context.Context, cmd *exec.Cmd) error {
+	SetProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan struct{})
+	var waitErr error
+	go func() {
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		return waitErr
+	case <-ctx.Done():
+	}
+	StopProcessGroup(ctx, cmd.Process, exited)
+	<-exited
+	return context.Cause(ctx)
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// ptyHelperEnvVar is set when the test binary is run as the helper process of TestRunCommandReadsTerminal.
+const ptyHelperEnvVar = "GODEL_TEST_PTY_HELPER"
+
+func TestRunCommandReadsTerminal(t *testing.T) {
+	if os.Getenv(ptyHelperEnvVar) != "" {
+		// running as the helper process in the foreground of the pseudo-terminal: run a command that reads from it
+		cmd := exec.Command("sh", "-c", `read line; echo "read $line"`)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		if err := RunCommand(context.Background(), cmd); err != nil {
+			fmt.Println("failed:", err)
+		}
+		return
+	}
+
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %v", err)
+	}
+	defer func() {
+		_ = ptmx.Close()
+	}()
+	require.NoError(t, unix.IoctlSetPointerInt(int(ptmx.Fd()), unix.TIOCSPTLCK, 0))
+	ptyNum, err := unix.IoctlGetInt(int(ptmx.Fd()), unix.TIOCGPTN)
+	require.NoError(t, err)
+	pts, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", ptyNum), os.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err)
+	defer func() {
+		_ = pts.Close()
+	}()
+
+	// run the test binary in a new session whose controlling terminal is the pseudo-terminal
+	helper := exec.Command(os.Args[0], "-test.run=^TestRunCommandReadsTerminal$")
+	helper.Env = append(os.Environ(), ptyHelperEnvVar+"=1")
+	helper.Stdin = pts
+	helper.Stdout = pts
+	helper.Stderr = pts
+	helper.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:  true,
+		Setctty: true,
+	}
+	require.NoError(t, helper.Start())
+	_, err = ptmx.Write([]byte("input\n"))
+	require.NoError(t, err)
+
+	output := make(chan string)
+	go func() {
+		buf := &bytes.Buffer{}
+		readBuf := make([]byte, 1024)
+		for !bytes.Contains(buf.Bytes(), []byte("read input")) && !bytes.Contains(buf.Bytes(), []byte("failed:")) {
+			n, err := ptmx.Read(readBuf)
+			if err != nil {
+				break
+			}
+			buf.Write(readBuf[:n])
+		}
+		output <- buf.String()
+	}()
+	select {
+	case got := <-output:
+		assert.Contains(t, got, "read input")
+	case <-time.After(10 * time.Second):
+		// a command that reads from the terminal while in a background process group is stopped by SIGTTIN
+		assert.Fail(t, "command that reads from the terminal did not complete")
+	}
+	_ = helper.Process.Kill()
+	_ = helper.Wait()
+}
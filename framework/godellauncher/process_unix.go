@@ -0,0 +1,79 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package godellauncher
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	// a process in a background process group of the terminal is stopped by SIGTTIN when it reads from the terminal
+	// (for example, a prompt for credentials), so processes are only started in their own process group if gödel is
+	// not running in the foreground of a terminal. Processes that share the process group of gödel receive the
+	// signals that are sent by the terminal (such as SIGINT for Ctrl-C) directly.
+	if inTerminalForeground() {
+		return
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// inTerminalForeground returns true if gödel has a controlling terminal and its process group is the foreground
+// process group of the terminal.
+func inTerminalForeground() bool {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = tty.Close()
+	}()
+	foregroundPgrp, err := unix.IoctlGetInt(int(tty.Fd()), unix.TIOCGPGRP)
+	if err != nil {
+		return false
+	}
+	return foregroundPgrp == unix.Getpgrp()
+}
+
+// processGroupTarget returns the PID that should be signaled to signal the process group of the provided process: the
+// negative PID of the process if it is the leader of its own process group and the PID of the process otherwise (the
+// process shares the process group of gödel, which should not be signaled).
+func processGroupTarget(process *os.Process) int {
+	if pgid, err := unix.Getpgid(process.Pid); err == nil && pgid == process.Pid {
+		return -process.Pid
+	}
+	return process.Pid
+}
+
+func signalProcessGroup(process *os.Process, sig os.Signal) error {
+	sysSig, ok := sig.(syscall.Signal)
+	if !ok {
+		return errors.Errorf("unsupported signal: %v", sig)
+	}
+	return syscall.Kill(processGroupTarget(process), sysSig)
+}
+
+func killProcessGroup(process *os.Process) error {
+	return syscall.Kill(processGroupTarget(process), syscall.SIGKILL)
+}
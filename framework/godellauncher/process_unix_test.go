@@ -0,0 +1,97 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package godellauncher
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandStopsProcessGroup(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	for i, tc := range []struct {
+		name       string
+		script     string
+		wantOutput string
+	}{
+		{
+			"process group receives SIGTERM",
+			`trap 'echo terminated >> "$1"; exit 1' TERM; echo started >> "$1"; while true; do sleep 0.1; done`,
+			"started\nterminated\n",
+		},
+		{
+			"process group that ignores SIGTERM is killed after grace period",
+			`trap '' TERM; echo started >> "$1"; while true; do sleep 0.1; done`,
+			"started\n",
+		},
+	} {
+		origGracePeriod := shutdownGracePeriod
+		shutdownGracePeriod = 500 * time.Millisecond
+
+		outputFile := filepath.Join(tmpDir, "output.txt")
+		require.NoError(t, os.WriteFile(outputFile, nil, 0644), "Case %d: %s", i, tc.name)
+
+		timeoutErr := &TimeoutError{Task: "test", Timeout: time.Second}
+		ctx, cancel := context.WithCancelCause(context.Background())
+		go func() {
+			// cancel once the script has started
+			for {
+				if output, _ := os.ReadFile(outputFile); strings.HasPrefix(string(output), "started") {
+					cancel(timeoutErr)
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}()
+		err := RunCommand(ctx, exec.Command("sh", "-c", tc.script, "sh", outputFile))
+		shutdownGracePeriod = origGracePeriod
+
+		assert.Equal(t, timeoutErr, err, "Case %d: %s", i, tc.name)
+		output, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, string(output), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestSignalContext(t *testing.T) {
+	ctx, stop := SignalContext(context.Background())
+	defer stop()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGINT))
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "context was not cancelled")
+	}
+	signalErr, ok := context.Cause(ctx).(*SignalError)
+	require.True(t, ok, "unexpected cause: %v", context.Cause(ctx))
+	assert.Equal(t, syscall.SIGINT, signalErr.Signal)
+	assert.Equal(t, 130, ExitCode(signalErr))
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// SignalError is the cause of the cancellation of the context provided to tasks when gödel receives a signal.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("received signal: %v", e.Signal)
+}
+
+// ExitCode returns the conventional exit code for a process that was terminated by the signal: 128 plus the number of
+// the signal.
+func (e *SignalError) ExitCode() int {
+	if sig, ok := e.Signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return 1
+}
+
+// timeoutExitCode is the exit code for a task that timed out. Matches the exit code used by the "timeout" command.
+const timeoutExitCode = 124
+
+// TimeoutError is the cause of the cancellation of the context provided to a task when the task times out.
+type TimeoutError struct {
+	Task    string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("task %s timed out after %v", e.Task, e.Timeout)
+}
+
+func (e *TimeoutError) ExitCode() int {
+	return timeoutExitCode
+}
+
+// SignalContext returns a context that is cancelled with a *SignalError cause when gödel receives SIGINT or SIGTERM.
+// Once the first signal is received, the default behavior for the signals is restored so that a second signal
+// terminates gödel immediately. The returned function stops the handling of the signals and should be called once the
+// context is no longer needed.
+func SignalContext(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-sigCh:
+			signal.Stop(sigCh)
+			cancel(&SignalError{
+				Signal: sig,
+			})
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(sigCh)
+		cancel(context.Canceled)
+	}
+}
@@ -15,6 +15,7 @@
 package godellauncher
 
 import (
+	"context"
 	"io"
 	"strconv"
 
@@ -29,19 +30,44 @@ type Task struct {
 	// The description for this task. Should be suitable to use as the command description in CLI help.
 	Description string
 
+	// LongDescription is the detailed description of the task that is printed by "help <task>". If empty, Description
+	// is used.
+	LongDescription string
+
+	// Usage describes the arguments of the task in the usage line printed by "help <task>": it is the text that follows
+	// the task name (for example, "[flags] [products...]"). Can be blank.
+	Usage string
+
+	// Source describes where the task is defined. Tasks are grouped by their source in the help output.
+	Source TaskSource
+
 	// The name of the configuration file for the task ("task.yml", etc.). Can be blank if the task does not require
 	// file-based configuration.
 	ConfigFile string
 
+	// ConfigSchema is the JSON Schema for the configuration file of the task. Nil if the task does not provide a schema
+	// for its configuration.
+	ConfigSchema []byte
+
 	// Configures the manner in which the global flags are processed.
 	GlobalFlagOpts GlobalFlagOptions
 
 	// Verify stores the option for the "--verify" task. If non-nil, this command is run as part of the "verify" task.
 	Verify *VerifyOptions
 
+	// Completion describes the flags, arguments and subcommands of the task for shell completion. Nil if they are not
+	// known, in which case only file names are completed for the arguments of the task.
+	Completion *Completion
+
 	// The runner that is invoked to run this task. Should be possible to run in-process (that is, this function should
-	// not call os.Exit or equivalent).
+	// not call os.Exit or equivalent). Not used if RunContextImpl is non-nil.
 	RunImpl func(t *Task, global GlobalConfig, stdout io.Writer) error
+
+	// RunContextImpl is the runner that is invoked to run this task with a context. If non-nil, it is used instead of
+	// RunImpl. The context is cancelled when gödel receives SIGINT or SIGTERM or when the task times out, and the reason
+	// that it was cancelled (a *SignalError or a *TimeoutError) is available using context.Cause. The runner should stop
+	// any processes that it started and return promptly once the context is done.
+	RunContextImpl func(ctx context.Context, t *Task, global GlobalConfig, stdout io.Writer) error
 }
 
 type GlobalFlagOptions struct {
@@ -127,6 +153,66 @@ const (
 	BoolFlag
 )
 
+// TaskSource describes where a task is defined.
+type TaskSource struct {
+	// Type is the type of the source.
+	Type TaskSourceType
+	// Plugin is the locator of the plugin that provides the task (for example, "com.palantir.godel:dist-plugin:1.0.0").
+	// Empty if the task is not provided by a plugin.
+	Plugin string
+}
+
+// TaskSourceType is the type of the source of a task.
+type TaskSourceType int
+
+const (
+	// BuiltinTaskSource is the source of the tasks that are built into gödel.
+	BuiltinTaskSource TaskSourceType = iota
+	// DefaultPluginTaskSource is the source of the tasks provided by the default plugins.
+	DefaultPluginTaskSource
+	// PluginTaskSource is the source of the tasks provided by the plugins configured for the project.
+	PluginTaskSource
+	// GodelConfigTaskSource is the source of the tasks and aliases defined in godel.yml.
+	GodelConfigTaskSource
+)
+
+// String returns the name of the source type that is used in machine-readable output: "builtin", "default-plugin",
+// "plugin" or "godel-config".
+func (t TaskSourceType) String() string {
+	switch t {
+	case DefaultPluginTaskSource:
+		return "default-plugin"
+	case PluginTaskSource:
+		return "plugin"
+	case GodelConfigTaskSource:
+		return "godel-config"
+	default:
+		return "builtin"
+	}
+}
+
 func (t *Task) Run(global GlobalConfig, stdout io.Writer) error {
-	return t.RunImpl(t, global, stdout)
+	return t.RunContext(context.Background(), global, stdout)
+}
+
+// RunContext runs the task with the provided context. If the task does not have a RunContextImpl, its RunImpl is run in
+// a separate goroutine and the cause of the context is returned without waiting for RunImpl to return if the context is
+// done first.
+func (t *Task) RunContext(ctx context.Context, global GlobalConfig, stdout io.Writer) error {
+	if t.RunContextImpl != nil {
+		return t.RunContextImpl(ctx, t, global, stdout)
+	}
+	if ctx.Done() == nil {
+		return t.RunImpl(t, global, stdout)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- t.RunImpl(t, global, stdout)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
 }
@@ -0,0 +1,59 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godellauncher_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRunContext(t *testing.T) {
+	// RunImpl is used for tasks that do not specify a RunContextImpl
+	task := godellauncher.Task{
+		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			return errors.New("run")
+		},
+	}
+	assert.EqualError(t, task.Run(godellauncher.GlobalConfig{}, io.Discard), "run")
+
+	// the cause of the context is returned without waiting for a RunImpl that does not return
+	block := make(chan struct{})
+	defer close(block)
+	task = godellauncher.Task{
+		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			<-block
+			return nil
+		},
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("cancelled"))
+	assert.EqualError(t, task.RunContext(ctx, godellauncher.GlobalConfig{}, io.Discard), "cancelled")
+
+	// RunContextImpl is used if it is specified
+	task = godellauncher.Task{
+		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			return errors.New("run")
+		},
+		RunContextImpl: func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			return errors.New("run context")
+		},
+	}
+	assert.EqualError(t, task.Run(godellauncher.GlobalConfig{}, io.Discard), "run context")
+}
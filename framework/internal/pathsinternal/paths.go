@@ -15,6 +15,8 @@
 package pathsinternal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 
@@ -36,6 +38,20 @@ func ConfigProviderFileName(locator artifactresolver.Locator) string {
 	return PluginFileName(locator) + ".yml"
 }
 
+// GitConfigProviderFileName returns the name of the file that stores the configuration provider file at the specified
+// path in the git repository with the specified URL at the specified commit.
+func GitConfigProviderFileName(url, path, commit string) string {
+	sum := sha256.Sum256([]byte(url + "\n" + path))
+	return fmt.Sprintf("git-%s-%s.yml", hex.EncodeToString(sum[:])[:16], commit)
+}
+
+// GitConfigProviderRefFileName returns the name of the file that stores the commit that the specified ref resolved to
+// in the git repository with the specified URL.
+func GitConfigProviderRefFileName(url, ref string) string {
+	sum := sha256.Sum256([]byte(url + "\n" + ref))
+	return fmt.Sprintf("git-%s.ref", hex.EncodeToString(sum[:])[:16])
+}
+
 func ResourceDirs() (pluginsDir string, assetsDir string, downloadsDir string, rErr error) {
 	godelHomeSpecDir, err := layout.GodelHomeSpecDir(specdir.Create)
 	if err != nil {
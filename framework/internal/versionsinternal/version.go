@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package installupdate
+package versionsinternal
 
 import (
 	"fmt"
@@ -56,7 +56,7 @@ var releaseRegexps = []*regexp.Regexp{
 	NonOrderable:             regexp.MustCompile(`^([0-9]+)\.([0-9]+)\.([0-9])+(-[a-z0-9-]+)?(\.dirty)?$`),
 }
 
-type godelVersion struct {
+type Version struct {
 	version string
 
 	// computed once on construction and stored
@@ -68,47 +68,47 @@ type godelVersion struct {
 	secondSequenceVersionNum *int
 }
 
-func (v godelVersion) String() string {
+func (v Version) String() string {
 	return v.version
 }
 
-func (v godelVersion) Type() Type {
+func (v Version) Type() Type {
 	return getType(v.version)
 }
 
-func (v godelVersion) Orderable() bool {
+func (v Version) Orderable() bool {
 	typ := v.Type()
 	return typ >= ReleaseCandidate && typ < NonOrderable
 }
 
-func (v godelVersion) Value() string {
+func (v Version) Value() string {
 	return v.version
 }
 
-func (v godelVersion) MajorVersionNum() int {
+func (v Version) MajorVersionNum() int {
 	return v.majorVersionNum
 }
 
-func (v godelVersion) MinorVersionNum() int {
+func (v Version) MinorVersionNum() int {
 	return v.minorVersionNum
 }
 
-func (v godelVersion) PatchVersionNum() int {
+func (v Version) PatchVersionNum() int {
 	return v.patchVersionNum
 }
 
-func (v godelVersion) FirstSequenceVersionNum() *int {
+func (v Version) FirstSequenceVersionNum() *int {
 	return v.firstSequenceVersionNum
 }
 
-func (v godelVersion) SecondSequenceVersionNum() *int {
+func (v Version) SecondSequenceVersionNum() *int {
 	return v.secondSequenceVersionNum
 }
 
-func newGodelVersion(v string) (godelVersion, error) {
+func NewVersion(v string) (Version, error) {
 	typ := getType(v)
 	if typ == unknown {
-		return godelVersion{}, fmt.Errorf("%s is not a valid SLS version", v)
+		return Version{}, fmt.Errorf("%s is not a valid SLS version", v)
 	}
 
 	matches := releaseRegexps[typ].FindStringSubmatch(v)
@@ -124,7 +124,7 @@ func newGodelVersion(v string) (godelVersion, error) {
 		secondSequenceVersionNum = &n
 	}
 
-	return godelVersion{
+	return Version{
 		version:                  v,
 		typ:                      typ,
 		majorVersionNum:          mustAtoI(matches[1]),
@@ -148,7 +148,7 @@ func getType(v string) Type {
 // always returns -1 and false. If both versions are orderable, then returns -1 if the receiver is less than the
 // argument, 0 if they are equal and 1 if the receiver is greater than the argument. If both versions are orderable, the
 // second return value is always true.
-func (v godelVersion) CompareTo(o godelVersion) (int, bool) {
+func (v Version) CompareTo(o Version) (int, bool) {
 	// if either input is not orderable, always return -1 and false
 	if !v.Orderable() || !o.Orderable() {
 		return -1, false
@@ -192,6 +192,22 @@ func (v godelVersion) CompareTo(o godelVersion) (int, bool) {
 	return 0, true
 }
 
+// CompareVersions compares the provided version strings using the semantics of CompareTo. Returns an error if either of
+// the provided strings is not a valid SLS version. If both versions are valid but either is not orderable, returns -1
+// and false.
+func CompareVersions(v, o string) (int, bool, error) {
+	vVersion, err := NewVersion(v)
+	if err != nil {
+		return -1, false, err
+	}
+	oVersion, err := NewVersion(o)
+	if err != nil {
+		return -1, false, err
+	}
+	cmp, ok := vVersion.CompareTo(oVersion)
+	return cmp, ok, nil
+}
+
 func compareInts(val, other int) int {
 	switch {
 	default:
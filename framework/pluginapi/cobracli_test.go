@@ -0,0 +1,55 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
+	"github.com/palantir/godel/v2/pkg/versionedconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCobraMigratorUpgradeConfigCmd(t *testing.T) {
+	type configV0 struct {
+		Name string `yaml:"name"`
+	}
+	type configV1 struct {
+		versionedconfig.ConfigWithVersion `yaml:",inline"`
+		Names                             []string `yaml:"names"`
+	}
+	migrator, err := versionedconfig.NewMigrator("test-plugin",
+		versionedconfig.VersionWithMigration("0", func(cfg configV0) (configV1, error) {
+			return configV1{
+				Names: []string{cfg.Name},
+			}, nil
+		}),
+		versionedconfig.Version[configV1]("1"),
+	)
+	require.NoError(t, err)
+
+	cmd := pluginapi.CobraMigratorUpgradeConfigCmd(migrator)
+	outputBuf := &bytes.Buffer{}
+	cmd.SetOut(outputBuf)
+	cmd.SetArgs([]string{base64.StdEncoding.EncodeToString([]byte("name: foo\n"))})
+	require.NoError(t, cmd.Execute())
+
+	upgradedCfg, err := base64.StdEncoding.DecodeString(outputBuf.String())
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1\nnames:\n  - foo\n", string(upgradedCfg))
+}
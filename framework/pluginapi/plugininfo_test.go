@@ -27,6 +27,7 @@ import (
 	"github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +101,44 @@ func TestPluginInfoJSONMarshal(t *testing.T) {
 			},
 			`{"pluginSchemaVersion":"2","group":"group","product":"product-plugin","version":"1.0.0","usesConfig":true,"tasks":[{"name":"foo","description":"does foo things","command":["foo"],"globalFlagOptions":{"debugFlag":"","projectDirFlag":"--project-dir","godelConfigFlag":"","configFlag":""},"verifyOptions":{"verifyTaskFlags":null,"ordering":null,"applyTrueArgs":null,"applyFalseArgs":null}}],"upgradeTask":null}`,
 		},
+		{
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoTaskInfo("foo", "does foo things",
+					pluginapi.TaskInfoCompletion(
+						pluginapi.CompletionFlag("product", "p", "product to build", godellauncher.StringFlag, pluginapi.CompletionTaskValues("products")),
+						pluginapi.CompletionSubcommand("clean", "removes foo",
+							pluginapi.CompletionArgs(pluginapi.CompletionStaticValues("all")),
+						),
+					),
+				),
+			},
+			`{"pluginSchemaVersion":"2","group":"group","product":"product-plugin","version":"1.0.0","usesConfig":false,"tasks":[{"name":"foo","description":"does foo things","command":null,"globalFlagOptions":null,"verifyOptions":null,"completion":{"flags":[{"name":"product","shorthand":"p","description":"product to build","type":0,"values":{"task":["products"]}}],"subcommands":[{"name":"clean","description":"removes foo","args":{"values":["all"]}}]}}],"upgradeTask":null}`,
+		},
+		{
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoTaskInfo("foo", "does foo things",
+					pluginapi.TaskInfoHelp("Does foo things in detail.", "[flags] [args...]"),
+				),
+			},
+			`{"pluginSchemaVersion":"2","group":"group","product":"product-plugin","version":"1.0.0","usesConfig":false,"tasks":[{"name":"foo","description":"does foo things","command":null,"globalFlagOptions":null,"verifyOptions":null,"longDescription":"Does foo things in detail.","usage":"[flags] [args...]"}],"upgradeTask":null}`,
+		},
+		{
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoGodelVersionRange("2.50.0", "3.0.0"),
+			},
+			`{"pluginSchemaVersion":"2","group":"group","product":"product-plugin","version":"1.0.0","usesConfig":false,"tasks":null,"upgradeTask":null,"godelVersionRange":{"minVersion":"2.50.0","maxVersion":"3.0.0"}}`,
+		},
+		{
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoUsesConfigFile(),
+				pluginapi.PluginInfoConfigSchema([]byte(`{"type":"object"}`)),
+			},
+			`{"pluginSchemaVersion":"2","group":"group","product":"product-plugin","version":"1.0.0","usesConfig":true,"tasks":null,"upgradeTask":null,"configSchema":{"type":"object"}}`,
+		},
 	} {
 		info, err := pluginapi.NewPluginInfo(tc.group, tc.product, tc.version, tc.params...)
 		require.NoError(t, err, "Case %d", i)
@@ -111,6 +150,87 @@ func TestPluginInfoJSONMarshal(t *testing.T) {
 	}
 }
 
+func TestPluginInfoCompletionFromCobraCommand(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "foo-plugin",
+	}
+	pluginapi.AddAllPFlagsPtrs(rootCmd.PersistentFlags(), new(bool), new(string), new(string), new(string), new([]string))
+	fooCmd := &cobra.Command{
+		Use:   "foo",
+		Short: "does foo things",
+		Annotations: map[string]string{
+			godellauncher.CompletionTaskAnnotation: "packages",
+		},
+	}
+	fooCmd.Flags().Bool("dry-run", false, "print what would be done")
+	rootCmd.AddCommand(fooCmd)
+
+	info, err := pluginapi.NewPluginInfo("group", "foo-plugin", "1.0.0",
+		pluginapi.PluginInfoGlobalFlagOptions(
+			pluginapi.GlobalFlagOptionsParamDebugFlag("--"+pluginapi.DebugFlagName),
+			pluginapi.GlobalFlagOptionsParamProjectDirFlag("--"+pluginapi.ProjectDirFlagName),
+			pluginapi.GlobalFlagOptionsParamGodelConfigFlag("--"+pluginapi.GodelConfigFlagName),
+			pluginapi.GlobalFlagOptionsParamConfigFlag("--"+pluginapi.ConfigFlagName),
+		),
+		pluginapi.PluginInfoTaskInfo("foo", "does foo things",
+			pluginapi.TaskInfoCommand("foo"),
+			pluginapi.TaskInfoCompletionFromCobraCommand(fooCmd),
+		),
+	)
+	require.NoError(t, err)
+
+	// the completion information is provided to gödel as part of the JSON-serialized plugin info
+	infoJSON, err := info.MarshalPluginInfoJSON()
+	require.NoError(t, err)
+	info, err = pluginapi.UnmarshalPluginInfoJSON(infoJSON)
+	require.NoError(t, err)
+
+	tasks := info.Tasks("", nil)
+	require.Len(t, tasks, 1)
+	// the flags that gödel provides to the plugin are not part of the completion information
+	assert.Equal(t, &godellauncher.Completion{
+		Flags: []godellauncher.CompletionFlag{
+			{Name: "dry-run", Description: "print what would be done", Type: godellauncher.BoolFlag},
+		},
+		Args: &godellauncher.CompletionValues{
+			Task: []string{"packages"},
+		},
+	}, tasks[0].Completion)
+}
+
+func TestPluginInfoHelpFromCobraCommand(t *testing.T) {
+	fooCmd := &cobra.Command{
+		Use:   "foo [flags] [packages...]",
+		Short: "does foo things",
+		Long:  "Does foo things to the provided packages.",
+	}
+	fooCmd.Flags().Bool("dry-run", false, "print what would be done")
+
+	info, err := pluginapi.NewPluginInfo("group", "foo-plugin", "1.0.0",
+		pluginapi.PluginInfoTaskInfo("foo", "does foo things",
+			pluginapi.TaskInfoCommand("foo"),
+			pluginapi.TaskInfoHelpFromCobraCommand(fooCmd),
+		),
+	)
+	require.NoError(t, err)
+
+	// the help information is cached as part of the JSON-serialized plugin info
+	infoJSON, err := info.MarshalPluginInfoJSON()
+	require.NoError(t, err)
+	info, err = pluginapi.UnmarshalPluginInfoJSON(infoJSON)
+	require.NoError(t, err)
+
+	tasks := info.Tasks("", nil)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Does foo things to the provided packages.", tasks[0].LongDescription)
+	assert.Equal(t, "[flags] [packages...]", tasks[0].Usage)
+	assert.Equal(t, &godellauncher.Completion{
+		Flags: []godellauncher.CompletionFlag{
+			{Name: "dry-run", Description: "print what would be done", Type: godellauncher.BoolFlag},
+		},
+	}, tasks[0].Completion)
+}
+
 func TestNewPluginInfoError(t *testing.T) {
 	for i, tc := range []struct {
 		name                    string
@@ -135,6 +255,47 @@ func TestNewPluginInfoError(t *testing.T) {
 			},
 			`plugin group:product-plugin:1.0.0 provides a configuration upgrade task but does not specify that it uses configuration`,
 		},
+		{
+			"gödel version range must specify a minimum or maximum",
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoGodelVersionRange("", ""),
+			},
+			`at least one of minimum or maximum version must be provided for gödel version range`,
+		},
+		{
+			"gödel version range bounds must be orderable versions",
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoGodelVersionRange("latest", ""),
+			},
+			`invalid minimum version "latest" for gödel version range: must be an orderable version such as "2.100.0"`,
+		},
+		{
+			"gödel version range minimum must be lower than maximum",
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoGodelVersionRange("3.0.0", "2.40.0"),
+			},
+			`invalid gödel version range: minimum version 3.0.0 must be lower than maximum version 2.40.0`,
+		},
+		{
+			"plugin cannot provide configuration schema if it does not use configuration",
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoConfigSchema([]byte(`{"type":"object"}`)),
+			},
+			`plugin group:product-plugin:1.0.0 provides a configuration schema but does not specify that it uses configuration`,
+		},
+		{
+			"configuration schema must be a JSON object",
+			"group", "product-plugin", "1.0.0",
+			[]pluginapi.PluginInfoParam{
+				pluginapi.PluginInfoUsesConfigFile(),
+				pluginapi.PluginInfoConfigSchema([]byte(`["object"]`)),
+			},
+			`configuration schema must be a JSON object: json: cannot unmarshal array into Go value of type map[string]interface {}`,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			_, err := pluginapi.NewPluginInfo(tc.group, tc.product, tc.version, tc.params...)
@@ -0,0 +1,165 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginInfoFileEnvVar is the environment variable that, when set, causes the test binary to act as a plugin whose
+// plugin info is read from the file at the path specified by the variable.
+const testPluginInfoFileEnvVar = "GODEL_PLUGINAPI_TEST_PLUGIN_INFO_FILE"
+
+func TestMain(m *testing.M) {
+	if infoFile := os.Getenv(testPluginInfoFileEnvVar); infoFile != "" {
+		os.Exit(runTestPlugin(infoFile))
+	}
+	os.Exit(m.Run())
+}
+
+// runTestPlugin runs the test binary as a long-lived process plugin. The "echo" command prints its arguments and
+// process ID, the "fail" command prints to stderr and exits with exit code 3, the "sleep" command sleeps for a minute
+// and the "upgrade-config" command prints the provided base64-encoded configuration.
+func runTestPlugin(infoFile string) int {
+	infoBytes, err := os.ReadFile(infoFile)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	info, err := pluginapi.UnmarshalPluginInfoJSON(infoBytes)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if pluginapi.InfoCmd(os.Args, os.Stdout, info) {
+		return 0
+	}
+	if pluginapi.ServeCmd(os.Args, info, func(args []string, stdout, stderr io.Writer) int {
+		switch {
+		case len(args) > 0 && args[0] == "fail":
+			_, _ = fmt.Fprintln(stderr, "Error: task failed")
+			return 3
+		case len(args) > 0 && args[0] == "sleep":
+			time.Sleep(time.Minute)
+			return 0
+		case len(args) == 2 && args[0] == "upgrade-config":
+			_, _ = fmt.Fprint(stdout, args[1])
+			return 0
+		default:
+			// print using fmt.Println to verify that os.Stdout is redirected
+			fmt.Println(strings.Join(args, " "), os.Getpid())
+			return 0
+		}
+	}) {
+		return 0
+	}
+	_, _ = fmt.Fprintln(os.Stderr, "plugin must be invoked using", pluginapi.PluginServeCommandName)
+	return 1
+}
+
+func TestLongLivedPluginProcess(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	info, err := pluginapi.NewPluginInfo("group", "echo-plugin", "1.0.0",
+		pluginapi.PluginInfoUsesConfigFile(),
+		pluginapi.PluginInfoLongLivedProcess(),
+		pluginapi.PluginInfoTaskInfo("echo", "echoes the provided input", pluginapi.TaskInfoCommand("echo")),
+		pluginapi.PluginInfoTaskInfo("fail", "fails", pluginapi.TaskInfoCommand("fail")),
+		pluginapi.PluginInfoTaskInfo("sleep", "sleeps", pluginapi.TaskInfoCommand("sleep")),
+		pluginapi.PluginInfoUpgradeConfigTaskInfo(pluginapi.UpgradeConfigTaskInfoCommand("upgrade-config")),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, pluginapi.LongLivedProcessSchemaVersion, info.PluginSchemaVersion())
+
+	infoJSON, err := info.MarshalPluginInfoJSON()
+	require.NoError(t, err)
+	infoFile := filepath.Join(tmpDir, "info.json")
+	require.NoError(t, os.WriteFile(infoFile, infoJSON, 0644))
+
+	testExecutable, err := os.Executable()
+	require.NoError(t, err)
+	pluginExecPath := filepath.Join(tmpDir, "echo-plugin.sh")
+	err = os.WriteFile(pluginExecPath, fmt.Appendf(nil, "#!/bin/sh\n%s=%s exec %s \"$@\"\n", testPluginInfoFileEnvVar, infoFile, testExecutable), 0755)
+	require.NoError(t, err)
+	defer pluginapi.ClosePluginProcesses()
+
+	pluginInfo, err := pluginapi.InfoFromPlugin(pluginExecPath)
+	require.NoError(t, err)
+	assert.Equal(t, pluginapi.LongLivedProcessSchemaVersion, pluginInfo.PluginSchemaVersion())
+
+	tasks := pluginInfo.Tasks(pluginExecPath, nil)
+	require.Equal(t, 3, len(tasks))
+
+	runEcho := func(args ...string) (string, string) {
+		outBuf := &bytes.Buffer{}
+		err := tasks[0].Run(godellauncher.GlobalConfig{
+			TaskArgs: args,
+		}, outBuf)
+		require.NoError(t, err)
+		parts := strings.Fields(outBuf.String())
+		require.NotEmpty(t, parts)
+		return strings.Join(parts[:len(parts)-1], " "), parts[len(parts)-1]
+	}
+
+	output, pid := runEcho("foo", "bar")
+	assert.Equal(t, "echo foo bar", output)
+	output, secondPid := runEcho("baz")
+	assert.Equal(t, "echo baz", output)
+	assert.Equal(t, pid, secondPid, "tasks should be run using the same plugin process")
+
+	outBuf := &bytes.Buffer{}
+	err = tasks[1].Run(godellauncher.GlobalConfig{}, outBuf)
+	require.Error(t, err)
+	assert.Equal(t, "", err.Error())
+	assert.Equal(t, 3, godellauncher.ExitCode(err))
+	assert.Equal(t, "Error: task failed\n", outBuf.String())
+
+	// the plugin process is stopped if the context of a task is done before the task completes
+	_, sleepPid := runEcho("before sleep")
+	timeoutErr := &godellauncher.TimeoutError{Task: "sleep", Timeout: 100 * time.Millisecond}
+	ctx, cancel := context.WithTimeoutCause(context.Background(), timeoutErr.Timeout, timeoutErr)
+	defer cancel()
+	err = tasks[2].RunContext(ctx, godellauncher.GlobalConfig{}, io.Discard)
+	assert.Equal(t, timeoutErr, err)
+	_, afterSleepPid := runEcho("after sleep")
+	assert.NotEqual(t, sleepPid, afterSleepPid, "a new plugin process should be started after the process is stopped")
+	pid = afterSleepPid
+
+	upgradeTask := pluginInfo.UpgradeConfigTask(pluginExecPath, nil)
+	require.NotNil(t, upgradeTask)
+	upgraded, err := upgradeTask.Run([]byte("foo: bar\n"), godellauncher.GlobalConfig{}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "foo: bar\n", string(upgraded))
+
+	pluginapi.ClosePluginProcesses()
+	_, restartedPid := runEcho("foo")
+	assert.NotEqual(t, pid, restartedPid, "a new plugin process should be started after processes are closed")
+}
@@ -15,6 +15,7 @@
 package pluginapi
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -146,7 +147,7 @@ func (ti taskInfoImpl) toTask(pluginExecPath, cfgFileName string, assets []strin
 		ConfigFile:     cfgFileName,
 		Verify:         verifyOpts,
 		GlobalFlagOpts: globalFlagOpts,
-		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+		RunContextImpl: func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
 			cmdArgs, err := globalFlagArgs(t.GlobalFlagOpts, t.ConfigFile, global)
 			if err != nil {
 				return err
@@ -162,9 +163,17 @@ func (ti taskInfoImpl) toTask(pluginExecPath, cfgFileName string, assets []strin
 			cmd.Stdout = stdout
 			cmd.Stderr = stdout
 			cmd.Stdin = os.Stdin
-			if err := cmd.Run(); err != nil {
+			// run the plugin in its own process group so that it is stopped gracefully if the context is done
+			if err := godellauncher.RunCommand(ctx, cmd); err != nil {
+				if ctx.Err() != nil {
+					// the plugin was stopped because the context is done
+					return context.Cause(ctx)
+				}
 				if _, ok := err.(*exec.ExitError); ok {
-					// create empty error because command will likely print its own error
+					// create error with empty message because command will likely print its own error
+					if exitCodeErr := godellauncher.ExitCodeErrorFromExec(err, ""); exitCodeErr != nil {
+						return exitCodeErr
+					}
 					return fmt.Errorf("")
 				}
 				return errors.Wrapf(err, "plugin execution failed")
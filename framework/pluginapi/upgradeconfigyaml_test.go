@@ -0,0 +1,75 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
+	"github.com/palantir/godel/v2/pkg/yamlnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+	yamlv2 "gopkg.in/yaml.v2"
+)
+
+func TestUpgradeYAMLConfig(t *testing.T) {
+	got, err := pluginapi.UpgradeYAMLConfig([]byte(`# comment
+checks:
+  # comment for foo
+  foo:
+    enabled: true
+`), func(doc *yaml.Node) error {
+		checks := yamlnode.MappingValue(doc.Content[0], "checks")
+		yamlnode.SetMappingValue(checks, "bar", yamlnode.NewMapping())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `# comment
+checks:
+  # comment for foo
+  foo:
+    enabled: true
+  bar: {}
+`, string(got))
+}
+
+func TestPreserveYAMLFormatting(t *testing.T) {
+	type config struct {
+		Version string            `yaml:"version"`
+		Checks  map[string]string `yaml:"checks"`
+	}
+	upgradeFn := pluginapi.PreserveYAMLFormatting(func(cfg []byte) ([]byte, error) {
+		var upgradedCfg config
+		if err := yamlv2.Unmarshal(cfg, &upgradedCfg); err != nil {
+			return nil, err
+		}
+		upgradedCfg.Version = "1"
+		return yamlv2.Marshal(upgradedCfg)
+	})
+
+	got, err := upgradeFn([]byte(`# checks comment
+checks:
+  zeta: "z" # zeta comment
+  alpha: a
+`))
+	require.NoError(t, err)
+	assert.Equal(t, `version: "1"
+# checks comment
+checks:
+  zeta: "z" # zeta comment
+  alpha: a
+`, string(got))
+}
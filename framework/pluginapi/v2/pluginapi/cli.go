@@ -15,7 +15,9 @@
 package pluginapi
 
 import (
+	"fmt"
 	"io"
+	"os"
 )
 
 func InfoCmd(osArgs []string, stdout io.Writer, info PluginInfo) bool {
@@ -25,3 +27,16 @@ func InfoCmd(osArgs []string, stdout io.Writer, info PluginInfo) bool {
 	_ = infoAction(info, stdout)
 	return true
 }
+
+// ServeCmd serves the long-lived plugin process protocol using the provided function to run tasks if osArgs invokes
+// the PluginServeCommandName command. Returns true if the command was handled, in which case the caller should exit.
+// Only plugins that specify the PluginInfoLongLivedProcess parameter are invoked using this command.
+func ServeCmd(osArgs []string, info PluginInfo, runFn PluginRunFn) bool {
+	if len(osArgs) < 2 || osArgs[1] != PluginServeCommandName {
+		return false
+	}
+	if err := ServePluginProcess(info, runFn, os.Stdin, os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	return true
+}
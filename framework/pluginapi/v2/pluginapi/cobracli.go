@@ -19,7 +19,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 
+	"github.com/palantir/godel/v2/pkg/versionedconfig"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +39,30 @@ func CobraInfoCmd(info PluginInfo) *cobra.Command {
 	}
 }
 
+// CobraServeCmd returns the hidden command that serves the long-lived plugin process protocol for plugins that
+// specify the PluginInfoLongLivedProcess parameter. newRootCmd must return a new root command for the plugin every
+// time it is called: every task is run by executing a new root command with the task arguments, so the command (and
+// any flag variables that it binds) must not carry state over from previous invocations.
+func CobraServeCmd(info PluginInfo, newRootCmd func() *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:    PluginServeCommandName,
+		Short:  "Serves the gödel long-lived plugin process protocol over standard input and output",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ServePluginProcess(info, func(args []string, stdout, stderr io.Writer) int {
+				rootCmd := newRootCmd()
+				rootCmd.SetArgs(args)
+				rootCmd.SetOut(stdout)
+				rootCmd.SetErr(stderr)
+				if err := rootCmd.Execute(); err != nil {
+					return 1
+				}
+				return 0
+			}, os.Stdin, os.Stdout)
+		},
+	}
+}
+
 type UpgradeConfigFn func(cfg []byte) ([]byte, error)
 
 func CobraUpgradeConfigCmd(upgradeFn UpgradeConfigFn) *cobra.Command {
@@ -65,6 +91,13 @@ is printed unmodified. If the upgrade fails, exits with a non-0 exit code and pr
 	}
 }
 
+// CobraMigratorUpgradeConfigCmd returns the "upgrade-config" command for a plugin whose configuration is upgraded using
+// the provided migrator. The plugin should also specify the PluginInfoUpgradeConfigTaskInfo parameter with the command
+// path of the returned command.
+func CobraMigratorUpgradeConfigCmd(migrator *versionedconfig.Migrator) *cobra.Command {
+	return CobraUpgradeConfigCmd(migrator.Upgrade)
+}
+
 func infoAction(info PluginInfo, w io.Writer) error {
 	bytes, err := json.Marshal(info)
 	if err != nil {
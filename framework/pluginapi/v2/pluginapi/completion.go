@@ -0,0 +1,275 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+import (
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/spf13/cobra"
+)
+
+// completionImpl is the JSON-serializable representation of a godellauncher.Completion. Refer to that struct for field
+// documentation.
+type completionImpl struct {
+	FlagsVar       []completionFlagImpl    `json:"flags,omitempty"`
+	ArgsVar        *completionValuesImpl   `json:"args,omitempty"`
+	SubcommandsVar []completionCommandImpl `json:"subcommands,omitempty"`
+}
+
+type completionCommandImpl struct {
+	NameVar        string `json:"name"`
+	DescriptionVar string `json:"description"`
+	completionImpl
+}
+
+type completionFlagImpl struct {
+	NameVar        string                 `json:"name"`
+	ShorthandVar   string                 `json:"shorthand,omitempty"`
+	DescriptionVar string                 `json:"description"`
+	TypeVar        godellauncher.FlagType `json:"type"`
+	ValuesVar      *completionValuesImpl  `json:"values,omitempty"`
+}
+
+type completionValuesImpl struct {
+	ValuesVar []string `json:"values,omitempty"`
+	TaskVar   []string `json:"task,omitempty"`
+}
+
+// CompletionValues specifies the values that are completed for a flag or for the arguments of a command. Refer to
+// godellauncher.CompletionValues for documentation.
+type CompletionValues interface {
+	Values() []string
+	Task() []string
+
+	toImpl() *completionValuesImpl
+}
+
+// CompletionStaticValues returns the CompletionValues that completes the provided values.
+func CompletionStaticValues(values ...string) CompletionValues {
+	return &completionValuesImpl{
+		ValuesVar: values,
+	}
+}
+
+// CompletionTaskValues returns the CompletionValues that completes the lines printed by running the provided gödel task
+// with the provided arguments. For example, CompletionTaskValues("packages") completes the packages of the project.
+func CompletionTaskValues(task string, args ...string) CompletionValues {
+	return &completionValuesImpl{
+		TaskVar: append([]string{task}, args...),
+	}
+}
+
+func (v *completionValuesImpl) Values() []string {
+	return v.ValuesVar
+}
+
+func (v *completionValuesImpl) Task() []string {
+	return v.TaskVar
+}
+
+func (v *completionValuesImpl) toImpl() *completionValuesImpl {
+	return v
+}
+
+type CompletionParam interface {
+	apply(*completionImpl)
+}
+
+type completionParamFunc func(*completionImpl)
+
+func (f completionParamFunc) apply(impl *completionImpl) {
+	f(impl)
+}
+
+// CompletionFlag specifies a flag of the task or subcommand. shorthand may be empty. values specifies the values that
+// are completed for a string flag and may be nil, in which case file names are completed.
+func CompletionFlag(name, shorthand, description string, typ godellauncher.FlagType, values CompletionValues) CompletionParam {
+	return completionParamFunc(func(impl *completionImpl) {
+		flag := completionFlagImpl{
+			NameVar:        name,
+			ShorthandVar:   shorthand,
+			DescriptionVar: description,
+			TypeVar:        typ,
+		}
+		if values != nil {
+			flag.ValuesVar = values.toImpl()
+		}
+		impl.FlagsVar = append(impl.FlagsVar, flag)
+	})
+}
+
+// CompletionArgs specifies the values that are completed for the arguments of the task or subcommand.
+func CompletionArgs(values CompletionValues) CompletionParam {
+	return completionParamFunc(func(impl *completionImpl) {
+		impl.ArgsVar = values.toImpl()
+	})
+}
+
+// CompletionSubcommand specifies a subcommand of the task or subcommand. The params specify the flags, arguments and
+// subcommands of the subcommand.
+func CompletionSubcommand(name, description string, params ...CompletionParam) CompletionParam {
+	return completionParamFunc(func(impl *completionImpl) {
+		impl.SubcommandsVar = append(impl.SubcommandsVar, completionCommandImpl{
+			NameVar:        name,
+			DescriptionVar: description,
+			completionImpl: newCompletionImpl(params...),
+		})
+	})
+}
+
+func newCompletionImpl(params ...CompletionParam) completionImpl {
+	var impl completionImpl
+	for _, p := range params {
+		if p == nil {
+			continue
+		}
+		p.apply(&impl)
+	}
+	return impl
+}
+
+// TaskInfoCompletion specifies the flags, arguments and subcommands of the task. They are used by the "completion" task
+// of gödel to complete the input for the task in a shell. If this parameter is not specified, only file names are
+// completed for the task.
+func TaskInfoCompletion(params ...CompletionParam) TaskInfoParam {
+	return taskInfoParamFunc(func(impl *taskInfoImpl) {
+		completion := newCompletionImpl(params...)
+		impl.CompletionVar = &completion
+	})
+}
+
+// TaskInfoCompletionFromCobraCommand specifies the flags, arguments and subcommands of the task using the provided
+// command, which should be the command that is run for the task. Refer to godellauncher.CompletionFromCobraCommand for
+// the manner in which they are determined: in particular, the godellauncher.CompletionTaskAnnotation annotation can be
+// used to specify a task that prints the values of a flag or of the arguments of a command.
+func TaskInfoCompletionFromCobraCommand(cmd *cobra.Command) TaskInfoParam {
+	return taskInfoParamFunc(func(impl *taskInfoImpl) {
+		completion := godellauncher.CompletionFromCobraCommand(cmd)
+		if completion == nil {
+			return
+		}
+		completionImpl := toCompletionImpl(*completion)
+		impl.CompletionVar = &completionImpl
+	})
+}
+
+func toCompletionImpl(completion godellauncher.Completion) completionImpl {
+	impl := completionImpl{
+		ArgsVar: toCompletionValuesImpl(completion.Args),
+	}
+	for _, f := range completion.Flags {
+		impl.FlagsVar = append(impl.FlagsVar, completionFlagImpl{
+			NameVar:        f.Name,
+			ShorthandVar:   f.Shorthand,
+			DescriptionVar: f.Description,
+			TypeVar:        f.Type,
+			ValuesVar:      toCompletionValuesImpl(f.Values),
+		})
+	}
+	for _, subCmd := range completion.Subcommands {
+		impl.SubcommandsVar = append(impl.SubcommandsVar, completionCommandImpl{
+			NameVar:        subCmd.Name,
+			DescriptionVar: subCmd.Description,
+			completionImpl: toCompletionImpl(subCmd.Completion),
+		})
+	}
+	return impl
+}
+
+func toCompletionValuesImpl(values *godellauncher.CompletionValues) *completionValuesImpl {
+	if values == nil {
+		return nil
+	}
+	return &completionValuesImpl{
+		ValuesVar: values.Values,
+		TaskVar:   values.Task,
+	}
+}
+
+func (impl completionImpl) toGodelCompletion() godellauncher.Completion {
+	completion := godellauncher.Completion{
+		Args: impl.ArgsVar.toGodelCompletionValues(),
+	}
+	for _, f := range impl.FlagsVar {
+		completion.Flags = append(completion.Flags, godellauncher.CompletionFlag{
+			Name:        f.NameVar,
+			Shorthand:   f.ShorthandVar,
+			Description: f.DescriptionVar,
+			Type:        f.TypeVar,
+			Values:      f.ValuesVar.toGodelCompletionValues(),
+		})
+	}
+	for _, subCmd := range impl.SubcommandsVar {
+		completion.Subcommands = append(completion.Subcommands, godellauncher.CompletionCommand{
+			Name:        subCmd.NameVar,
+			Description: subCmd.DescriptionVar,
+			Completion:  subCmd.toGodelCompletion(),
+		})
+	}
+	return completion
+}
+
+func (v *completionValuesImpl) toGodelCompletionValues() *godellauncher.CompletionValues {
+	if v == nil {
+		return nil
+	}
+	return &godellauncher.CompletionValues{
+		Values: v.ValuesVar,
+		Task:   v.TaskVar,
+	}
+}
+
+// withoutGlobalFlags returns a copy of the completion without the flags that gödel provides to the plugin (the flags
+// specified by the global flag options and the assets flag) for the task and all of its subcommands.
+func (impl *completionImpl) withoutGlobalFlags(globalFlagOpts *globalFlagOptionsImpl) *completionImpl {
+	if impl == nil {
+		return nil
+	}
+	globalFlags := map[string]struct{}{
+		AssetsFlagName: {},
+	}
+	if globalFlagOpts != nil {
+		for _, f := range []string{
+			globalFlagOpts.DebugFlagVar,
+			globalFlagOpts.ProjectDirFlagVar,
+			globalFlagOpts.GodelConfigFlagVar,
+			globalFlagOpts.ConfigFlagVar,
+		} {
+			if f != "" {
+				globalFlags[strings.TrimLeft(f, "-")] = struct{}{}
+			}
+		}
+	}
+	filtered := impl.withoutFlags(globalFlags)
+	return &filtered
+}
+
+func (impl completionImpl) withoutFlags(flags map[string]struct{}) completionImpl {
+	filtered := completionImpl{
+		ArgsVar: impl.ArgsVar,
+	}
+	for _, f := range impl.FlagsVar {
+		if _, ok := flags[f.NameVar]; ok {
+			continue
+		}
+		filtered.FlagsVar = append(filtered.FlagsVar, f)
+	}
+	for _, subCmd := range impl.SubcommandsVar {
+		subCmd.completionImpl = subCmd.withoutFlags(flags)
+		filtered.SubcommandsVar = append(filtered.SubcommandsVar, subCmd)
+	}
+	return filtered
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+// GodelVersionRange is a JSON-serializable interface that specifies the range of gödel versions that a plugin is
+// compatible with.
+type GodelVersionRange interface {
+	// MinVersion returns the minimum gödel version (inclusive) that the plugin supports. Blank if there is no minimum.
+	MinVersion() string
+	// MaxVersion returns the maximum gödel version (exclusive) that the plugin supports. Blank if there is no maximum.
+	MaxVersion() string
+}
+
+// godelVersionRangeImpl is a concrete implementation of GodelVersionRange. Note that the functions are defined on
+// non-pointer receivers to reduce bugs in calling functions in closures.
+type godelVersionRangeImpl struct {
+	MinVersionVar string `json:"minVersion,omitempty"`
+	MaxVersionVar string `json:"maxVersion,omitempty"`
+}
+
+func (r godelVersionRangeImpl) MinVersion() string {
+	return r.MinVersionVar
+}
+
+func (r godelVersionRangeImpl) MaxVersion() string {
+	return r.MaxVersionVar
+}
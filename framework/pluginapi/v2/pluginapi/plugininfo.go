@@ -15,19 +15,27 @@
 package pluginapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/internal/versionsinternal"
 	v1 "github.com/palantir/godel/v2/framework/pluginapi"
+	"github.com/palantir/godel/v2/framework/trace"
 	"github.com/pkg/errors"
 )
 
 const (
-	CurrentSchemaVersion  = "2"
-	PluginInfoCommandName = v1.PluginInfoCommandName
+	CurrentSchemaVersion = "2"
+	// LongLivedProcessSchemaVersion is the schema version of plugins that are run as a single long-lived process for
+	// the duration of a gödel invocation rather than as a new process for every task. Such plugins communicate with
+	// gödel using the protocol served by ServePluginProcess.
+	LongLivedProcessSchemaVersion = "3"
+	PluginInfoCommandName         = v1.PluginInfoCommandName
 )
 
 // PluginInfo specifies the information for a plugin and the tasks that it provides.
@@ -57,6 +65,14 @@ type PluginInfo interface {
 	// does not support upgrading configuration.
 	UpgradeConfigTask(pluginExecPath string, assets []string) *godellauncher.UpgradeConfigTask
 
+	// GodelVersionRange returns the range of gödel versions that the plugin is compatible with. Returns nil if the
+	// plugin does not declare a range (in which case it is assumed to be compatible with all versions).
+	GodelVersionRange() GodelVersionRange
+
+	// ConfigSchema returns the JSON Schema for the configuration file of the plugin. Returns nil if the plugin does not
+	// provide a schema.
+	ConfigSchema() []byte
+
 	// MarshalPluginInfoJSON returns a JSON representation of the plugin info. Note that this function is intentionally
 	// *not* MarshalJSON: this ensures that individual implementors of PluginInfo can have their own MarshalJSON that
 	// only marshals the specific type.
@@ -79,12 +95,14 @@ func UnmarshalPluginInfoJSON(data []byte) (PluginInfo, error) {
 		return nil, errors.Wrapf(err, "failed to unmarshal plugin info")
 	}
 	switch {
+	case marshalType.V3Info != nil:
+		return marshalType.V3Info, nil
 	case marshalType.V2Info != nil:
 		return marshalType.V2Info, nil
 	case marshalType.WrappedV1Info != nil:
 		return marshalType.WrappedV1Info, nil
 	}
-	return nil, errors.Errorf("JSON data did not have V2Info or WrappedV1Info field")
+	return nil, errors.Errorf("JSON data did not have V3Info, V2Info or WrappedV1Info field")
 }
 
 func marshalJSONHelper(in pluginInfoMarshalType) ([]byte, error) {
@@ -99,6 +117,7 @@ const currentPluginInfoMarshalTypeSchemaVersion = 1
 type pluginInfoMarshalType struct {
 	SchemaVersion int                      `json:"schemaVersion"`
 	V2Info        *pluginInfoImpl          `json:"v2Info,omitempty"`
+	V3Info        *pluginInfoV3Impl        `json:"v3Info,omitempty"`
 	WrappedV1Info *wrappedV1PluginInfoImpl `json:"wrappedV1Info,omitempty"`
 }
 
@@ -140,6 +159,8 @@ func NewPluginInfo(group, product, version string, params ...PluginInfoParam) (P
 	// set global flag options based on builder
 	for i := range builder.tasks {
 		builder.tasks[i].GlobalFlagOptionsVar = builder.globalFlagOpts
+		// the flags that gödel provides to the plugin are not completed
+		builder.tasks[i].CompletionVar = builder.tasks[i].CompletionVar.withoutGlobalFlags(builder.globalFlagOpts)
 	}
 	if builder.upgradeConfigTask != nil {
 		builder.upgradeConfigTask.GroupID = group
@@ -158,8 +179,11 @@ func NewPluginInfo(group, product, version string, params ...PluginInfoParam) (P
 	if builder.upgradeConfigTask != nil && !builder.usesConfigFile {
 		return nil, errors.Errorf(`plugin %s provides a configuration upgrade task but does not specify that it uses configuration`, id)
 	}
+	if builder.configSchema != nil && !builder.usesConfigFile {
+		return nil, errors.Errorf(`plugin %s provides a configuration schema but does not specify that it uses configuration`, id)
+	}
 
-	return &pluginInfoImpl{
+	info := pluginInfoImpl{
 		PluginSchemaVersionVar: CurrentSchemaVersion,
 		GroupVar:               group,
 		ProductVar:             product,
@@ -167,7 +191,16 @@ func NewPluginInfo(group, product, version string, params ...PluginInfoParam) (P
 		UsesConfigVar:          builder.usesConfigFile,
 		TasksVar:               builder.tasks,
 		UpgradeConfigTaskVar:   builder.upgradeConfigTask,
-	}, nil
+		GodelVersionRangeVar:   builder.godelVersionRange,
+		ConfigSchemaVar:        builder.configSchema,
+	}
+	if builder.longLivedProcess {
+		info.PluginSchemaVersionVar = LongLivedProcessSchemaVersion
+		return &pluginInfoV3Impl{
+			pluginInfoImpl: info,
+		}, nil
+	}
+	return &info, nil
 }
 
 func validateComponent(name, val string) error {
@@ -185,6 +218,9 @@ type pluginInfoBuilder struct {
 	tasks             []taskInfoImpl
 	globalFlagOpts    *globalFlagOptionsImpl
 	upgradeConfigTask *upgradeConfigTaskInfoImpl
+	godelVersionRange *godelVersionRangeImpl
+	longLivedProcess  bool
+	configSchema      json.RawMessage
 }
 
 type PluginInfoParam interface {
@@ -235,6 +271,67 @@ func PluginInfoUpgradeConfigTaskInfo(params ...UpgradeConfigTaskInfoParam) Plugi
 	})
 }
 
+// PluginInfoGodelVersionRange specifies the range of gödel versions that the plugin is compatible with. minVersion is
+// inclusive and maxVersion is exclusive. Either value may be blank to indicate that the range is unbounded in that
+// direction, but at least one of them must be non-blank. Non-blank values must be orderable gödel versions such as
+// "2.100.0" and minVersion must be lower than maxVersion. The launcher refuses to load the plugin if its version is not
+// within the specified range.
+func PluginInfoGodelVersionRange(minVersion, maxVersion string) PluginInfoParam {
+	return pluginInfoParamFunc(func(impl *pluginInfoBuilder) error {
+		if minVersion == "" && maxVersion == "" {
+			return errors.Errorf("at least one of minimum or maximum version must be provided for gödel version range")
+		}
+		for _, bound := range []struct {
+			name, version string
+		}{
+			{"minimum", minVersion},
+			{"maximum", maxVersion},
+		} {
+			if bound.version == "" {
+				continue
+			}
+			if v, err := versionsinternal.NewVersion(bound.version); err != nil || !v.Orderable() {
+				return errors.Errorf(`invalid %s version %q for gödel version range: must be an orderable version such as "2.100.0"`, bound.name, bound.version)
+			}
+		}
+		if minVersion != "" && maxVersion != "" {
+			if cmp, _, _ := versionsinternal.CompareVersions(minVersion, maxVersion); cmp >= 0 {
+				return errors.Errorf("invalid gödel version range: minimum version %s must be lower than maximum version %s", minVersion, maxVersion)
+			}
+		}
+		impl.godelVersionRange = &godelVersionRangeImpl{
+			MinVersionVar: minVersion,
+			MaxVersionVar: maxVersion,
+		}
+		return nil
+	})
+}
+
+// PluginInfoConfigSchema specifies the JSON Schema for the configuration file of the plugin. The schema must be a
+// JSON object. The "config validate" task validates the configuration file of the plugin against this schema. Plugins
+// that specify this parameter must also specify PluginInfoUsesConfigFile.
+func PluginInfoConfigSchema(schema []byte) PluginInfoParam {
+	return pluginInfoParamFunc(func(impl *pluginInfoBuilder) error {
+		var schemaObj map[string]interface{}
+		if err := json.Unmarshal(schema, &schemaObj); err != nil {
+			return errors.Wrapf(err, "configuration schema must be a JSON object")
+		}
+		impl.configSchema = json.RawMessage(schema)
+		return nil
+	})
+}
+
+// PluginInfoLongLivedProcess specifies that the plugin should be run as a single long-lived process for the duration
+// of a gödel invocation. The plugin must handle the PluginServeCommandName command by calling ServePluginProcess
+// (CobraServeCmd and ServeCmd do this). Plugins that specify this parameter use schema version
+// LongLivedProcessSchemaVersion and require a version of gödel that supports it.
+func PluginInfoLongLivedProcess() PluginInfoParam {
+	return pluginInfoParamFunc(func(impl *pluginInfoBuilder) error {
+		impl.longLivedProcess = true
+		return nil
+	})
+}
+
 // pluginInfoImpl is a concrete implementation of Info. Note that the functions are defined on non-pointer receivers to reduce
 // bugs in calling functions in closures.
 type pluginInfoImpl struct {
@@ -251,6 +348,10 @@ type pluginInfoImpl struct {
 	TasksVar []taskInfoImpl `json:"tasks"`
 	// The configuration upgrade task provided by the plugin.
 	UpgradeConfigTaskVar *upgradeConfigTaskInfoImpl `json:"upgradeTask"`
+	// The range of gödel versions that the plugin is compatible with. Omitted if the plugin does not declare a range.
+	GodelVersionRangeVar *godelVersionRangeImpl `json:"godelVersionRange,omitempty"`
+	// The JSON Schema for the configuration file of the plugin. Omitted if the plugin does not provide a schema.
+	ConfigSchemaVar json.RawMessage `json:"configSchema,omitempty"`
 }
 
 func (infoImpl *pluginInfoImpl) PluginSchemaVersion() string {
@@ -284,7 +385,9 @@ func (infoImpl *pluginInfoImpl) configFileName() string {
 func (infoImpl *pluginInfoImpl) Tasks(pluginExecPath string, assets []string) []godellauncher.Task {
 	var tasks []godellauncher.Task
 	for _, ti := range infoImpl.TasksVar {
-		tasks = append(tasks, ti.toTask(pluginExecPath, infoImpl.configFileName(), assets))
+		task := ti.toTask(pluginExecPath, infoImpl.configFileName(), assets)
+		task.ConfigSchema = infoImpl.ConfigSchema()
+		tasks = append(tasks, task)
 	}
 	return tasks
 }
@@ -297,6 +400,20 @@ func (infoImpl *pluginInfoImpl) UpgradeConfigTask(pluginExecPath string, assets
 	return &taskVar
 }
 
+func (infoImpl *pluginInfoImpl) GodelVersionRange() GodelVersionRange {
+	if infoImpl.GodelVersionRangeVar == nil {
+		return nil
+	}
+	return infoImpl.GodelVersionRangeVar
+}
+
+func (infoImpl *pluginInfoImpl) ConfigSchema() []byte {
+	if len(infoImpl.ConfigSchemaVar) == 0 {
+		return nil
+	}
+	return infoImpl.ConfigSchemaVar
+}
+
 func (infoImpl *pluginInfoImpl) MarshalPluginInfoJSON() ([]byte, error) {
 	return marshalJSONHelper(pluginInfoMarshalType{
 		V2Info: infoImpl,
@@ -305,11 +422,89 @@ func (infoImpl *pluginInfoImpl) MarshalPluginInfoJSON() ([]byte, error) {
 
 func (infoImpl *pluginInfoImpl) private() {}
 
+func (infoImpl *pluginInfoImpl) id() string {
+	return fmt.Sprintf("%s:%s:%s", infoImpl.GroupVar, infoImpl.ProductVar, infoImpl.VersionVar)
+}
+
+// pluginInfoV3Impl is the implementation of PluginInfo for plugins with schema version LongLivedProcessSchemaVersion.
+// It has the same content as pluginInfoImpl, but its tasks are run by sending requests to a long-lived plugin process
+// rather than by executing the plugin for every task.
+type pluginInfoV3Impl struct {
+	pluginInfoImpl
+}
+
+func (infoImpl *pluginInfoV3Impl) Tasks(pluginExecPath string, assets []string) []godellauncher.Task {
+	var tasks []godellauncher.Task
+	for _, ti := range infoImpl.TasksVar {
+		task := ti.toTaskWithRunner(infoImpl.configFileName(), func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+			cmdArgs, err := ti.taskArgs(t, global, assets)
+			if err != nil {
+				return err
+			}
+			span := trace.StartSpan("run plugin process task "+ti.NameVar, "plugins")
+			span.SetArg("args", cmdArgs)
+			exitCode, err := runTaskOnPluginProcess(ctx, pluginExecPath, infoImpl.id(), cmdArgs, stdout)
+			span.End()
+			if ctx.Err() != nil {
+				// the plugin process was stopped because the context is done
+				return context.Cause(ctx)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "plugin execution failed")
+			}
+			if exitCode > 0 {
+				// create error with empty message because command will likely print its own error
+				return &godellauncher.ExitCodeError{
+					Code: exitCode,
+				}
+			}
+			if exitCode != 0 {
+				return fmt.Errorf("")
+			}
+			return nil
+		})
+		task.ConfigSchema = infoImpl.ConfigSchema()
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func (infoImpl *pluginInfoV3Impl) UpgradeConfigTask(pluginExecPath string, assets []string) *godellauncher.UpgradeConfigTask {
+	if infoImpl.UpgradeConfigTaskVar == nil {
+		return nil
+	}
+	ti := *infoImpl.UpgradeConfigTaskVar
+	taskVar := ti.toTaskWithRunner(infoImpl.configFileName(), func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+		cmdArgs, err := ti.upgradeConfigArgs(t, global, assets)
+		if err != nil {
+			return nil, err
+		}
+		exitCode, outputBytes, err := upgradeConfigOnPluginProcess(pluginExecPath, infoImpl.id(), cmdArgs, configBytes)
+		if err != nil {
+			return nil, err
+		}
+		if exitCode != 0 {
+			return nil, errors.Errorf("%s", upgradeConfigErrorOutput(outputBytes, fmt.Sprintf("upgrade command %v failed with exit code %d", ti.CommandVar, exitCode)))
+		}
+		return decodeUpgradeConfigOutput(outputBytes)
+	})
+	return &taskVar
+}
+
+func (infoImpl *pluginInfoV3Impl) MarshalPluginInfoJSON() ([]byte, error) {
+	return marshalJSONHelper(pluginInfoMarshalType{
+		V3Info: infoImpl,
+	})
+}
+
 // InfoFromPlugin returns the Info for the plugin at the specified path. Does so by invoking the InfoCommand on the
 // plugin and parsing the output.
 func InfoFromPlugin(pluginPath string) (PluginInfo, error) {
 	cmd := exec.Command(pluginPath, PluginInfoCommandName)
+	span := trace.StartSpan("exec plugin info", "plugins")
+	span.SetArg("plugin", pluginPath)
 	bytes, err := cmd.CombinedOutput()
+	span.End()
 	if err != nil {
 		return nil, errors.Wrapf(err, "command %v failed.\nError:\n%v\nOutput:\n%s\n", cmd.Args, err, string(bytes))
 	}
@@ -344,6 +539,12 @@ func InfoFromPlugin(pluginPath string) (PluginInfo, error) {
 			return nil, errors.Wrapf(err, "failed to unmarshal plugin information for plugin %s from output %q", pluginPath, string(bytes))
 		}
 		pluginInfo = &v2Info
+	case LongLivedProcessSchemaVersion:
+		var v3Info pluginInfoV3Impl
+		if err := json.Unmarshal(bytes, &v3Info); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal plugin information for plugin %s from output %q", pluginPath, string(bytes))
+		}
+		pluginInfo = &v3Info
 	default:
 		return nil, errors.Errorf("unsupported plugin schema version: %s", version)
 	}
@@ -386,6 +587,14 @@ func (infoImpl *wrappedV1PluginInfoImpl) UpgradeConfigTask(pluginExecPath string
 	return nil
 }
 
+func (infoImpl *wrappedV1PluginInfoImpl) GodelVersionRange() GodelVersionRange {
+	return nil
+}
+
+func (infoImpl *wrappedV1PluginInfoImpl) ConfigSchema() []byte {
+	return nil
+}
+
 func (infoImpl *wrappedV1PluginInfoImpl) MarshalPluginInfoJSON() ([]byte, error) {
 	return marshalJSONHelper(pluginInfoMarshalType{
 		WrappedV1Info: infoImpl,
@@ -0,0 +1,284 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/trace"
+	"github.com/pkg/errors"
+)
+
+// pluginProcessShutdownTimeout is the amount of time a plugin process is given to exit after it is asked to shut down
+// before it is killed.
+const pluginProcessShutdownTimeout = 5 * time.Second
+
+var (
+	pluginProcessesMutex sync.Mutex
+	// pluginProcesses stores the running long-lived plugin processes keyed by the path to the plugin executable.
+	pluginProcesses = make(map[string]*pluginProcess)
+)
+
+// ClosePluginProcesses shuts down all of the long-lived plugin processes started by this process. Should be called
+// before gödel exits. Plugin processes also exit on their own when gödel exits because their standard input is
+// closed, so calling this function is not required for correctness.
+func ClosePluginProcesses() {
+	pluginProcessesMutex.Lock()
+	defer pluginProcessesMutex.Unlock()
+	for k, p := range pluginProcesses {
+		p.close()
+		delete(pluginProcesses, k)
+	}
+}
+
+// runningPluginProcess returns the running plugin process for the plugin at the provided path, starting it if it is not
+// running. pluginID is the "group:product:version" identifier of the plugin: a newly started process must report the
+// same identifier.
+func runningPluginProcess(pluginExecPath, pluginID string) (*pluginProcess, error) {
+	pluginProcessesMutex.Lock()
+	defer pluginProcessesMutex.Unlock()
+	if p, ok := pluginProcesses[pluginExecPath]; ok {
+		if !p.exited() {
+			return p, nil
+		}
+		p.close()
+		delete(pluginProcesses, pluginExecPath)
+	}
+	p, err := startPluginProcess(pluginExecPath, pluginID)
+	if err != nil {
+		return nil, err
+	}
+	pluginProcesses[pluginExecPath] = p
+	return p, nil
+}
+
+type pluginProcess struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// collectTrace adds the spans recorded by the process to the trace. Called once the process exits.
+	collectTrace func()
+
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	decoder *json.Decoder
+	nextID  int64
+	// err is set if communication with the process failed. Once set, the process is not used again.
+	err error
+}
+
+func startPluginProcess(pluginExecPath, pluginID string) (*pluginProcess, error) {
+	span := trace.StartSpan("start plugin process", "plugins")
+	span.SetArg("plugin", pluginID)
+	defer span.End()
+
+	cmd := exec.Command(pluginExecPath, PluginServeCommandName)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create stdin pipe for plugin %s", pluginExecPath)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create stdout pipe for plugin %s", pluginExecPath)
+	}
+	// run the plugin process in its own process group so that it can be stopped gracefully when a task is cancelled
+	godellauncher.SetProcessGroup(cmd)
+	collectTrace := trace.TraceCommand(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "failed to start plugin process %v", cmd.Args)
+	}
+	p := &pluginProcess{
+		cmd:          cmd,
+		stdin:        stdin,
+		collectTrace: collectTrace,
+		encoder:      json.NewEncoder(stdin),
+		decoder:      json.NewDecoder(stdout),
+	}
+
+	// verify that the process is the expected plugin
+	infoBytes, err := p.call(pluginProcessMethodInfo, nil, nil)
+	if err != nil {
+		p.close()
+		return nil, errors.Wrapf(err, "failed to get plugin information from plugin process %v", cmd.Args)
+	}
+	var info pluginInfoImpl
+	if err := json.Unmarshal(infoBytes, &info); err != nil {
+		p.close()
+		return nil, errors.Wrapf(err, "failed to unmarshal plugin information from plugin process %v", cmd.Args)
+	}
+	if gotID := fmt.Sprintf("%s:%s:%s", info.GroupVar, info.ProductVar, info.VersionVar); gotID != pluginID {
+		p.close()
+		return nil, errors.Errorf("plugin process %v reported plugin %s, expected %s", cmd.Args, gotID, pluginID)
+	}
+	return p, nil
+}
+
+func (p *pluginProcess) exited() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.err != nil
+}
+
+// call sends a request with the provided method and parameters to the plugin process and returns the result. Output
+// notifications for the request are provided to onOutput if it is non-nil.
+func (p *pluginProcess) call(method string, params interface{}, onOutput func(pluginProcessOutputParams) error) (json.RawMessage, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	var paramBytes json.RawMessage
+	if params != nil {
+		var err error
+		if paramBytes, err = json.Marshal(params); err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal parameters for %s", method)
+		}
+	}
+	p.nextID++
+	id := p.nextID
+	if err := p.encoder.Encode(pluginProcessMessage{
+		JSONRPC: pluginProcessJSONRPCVersion,
+		ID:      &id,
+		Method:  method,
+		Params:  paramBytes,
+	}); err != nil {
+		p.err = errors.Wrapf(err, "failed to send request to plugin process %v", p.cmd.Args)
+		return nil, p.err
+	}
+
+	for {
+		var msg pluginProcessMessage
+		if err := p.decoder.Decode(&msg); err != nil {
+			p.err = errors.Wrapf(err, "plugin process %v exited unexpectedly", p.cmd.Args)
+			return nil, p.err
+		}
+		if msg.ID == nil {
+			if msg.Method != pluginProcessMethodOutput || onOutput == nil {
+				continue
+			}
+			var output pluginProcessOutputParams
+			if err := json.Unmarshal(msg.Params, &output); err != nil {
+				p.err = errors.Wrapf(err, "plugin process %v sent invalid output", p.cmd.Args)
+				return nil, p.err
+			}
+			if output.RequestID != id {
+				continue
+			}
+			if err := onOutput(output); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if *msg.ID != id {
+			continue
+		}
+		if msg.Error != nil {
+			return nil, errors.Errorf("plugin process %v failed to handle %s: %s", p.cmd.Args, method, msg.Error.Message)
+		}
+		return msg.Result, nil
+	}
+}
+
+// close asks the plugin process to shut down and waits for it to exit. The process is killed if it does not exit
+// within pluginProcessShutdownTimeout.
+func (p *pluginProcess) close() {
+	if !p.exited() {
+		_, _ = p.call(pluginProcessMethodShutdown, nil, nil)
+	}
+	_ = p.stdin.Close()
+
+	done := make(chan struct{})
+	go func() {
+		_ = p.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(pluginProcessShutdownTimeout):
+		_ = p.cmd.Process.Kill()
+		<-done
+	}
+	p.collectTrace()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.err == nil {
+		p.err = errors.Errorf("plugin process %v was shut down", p.cmd.Args)
+	}
+}
+
+// runTaskOnPluginProcess runs a task with the provided arguments using the long-lived process for the plugin and
+// returns its exit code. Standard output and standard error of the task are both written to stdout. If the provided
+// context is done before the task completes, the plugin process is stopped using godellauncher.StopProcessGroup.
+func runTaskOnPluginProcess(ctx context.Context, pluginExecPath, pluginID string, args []string, stdout io.Writer) (int, error) {
+	p, err := runningPluginProcess(pluginExecPath, pluginID)
+	if err != nil {
+		return 0, err
+	}
+	// the call returns once the process exits because its output is closed, so callDone is used to determine whether
+	// the stopped process exited within the grace period
+	callDone := make(chan struct{})
+	defer close(callDone)
+	stopOnDone := context.AfterFunc(ctx, func() {
+		godellauncher.StopProcessGroup(ctx, p.cmd.Process, callDone)
+	})
+	defer stopOnDone()
+	resultBytes, err := p.call(pluginProcessMethodRunTask, pluginProcessRunTaskParams{
+		Args: args,
+	}, func(output pluginProcessOutputParams) error {
+		if _, err := stdout.Write(output.Data); err != nil {
+			return errors.Wrapf(err, "failed to write output")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	var result pluginProcessRunTaskResult
+	if err := json.Unmarshal(resultBytes, &result); err != nil {
+		return 0, errors.Wrapf(err, "failed to unmarshal task result")
+	}
+	return result.ExitCode, nil
+}
+
+// upgradeConfigOnPluginProcess runs the configuration upgrade command with the provided arguments and configuration
+// using the long-lived process for the plugin and returns the exit code and combined output of the command.
+func upgradeConfigOnPluginProcess(pluginExecPath, pluginID string, args []string, configBytes []byte) (int, []byte, error) {
+	p, err := runningPluginProcess(pluginExecPath, pluginID)
+	if err != nil {
+		return 0, nil, err
+	}
+	resultBytes, err := p.call(pluginProcessMethodUpgradeConfig, pluginProcessUpgradeConfigParams{
+		Args:   args,
+		Config: configBytes,
+	}, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	var result pluginProcessUpgradeConfigResult
+	if err := json.Unmarshal(resultBytes, &result); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to unmarshal upgrade result")
+	}
+	return result.ExitCode, result.Output, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+import (
+	"encoding/json"
+)
+
+// The protocol spoken between gödel and a long-lived plugin process is JSON-RPC 2.0 where every message is a single
+// JSON object written on its own line to the standard input (requests from gödel) or standard output (responses and
+// notifications from the plugin) of the plugin process. gödel sends one request at a time and waits for its response
+// before sending the next one. The supported methods are:
+//
+//   - "info": returns the JSON representation of the plugin info (the same content printed by PluginInfoCommandName).
+//   - "runTask": runs the plugin with the provided arguments. While the task runs, the plugin sends "output"
+//     notifications with the content written to standard output and standard error. The result is the exit code.
+//   - "upgradeConfig": runs the plugin's configuration upgrade command with the provided arguments and configuration.
+//     The result is the exit code and the combined output of the command.
+//   - "shutdown": acknowledges the request and exits the process.
+//
+// The plugin process also exits when its standard input is closed.
+const (
+	pluginProcessJSONRPCVersion = "2.0"
+
+	pluginProcessMethodInfo          = "info"
+	pluginProcessMethodRunTask       = "runTask"
+	pluginProcessMethodUpgradeConfig = "upgradeConfig"
+	pluginProcessMethodShutdown      = "shutdown"
+	pluginProcessMethodOutput        = "output"
+
+	pluginProcessStreamStdout = "stdout"
+	pluginProcessStreamStderr = "stderr"
+
+	// standard JSON-RPC 2.0 error codes
+	pluginProcessErrCodeInvalidParams  = -32602
+	pluginProcessErrCodeMethodNotFound = -32601
+	pluginProcessErrCodeInternal       = -32603
+)
+
+// pluginProcessMessage is a JSON-RPC 2.0 message. Requests have an ID and a method, notifications have a method but
+// no ID and responses have an ID and either a result or an error.
+type pluginProcessMessage struct {
+	JSONRPC string               `json:"jsonrpc"`
+	ID      *int64               `json:"id,omitempty"`
+	Method  string               `json:"method,omitempty"`
+	Params  json.RawMessage      `json:"params,omitempty"`
+	Result  json.RawMessage      `json:"result,omitempty"`
+	Error   *pluginProcessRPCErr `json:"error,omitempty"`
+}
+
+type pluginProcessRPCErr struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type pluginProcessRunTaskParams struct {
+	Args []string `json:"args"`
+}
+
+type pluginProcessRunTaskResult struct {
+	ExitCode int `json:"exitCode"`
+}
+
+type pluginProcessUpgradeConfigParams struct {
+	Args   []string `json:"args"`
+	Config []byte   `json:"config"`
+}
+
+type pluginProcessUpgradeConfigResult struct {
+	ExitCode int    `json:"exitCode"`
+	Output   []byte `json:"output"`
+}
+
+// pluginProcessOutputParams are the parameters of the "output" notification. RequestID is the ID of the "runTask"
+// request that produced the output.
+type pluginProcessOutputParams struct {
+	RequestID int64  `json:"requestId"`
+	Stream    string `json:"stream"`
+	Data      []byte `json:"data"`
+}
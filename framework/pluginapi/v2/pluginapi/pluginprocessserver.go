@@ -0,0 +1,246 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PluginServeCommandName is the command that gödel uses to start a plugin with schema version
+// LongLivedProcessSchemaVersion as a long-lived process.
+const PluginServeCommandName = "_godelPluginServe"
+
+// PluginRunFn runs the plugin with the provided arguments (which do not include the path to the executable) and
+// returns the exit code. Output should be written to the provided writers. The function is called once for every task
+// run by gödel within the same process, so it must not rely on state left over from previous calls. It must also
+// return its exit code rather than exit the process: calling os.Exit (including indirectly through functions such as
+// log.Fatal) terminates the long-lived process that serves every later request, so the task fails as if the plugin
+// process exited unexpectedly (its exit code is lost) and gödel has to start a new process for the next task.
+type PluginRunFn func(args []string, stdout, stderr io.Writer) int
+
+// ServePluginProcess serves the long-lived plugin process protocol: requests are read from in and responses are
+// written to out. Returns when a "shutdown" request is received or when in is closed.
+//
+// While a task runs, os.Stdout and os.Stderr are redirected so that output written directly to them is forwarded to
+// gödel rather than corrupting the protocol stream, and os.Stdin reads from the null device: tasks run using a
+// long-lived process cannot read interactive input.
+func ServePluginProcess(info PluginInfo, runFn PluginRunFn, in io.Reader, out io.Writer) error {
+	s := &pluginProcessServer{
+		info:    info,
+		runFn:   runFn,
+		encoder: json.NewEncoder(out),
+	}
+	decoder := json.NewDecoder(in)
+	for {
+		var req pluginProcessMessage
+		if err := decoder.Decode(&req); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to read request")
+		}
+		if req.ID == nil {
+			// notifications are not expected from gödel: ignore
+			continue
+		}
+		done, err := s.handle(*req.ID, req.Method, req.Params)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+type pluginProcessServer struct {
+	info  PluginInfo
+	runFn PluginRunFn
+
+	encoderMutex sync.Mutex
+	encoder      *json.Encoder
+}
+
+// handle handles the request with the provided ID and returns true if the server should exit. Returns an error only
+// if writing the response fails.
+func (s *pluginProcessServer) handle(id int64, method string, params json.RawMessage) (bool, error) {
+	switch method {
+	case pluginProcessMethodInfo:
+		infoBytes, err := json.Marshal(s.info)
+		if err != nil {
+			return false, s.writeError(id, pluginProcessErrCodeInternal, fmt.Sprintf("failed to marshal plugin info: %v", err))
+		}
+		return false, s.writeResult(id, json.RawMessage(infoBytes))
+	case pluginProcessMethodRunTask:
+		var runParams pluginProcessRunTaskParams
+		if err := json.Unmarshal(params, &runParams); err != nil {
+			return false, s.writeError(id, pluginProcessErrCodeInvalidParams, err.Error())
+		}
+		exitCode := s.run(runParams.Args, &pluginProcessOutputWriter{server: s, requestID: id, stream: pluginProcessStreamStdout}, &pluginProcessOutputWriter{server: s, requestID: id, stream: pluginProcessStreamStderr})
+		return false, s.writeResult(id, pluginProcessRunTaskResult{
+			ExitCode: exitCode,
+		})
+	case pluginProcessMethodUpgradeConfig:
+		var upgradeParams pluginProcessUpgradeConfigParams
+		if err := json.Unmarshal(params, &upgradeParams); err != nil {
+			return false, s.writeError(id, pluginProcessErrCodeInvalidParams, err.Error())
+		}
+		// the configuration is provided as a base64-encoded argument, matching the contract of CobraUpgradeConfigCmd
+		args := append(upgradeParams.Args, base64.StdEncoding.EncodeToString(upgradeParams.Config))
+		combined := &lockedBuffer{}
+		exitCode := s.run(args, combined, combined)
+		return false, s.writeResult(id, pluginProcessUpgradeConfigResult{
+			ExitCode: exitCode,
+			Output:   combined.Bytes(),
+		})
+	case pluginProcessMethodShutdown:
+		return true, s.writeResult(id, nil)
+	default:
+		return false, s.writeError(id, pluginProcessErrCodeMethodNotFound, fmt.Sprintf("method not found: %q", method))
+	}
+}
+
+// run runs the plugin function with os.Stdout, os.Stderr and os.Stdin redirected for the duration of the call. Returns
+// the exit code of the function (1 if the function panics).
+func (s *pluginProcessServer) run(args []string, stdout, stderr io.Writer) (exitCode int) {
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to create pipe: %v\n", err)
+		return 1
+	}
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		_ = stdoutR.Close()
+		_ = stdoutW.Close()
+		_, _ = fmt.Fprintf(stderr, "failed to create pipe: %v\n", err)
+		return 1
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(stdout, stdoutR)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(stderr, stderrR)
+	}()
+
+	origStdout, origStderr, origStdin := os.Stdout, os.Stderr, os.Stdin
+	devNull, err := os.Open(os.DevNull)
+	if err == nil {
+		os.Stdin = devNull
+	}
+	os.Stdout, os.Stderr = stdoutW, stderrW
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(stderrW, "panic: %v\n", r)
+			exitCode = 1
+		}
+		os.Stdout, os.Stderr, os.Stdin = origStdout, origStderr, origStdin
+		if devNull != nil {
+			_ = devNull.Close()
+		}
+		_ = stdoutW.Close()
+		_ = stderrW.Close()
+		wg.Wait()
+		_ = stdoutR.Close()
+		_ = stderrR.Close()
+	}()
+	return s.runFn(args, stdoutW, stderrW)
+}
+
+func (s *pluginProcessServer) writeResult(id int64, result interface{}) error {
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal result")
+	}
+	return s.write(pluginProcessMessage{
+		ID:     &id,
+		Result: resultBytes,
+	})
+}
+
+func (s *pluginProcessServer) writeError(id int64, code int, msg string) error {
+	return s.write(pluginProcessMessage{
+		ID: &id,
+		Error: &pluginProcessRPCErr{
+			Code:    code,
+			Message: msg,
+		},
+	})
+}
+
+func (s *pluginProcessServer) write(msg pluginProcessMessage) error {
+	msg.JSONRPC = pluginProcessJSONRPCVersion
+	s.encoderMutex.Lock()
+	defer s.encoderMutex.Unlock()
+	if err := s.encoder.Encode(msg); err != nil {
+		return errors.Wrapf(err, "failed to write message")
+	}
+	return nil
+}
+
+// pluginProcessOutputWriter is an io.Writer that sends the content written to it as "output" notifications.
+type pluginProcessOutputWriter struct {
+	server    *pluginProcessServer
+	requestID int64
+	stream    string
+}
+
+func (w *pluginProcessOutputWriter) Write(p []byte) (int, error) {
+	paramBytes, err := json.Marshal(pluginProcessOutputParams{
+		RequestID: w.requestID,
+		Stream:    w.stream,
+		Data:      p,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to marshal output")
+	}
+	if err := w.server.write(pluginProcessMessage{
+		Method: pluginProcessMethodOutput,
+		Params: paramBytes,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// lockedBuffer is a bytes.Buffer that is safe for concurrent writes.
+type lockedBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Bytes()
+}
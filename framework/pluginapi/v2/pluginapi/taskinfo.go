@@ -15,6 +15,7 @@
 package pluginapi
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -24,7 +25,9 @@ import (
 	"unicode"
 
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/trace"
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
 // TaskInfo is a JSON-serializable interface that can be translated into a godellauncher.Task. Refer to that struct for
@@ -32,6 +35,8 @@ import (
 type TaskInfo interface {
 	Name() string
 	Description() string
+	LongDescription() string
+	Usage() string
 	Command() []string
 	GlobalFlagOptions() GlobalFlagOptions
 	VerifyOptions() VerifyOptions
@@ -47,6 +52,9 @@ type taskInfoImpl struct {
 	CommandVar           []string               `json:"command"`
 	GlobalFlagOptionsVar *globalFlagOptionsImpl `json:"globalFlagOptions"`
 	VerifyOptionsVar     *verifyOptionsImpl     `json:"verifyOptions"`
+	CompletionVar        *completionImpl        `json:"completion,omitempty"`
+	LongDescriptionVar   string                 `json:"longDescription,omitempty"`
+	UsageVar             string                 `json:"usage,omitempty"`
 }
 
 type TaskInfoParam interface {
@@ -85,6 +93,27 @@ func TaskInfoVerifyOptions(params ...VerifyOptionsParam) TaskInfoParam {
 	})
 }
 
+// TaskInfoHelp specifies the detailed description and the usage of the task, which are printed by the "help <task>"
+// task of gödel along with the flags and subcommands specified using TaskInfoCompletion. usage is the text that follows
+// the task name in the usage line (for example, "[flags] [products...]"). Because this information is part of the
+// plugin information, gödel prints the help for the task without running the plugin.
+func TaskInfoHelp(longDescription, usage string) TaskInfoParam {
+	return taskInfoParamFunc(func(impl *taskInfoImpl) {
+		impl.LongDescriptionVar = longDescription
+		impl.UsageVar = usage
+	})
+}
+
+// TaskInfoHelpFromCobraCommand specifies the detailed description, usage, flags and subcommands of the task using the
+// provided command, which should be the command that is run for the task. It is equivalent to specifying TaskInfoHelp
+// with the "Long" field and usage of the command and TaskInfoCompletionFromCobraCommand with the command.
+func TaskInfoHelpFromCobraCommand(cmd *cobra.Command) TaskInfoParam {
+	return taskInfoParamFunc(func(impl *taskInfoImpl) {
+		TaskInfoHelp(cmd.Long, godellauncher.CobraCommandUsage(cmd)).apply(impl)
+		TaskInfoCompletionFromCobraCommand(cmd).apply(impl)
+	})
+}
+
 func newTaskInfoImpl(name, description string, params ...TaskInfoParam) (taskInfoImpl, error) {
 	for _, r := range name {
 		if unicode.IsSpace(r) {
@@ -112,6 +141,14 @@ func (ti taskInfoImpl) Description() string {
 	return ti.DescriptionVar
 }
 
+func (ti taskInfoImpl) LongDescription() string {
+	return ti.LongDescriptionVar
+}
+
+func (ti taskInfoImpl) Usage() string {
+	return ti.UsageVar
+}
+
 func (ti taskInfoImpl) Command() []string {
 	return ti.CommandVar
 }
@@ -131,6 +168,42 @@ func (ti taskInfoImpl) GlobalFlagOptions() GlobalFlagOptions {
 }
 
 func (ti taskInfoImpl) toTask(pluginExecPath, cfgFileName string, assets []string) godellauncher.Task {
+	return ti.toTaskWithRunner(cfgFileName, func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
+		cmdArgs, err := ti.taskArgs(t, global, assets)
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command(pluginExecPath, cmdArgs...)
+		cmd.Stdout = stdout
+		cmd.Stderr = stdout
+		cmd.Stdin = os.Stdin
+		span := trace.StartSpan("exec plugin "+ti.NameVar, "plugins")
+		span.SetArg("args", cmd.Args)
+		collectTrace := trace.TraceCommand(cmd)
+		// run the plugin in its own process group so that it is stopped gracefully if the context is done
+		err = godellauncher.RunCommand(ctx, cmd)
+		span.End()
+		collectTrace()
+		if err != nil {
+			if ctx.Err() != nil {
+				// the plugin was stopped because the context is done
+				return context.Cause(ctx)
+			}
+			if _, ok := err.(*exec.ExitError); ok {
+				// create error with empty message because command will likely print its own error
+				if exitCodeErr := godellauncher.ExitCodeErrorFromExec(err, ""); exitCodeErr != nil {
+					return exitCodeErr
+				}
+				return fmt.Errorf("")
+			}
+			return errors.Wrapf(err, "plugin execution failed")
+		}
+		return nil
+	})
+}
+
+// toTaskWithRunner returns the godellauncher.Task for this task info that uses the provided function as its runner.
+func (ti taskInfoImpl) toTaskWithRunner(cfgFileName string, runContextImpl func(ctx context.Context, t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error) godellauncher.Task {
 	var verifyOpts *godellauncher.VerifyOptions
 	if ti.VerifyOptions() != nil {
 		opts := ti.VerifyOptionsVar.toGodelVerifyOptions()
@@ -140,38 +213,39 @@ func (ti taskInfoImpl) toTask(pluginExecPath, cfgFileName string, assets []strin
 	if ti.GlobalFlagOptionsVar != nil {
 		globalFlagOpts = ti.GlobalFlagOptionsVar.toGodelGlobalFlagOptions()
 	}
+	var completion *godellauncher.Completion
+	if ti.CompletionVar != nil {
+		c := ti.CompletionVar.toGodelCompletion()
+		completion = &c
+	}
 	return godellauncher.Task{
-		Name:           ti.NameVar,
-		Description:    ti.DescriptionVar,
-		ConfigFile:     cfgFileName,
-		Verify:         verifyOpts,
-		GlobalFlagOpts: globalFlagOpts,
-		RunImpl: func(t *godellauncher.Task, global godellauncher.GlobalConfig, stdout io.Writer) error {
-			cmdArgs, err := globalFlagArgs(t.GlobalFlagOpts, t.ConfigFile, global)
-			if err != nil {
-				return err
-			}
-			// if assets are specified, provide as slice argument
-			if len(assets) > 0 {
-				cmdArgs = append(cmdArgs, "--"+AssetsFlagName)
-				cmdArgs = append(cmdArgs, strings.Join(assets, ","))
-			}
-			cmdArgs = append(cmdArgs, ti.CommandVar...)
-			cmdArgs = append(cmdArgs, global.TaskArgs...)
-			cmd := exec.Command(pluginExecPath, cmdArgs...)
-			cmd.Stdout = stdout
-			cmd.Stderr = stdout
-			cmd.Stdin = os.Stdin
-			if err := cmd.Run(); err != nil {
-				if _, ok := err.(*exec.ExitError); ok {
-					// create empty error because command will likely print its own error
-					return fmt.Errorf("")
-				}
-				return errors.Wrapf(err, "plugin execution failed")
-			}
-			return nil
-		},
+		Name:            ti.NameVar,
+		Description:     ti.DescriptionVar,
+		LongDescription: ti.LongDescriptionVar,
+		Usage:           ti.UsageVar,
+		ConfigFile:      cfgFileName,
+		Verify:          verifyOpts,
+		GlobalFlagOpts:  globalFlagOpts,
+		Completion:      completion,
+		RunContextImpl:  runContextImpl,
+	}
+}
+
+// taskArgs returns the arguments that should be provided to the plugin to run the task: the global flag arguments,
+// the assets flag (if any assets are specified), the task command and the task arguments.
+func (ti taskInfoImpl) taskArgs(t *godellauncher.Task, global godellauncher.GlobalConfig, assets []string) ([]string, error) {
+	cmdArgs, err := globalFlagArgs(t.GlobalFlagOpts, t.ConfigFile, global)
+	if err != nil {
+		return nil, err
+	}
+	// if assets are specified, provide as slice argument
+	if len(assets) > 0 {
+		cmdArgs = append(cmdArgs, "--"+AssetsFlagName)
+		cmdArgs = append(cmdArgs, strings.Join(assets, ","))
 	}
+	cmdArgs = append(cmdArgs, ti.CommandVar...)
+	cmdArgs = append(cmdArgs, global.TaskArgs...)
+	return cmdArgs, nil
 }
 
 func globalFlagArgs(globalFlagOpts godellauncher.GlobalFlagOptions, configFileName string, global godellauncher.GlobalConfig) ([]string, error) {
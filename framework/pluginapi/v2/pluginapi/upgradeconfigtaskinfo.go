@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/trace"
 	"github.com/pkg/errors"
 )
 
@@ -98,6 +99,37 @@ func (ti upgradeConfigTaskInfoImpl) GlobalFlagOptions() GlobalFlagOptions {
 }
 
 func (ti upgradeConfigTaskInfoImpl) toTask(pluginExecPath, cfgFileName string, assets []string) godellauncher.UpgradeConfigTask {
+	return ti.toTaskWithRunner(cfgFileName, func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
+		cmdArgs, err := ti.upgradeConfigArgs(t, global, assets)
+		if err != nil {
+			return nil, err
+		}
+
+		// add base64-encoded config as argument
+		cmdArgs = append(cmdArgs, base64.StdEncoding.EncodeToString(configBytes))
+
+		cmd := exec.Command(pluginExecPath, cmdArgs...)
+		cmd.Stdin = os.Stdin
+		span := trace.StartSpan("exec plugin upgrade-config", "plugins")
+		span.SetArg("plugin", pluginExecPath)
+		collectTrace := trace.TraceCommand(cmd)
+		outputBytes, err := cmd.CombinedOutput()
+		span.End()
+		collectTrace()
+		if err != nil {
+			if _, ok := err.(*exec.ExitError); ok {
+				// if error was an exit error, don't bother wrapping because it's probably just "exit 1"
+				return nil, errors.Errorf("%s", upgradeConfigErrorOutput(outputBytes, fmt.Sprintf("command %v failed: %v", cmd.Args, err)))
+			}
+			return nil, errors.Wrap(err, upgradeConfigErrorOutput(outputBytes, fmt.Sprintf("command %v failed: %v", cmd.Args, err)))
+		}
+		return decodeUpgradeConfigOutput(outputBytes)
+	})
+}
+
+// toTaskWithRunner returns the godellauncher.UpgradeConfigTask for this task info that uses the provided function as
+// its runner.
+func (ti upgradeConfigTaskInfoImpl) toTaskWithRunner(cfgFileName string, runImpl func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error)) godellauncher.UpgradeConfigTask {
 	var globalFlagOpts godellauncher.GlobalFlagOptions
 	if ti.GlobalFlagOptionsVar != nil {
 		globalFlagOpts = ti.GlobalFlagOptionsVar.toGodelGlobalFlagOptions()
@@ -107,46 +139,45 @@ func (ti upgradeConfigTaskInfoImpl) toTask(pluginExecPath, cfgFileName string, a
 		ConfigFile:       cfgFileName,
 		LegacyConfigFile: ti.LegacyConfigFile,
 		GlobalFlagOpts:   globalFlagOpts,
-		RunImpl: func(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, configBytes []byte, stdout io.Writer) ([]byte, error) {
-			cmdArgs, err := globalFlagArgs(t.GlobalFlagOpts, t.ConfigFile, global)
-			if err != nil {
-				return nil, err
-			}
-			// if assets are specified, provide as slice argument
-			if len(assets) > 0 {
-				cmdArgs = append(cmdArgs, "--"+AssetsFlagName)
-				cmdArgs = append(cmdArgs, strings.Join(assets, ","))
-			}
-			cmdArgs = append(cmdArgs, ti.CommandVar...)
-
-			// add base64-encoded config as argument
-			cmdArgs = append(cmdArgs, base64.StdEncoding.EncodeToString(configBytes))
-
-			cmd := exec.Command(pluginExecPath, cmdArgs...)
-			cmd.Stdin = os.Stdin
-			outputBytes, err := cmd.CombinedOutput()
-			if err != nil {
-				output := string(outputBytes)
-				if output == "" {
-					output = fmt.Sprintf("command %v failed: %v", cmd.Args, err)
-				} else {
-					// clean up output for underlying command
-					output = strings.TrimPrefix(output, "Error: ")
-					output = strings.TrimSuffix(output, "\n")
-				}
-				if _, ok := err.(*exec.ExitError); ok {
-					// if error was an exit error, don't bother wrapping because it's probably just "exit 1"
-					return nil, errors.Errorf("%s", output)
-				}
-				return nil, errors.Wrap(err, output)
-			}
+		RunImpl:          runImpl,
+	}
+}
 
-			// valid output bytes are encoded as base64, so decode
-			decoded, err := base64.StdEncoding.DecodeString(string(outputBytes))
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to decode base64 output")
-			}
-			return decoded, nil
-		},
+// upgradeConfigArgs returns the arguments that should be provided to the plugin to run the upgrade task, excluding
+// the configuration itself.
+func (ti upgradeConfigTaskInfoImpl) upgradeConfigArgs(t *godellauncher.UpgradeConfigTask, global godellauncher.GlobalConfig, assets []string) ([]string, error) {
+	cmdArgs, err := globalFlagArgs(t.GlobalFlagOpts, t.ConfigFile, global)
+	if err != nil {
+		return nil, err
+	}
+	// if assets are specified, provide as slice argument
+	if len(assets) > 0 {
+		cmdArgs = append(cmdArgs, "--"+AssetsFlagName)
+		cmdArgs = append(cmdArgs, strings.Join(assets, ","))
+	}
+	cmdArgs = append(cmdArgs, ti.CommandVar...)
+	return cmdArgs, nil
+}
+
+// upgradeConfigErrorOutput returns the error message for a failed upgrade based on the output of the upgrade command.
+// If the output is empty, the provided default message is returned.
+func upgradeConfigErrorOutput(outputBytes []byte, defaultMsg string) string {
+	output := string(outputBytes)
+	if output == "" {
+		return defaultMsg
+	}
+	// clean up output for underlying command
+	output = strings.TrimPrefix(output, "Error: ")
+	output = strings.TrimSuffix(output, "\n")
+	return output
+}
+
+// decodeUpgradeConfigOutput decodes the output of a successful upgrade command.
+func decodeUpgradeConfigOutput(outputBytes []byte) ([]byte, error) {
+	// valid output bytes are encoded as base64, so decode
+	decoded, err := base64.StdEncoding.DecodeString(string(outputBytes))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode base64 output")
 	}
+	return decoded, nil
 }
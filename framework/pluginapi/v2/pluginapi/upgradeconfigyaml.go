@@ -0,0 +1,47 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginapi
+
+import (
+	"github.com/palantir/godel/v2/pkg/yamlnode"
+	"go.yaml.in/yaml/v3"
+)
+
+// UpgradeYAMLConfig upgrades the provided YAML configuration by calling the provided function with its document node
+// and returns the YAML representation of the modified document. Because the upgrade operates on the node
+// representation of the configuration, the comments, key order and value styles of the parts of the configuration that
+// are not modified are preserved. If the upgrade does not modify the content of the configuration, the input is
+// returned unmodified. Refer to the yamlnode package for functions that can be used to modify the document.
+func UpgradeYAMLConfig(cfgBytes []byte, upgradeFn func(doc *yaml.Node) error) ([]byte, error) {
+	return yamlnode.Update(cfgBytes, upgradeFn)
+}
+
+// PreserveYAMLFormatting returns an UpgradeConfigFn that calls the provided function and applies the comments, key order
+// and value styles of the input configuration to its output. Intended for upgraders that unmarshal configuration into
+// Go types and marshal the upgraded result, which does not preserve comments. If the input or output of the provided
+// function cannot be parsed as YAML, the output is returned unmodified.
+func PreserveYAMLFormatting(upgradeFn UpgradeConfigFn) UpgradeConfigFn {
+	return func(cfg []byte) ([]byte, error) {
+		upgradedCfg, err := upgradeFn(cfg)
+		if err != nil {
+			return nil, err
+		}
+		formattedCfg, err := yamlnode.PreserveFormatting(cfg, upgradedCfg)
+		if err != nil {
+			return upgradedCfg, nil
+		}
+		return formattedCfg, nil
+	}
+}
@@ -15,11 +15,15 @@
 package plugins
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/palantir/godel/v2/framework/artifactresolver"
 	"github.com/palantir/godel/v2/framework/builtintasks/installupdate/layout"
@@ -37,59 +41,115 @@ import (
 // params. Does the following:
 //
 //   - Resolves all of the configuration providers defined in the provided params into the gödel home configs and
-//     downloads directories.
+//     downloads directories. Configuration providers that are local files are read from the project directory.
 //   - Unmarshals all of the resolved configurations into godellauncher.TasksConfig structs.
 //
-// Returns all of the unmarshaled configurations.
-func LoadProvidedConfigurations(taskConfigProvidersParam godellauncher.TasksConfigProvidersParam, stderr io.Writer) ([]config.TasksConfig, error) {
+// Returns all of the unmarshaled configurations and a checksum of their content. The checksum changes whenever the
+// content of any of the configuration providers changes and is empty if there are no configuration providers.
+func LoadProvidedConfigurations(projectDir string, taskConfigProvidersParam godellauncher.TasksConfigProvidersParam, stderr io.Writer) ([]config.TasksConfig, string, error) {
+	configsDir, downloadsDir, err := configProviderDirs()
+	if err != nil {
+		return nil, "", err
+	}
+	return resolveConfigProviders(projectDir, configsDir, downloadsDir, taskConfigProvidersParam, false, stderr)
+}
+
+// RefreshConfigProviders resolves all of the configuration providers defined in the provided params again regardless of
+// their refresh policy and prints the configuration providers that were refreshed. Returns an error if any of the
+// configuration providers cannot be resolved.
+func RefreshConfigProviders(projectDir string, taskConfigProvidersParam godellauncher.TasksConfigProvidersParam, stdout, stderr io.Writer) error {
+	configsDir, downloadsDir, err := configProviderDirs()
+	if err != nil {
+		return err
+	}
+	if _, _, err := resolveConfigProviders(projectDir, configsDir, downloadsDir, taskConfigProvidersParam, true, stderr); err != nil {
+		return err
+	}
+	for _, currProvider := range taskConfigProvidersParam.ConfigProviders {
+		if currProvider.LocalPath != "" {
+			// local configuration providers are not cached
+			continue
+		}
+		_, _ = fmt.Fprintf(stdout, "Refreshed configuration provider %s\n", currProvider)
+	}
+	return nil
+}
+
+func configProviderDirs() (configsDir string, downloadsDir string, rErr error) {
 	godelHomeSpecDir, err := layout.GodelHomeSpecDir(specdir.Create)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create gödel home directory")
+		return "", "", errors.Wrapf(err, "failed to create gödel home directory")
 	}
-	configsDir := godelHomeSpecDir.Path(layout.ConfigsDir)
-	downloadsDir := godelHomeSpecDir.Path(layout.DownloadsDir)
-	return resolveConfigProviders(configsDir, downloadsDir, taskConfigProvidersParam, stderr)
+	return godelHomeSpecDir.Path(layout.ConfigsDir), godelHomeSpecDir.Path(layout.DownloadsDir), nil
 }
 
 // resolveConfigProviders resolves all of the configurations provided by the specified parameters. Returns a slice that
-// contains all of the resolved configurations. If errors were encountered while trying to resolve configurations,
-// returns an error that summarizes the errors.
+// contains all of the resolved configurations in the order in which they were specified. If errors were encountered
+// while trying to resolve configurations, returns an error that summarizes the errors.
 //
 // For each configuration provider defined in the parameters:
 //
-// * If a file does not exist in the expected location in the configurations directory, resolve it
+// * If the configuration provider is an artifact and a file does not exist in the expected location in the
+// configurations directory, the file must be refreshed based on the refresh policy of the provider or the file does
+// not match the checksum specified by the configuration provider, resolve it
 //   - If the configuration provider specifies a custom resolver, use it to resolve the configuration YML to the
 //     expected location in the configurations directory
 //   - Otherwise, if default resolvers are specified in the parameters, try to resolve the configuration YML to the
 //     expected location from each of them in order
 //   - If the configuration cannot be resolved, return an error
-//   - If the configuration specifies a checksum, verify that the checksum of the YML in the configurations directory
-//     matches the specified checksum
-//   - Unmarshal the downloaded YML as godellauncher.TasksConfig
+//   - If the configuration specifies a checksum, verify that the checksum of the resolved YML matches the specified
+//     checksum. Checksums are OS-independent.
+//   - If the configuration was being refreshed and cannot be resolved, print a warning and use the existing file
+//
+// * If the configuration provider is a file in a git repository, resolve the ref to a commit and, if a file does not
+// exist for that commit in the configurations directory, fetch the file from the repository into it
+// * If the configuration provider is a local file, use the file directly
+// * Unmarshal the YML as godellauncher.TasksConfig
 //   - If the unmarshal fails, return an error
 //   - If the TaskConfig contains a plugin configuration that specifies an "override" parameter, return an error
 //     (configuration providers are not allowed to set overrides)
-func resolveConfigProviders(configsDir, downloadsDir string, taskConfigProvidersParam godellauncher.TasksConfigProvidersParam, stderr io.Writer) ([]config.TasksConfig, error) {
+//
+// If forceRefresh is true, all configuration providers are resolved again regardless of their refresh policy and
+// failing to resolve a configuration provider is an error even if it was resolved previously.
+func resolveConfigProviders(projectDir, configsDir, downloadsDir string, taskConfigProvidersParam godellauncher.TasksConfigProvidersParam, forceRefresh bool, stderr io.Writer) ([]config.TasksConfig, string, error) {
 	var configs []config.TasksConfig
-	providerErrors := make(map[artifactresolver.Locator]error)
+	providerErrors := make(map[string]error)
+	contentHash := sha256.New()
 	for _, currProvider := range taskConfigProvidersParam.ConfigProviders {
-		currProviderLocator, ok := resolveAndVerifyConfigProvider(
-			currProvider,
-			providerErrors,
-			configsDir,
-			downloadsDir,
-			taskConfigProvidersParam.DefaultResolvers,
-			stderr,
-		)
-		if !ok {
+		var cfgPath string
+		var err error
+		switch {
+		case currProvider.Artifact != nil:
+			cfgPath, err = resolveAndVerifyConfigProvider(
+				*currProvider.Artifact,
+				currProvider.Checksum,
+				currProvider.Refresh,
+				forceRefresh,
+				configsDir,
+				downloadsDir,
+				taskConfigProvidersParam.DefaultResolvers,
+				stderr,
+			)
+		case currProvider.Git != nil:
+			cfgPath, err = resolveGitConfigProvider(*currProvider.Git, currProvider.Refresh, forceRefresh, configsDir, downloadsDir, stderr)
+		default:
+			cfgPath = currProvider.LocalPath
+			if !filepath.IsAbs(cfgPath) {
+				cfgPath = filepath.Join(projectDir, cfgPath)
+			}
+		}
+		if err != nil {
+			providerErrors[currProvider.String()] = err
 			continue
 		}
 
-		tasksCfg, err := readConfigFromProvider(currProviderLocator, configsDir)
+		tasksCfg, cfgBytes, err := readConfigFromProvider(cfgPath)
 		if err != nil {
-			providerErrors[currProviderLocator] = err
+			providerErrors[currProvider.String()] = err
 			continue
 		}
+		cfgChecksum := sha256.Sum256(cfgBytes)
+		_, _ = contentHash.Write(cfgChecksum[:])
 
 		var pluginsWithOverrides []string
 		for _, pluginCfg := range tasksCfg.Plugins.Plugins {
@@ -99,7 +159,7 @@ func resolveConfigProviders(configsDir, downloadsDir string, taskConfigProviders
 			pluginsWithOverrides = append(pluginsWithOverrides, pluginCfg.Locator.ID)
 		}
 		if len(pluginsWithOverrides) > 0 {
-			providerErrors[currProviderLocator] = fmt.Errorf("plugins specify override property as 'true', which is not supported in config providers")
+			providerErrors[currProvider.String()] = fmt.Errorf("plugins specify override property as 'true', which is not supported in config providers")
 			continue
 		}
 
@@ -107,89 +167,117 @@ func resolveConfigProviders(configsDir, downloadsDir string, taskConfigProviders
 	}
 
 	if len(providerErrors) == 0 {
-		return configs, nil
+		if len(configs) == 0 {
+			return configs, "", nil
+		}
+		return configs, hex.EncodeToString(contentHash.Sum(nil)), nil
 	}
 
 	// encountered errors: summarize and return
-	var sortedKeys []artifactresolver.Locator
+	var sortedKeys []string
 	for k := range providerErrors {
 		sortedKeys = append(sortedKeys, k)
 	}
-	pluginsinternal.SortLocators(sortedKeys)
+	sort.Strings(sortedKeys)
 
 	errStringsParts := []string{fmt.Sprintf("failed to resolve %d configuration provider(s):", len(providerErrors))}
 	for _, k := range sortedKeys {
 		errStringsParts = append(errStringsParts, providerErrors[k].Error())
 	}
-	return nil, errors.New(strings.Join(errStringsParts, "\n"+strings.Repeat(" ", pluginsinternal.IndentSpaces)))
+	return nil, "", errors.New(strings.Join(errStringsParts, "\n"+strings.Repeat(" ", pluginsinternal.IndentSpaces)))
 }
 
 func resolveAndVerifyConfigProvider(
 	currArtifact artifactresolver.LocatorWithResolverParam,
-	artifactErrors map[artifactresolver.Locator]error,
+	wantChecksum string,
+	refresh godellauncher.ConfigProviderRefreshParam,
+	forceRefresh bool,
 	dstBaseDir, downloadsDir string,
 	defaultResolvers []artifactresolver.Resolver,
-	stderr io.Writer) (currLocator artifactresolver.Locator, ok bool) {
+	stderr io.Writer) (string, error) {
 
-	currLocator = currArtifact.LocatorWithChecksums.Locator
+	currLocator := currArtifact.LocatorWithChecksums.Locator
 	currDstPath := filepath.Join(dstBaseDir, pathsinternal.ConfigProviderFileName(currLocator))
 
-	if _, err := os.Stat(currDstPath); os.IsNotExist(err) {
-		downloadDstPath := filepath.Join(downloadsDir, pathsinternal.ConfigProviderFileName(currLocator))
-		if err := artifactresolver.ResolveArtifact(currArtifact, defaultResolvers, osarch.Current(), downloadDstPath, artifactresolver.SHA256ChecksumFile, stderr); err != nil {
-			artifactErrors[currLocator] = err
-			return currLocator, false
+	exists, stale, err := cachedConfigProviderState(currDstPath, refresh)
+	if err != nil {
+		return "", err
+	}
+	if exists && wantChecksum != "" {
+		// verify the cached file every time that it is used: it may have been modified or written by a previous
+		// version of gödel that did not verify checksums
+		gotChecksum, err := artifactresolver.SHA256ChecksumFile(currDstPath)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to compute checksum of %s", currDstPath)
+		}
+		if gotChecksum != wantChecksum {
+			_, _ = fmt.Fprintf(stderr, "Cached configuration provider %s at %s does not match the checksum specified in godel.yml (want %s, got %s): resolving it again\n", currLocator, currDstPath, wantChecksum, gotChecksum)
+			exists, stale = false, true
 		}
-		if err := func() (rErr error) {
-			cfgFile, err := os.OpenFile(currDstPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	}
+	if stale || forceRefresh {
+		if err := func() error {
+			downloadDstPath := filepath.Join(downloadsDir, pathsinternal.ConfigProviderFileName(currLocator))
+			if err := artifactresolver.ResolveArtifact(currArtifact, defaultResolvers, osarch.Current(), downloadDstPath, artifactresolver.SHA256ChecksumFile, stderr); err != nil {
+				return err
+			}
+			cfgBytes, err := os.ReadFile(downloadDstPath)
 			if err != nil {
-				return errors.Wrapf(err, "failed to create file %s", currDstPath)
+				return errors.Wrapf(err, "failed to read %s", downloadDstPath)
 			}
-			defer func() {
-				if err := cfgFile.Close(); err != nil {
-					rErr = errors.Wrapf(err, "failed to close file %s", currDstPath)
+			if wantChecksum != "" {
+				if gotChecksum := sha256Hex(cfgBytes); gotChecksum != wantChecksum {
+					return errors.Errorf("checksum of configuration provider %s does not match the checksum specified in godel.yml: want %s, got %s. "+
+						"If the configuration provider was republished intentionally, update its checksum in godel.yml to %s; otherwise, verify that its resolvers refer to the expected repository", currLocator, wantChecksum, gotChecksum, gotChecksum)
 				}
-			}()
-
-			downloadedFile, err := os.Open(downloadDstPath)
-			if err != nil {
-				return errors.Wrapf(err, "failed to open %s for reading", downloadDstPath)
 			}
-			if _, err := io.Copy(cfgFile, downloadedFile); err != nil {
-				return err
+			if err := writeFileUsingRename(currDstPath, cfgBytes); err != nil {
+				return errors.Wrapf(err, "failed to copy resolved artifact to destination")
 			}
 			return nil
 		}(); err != nil {
-			artifactErrors[currLocator] = errors.Wrapf(err, "failed to copy resolved artifact to destination")
-			return currLocator, false
+			if !exists || forceRefresh {
+				return "", err
+			}
+			_, _ = fmt.Fprintf(stderr, "Failed to refresh configuration provider %s, using cached configuration: %v\n", currLocator, err)
 		}
 	}
+	return currDstPath, nil
+}
 
-	if wantChecksum, ok := currArtifact.LocatorWithChecksums.Checksums[osarch.Current()]; ok {
-		gotChecksum, err := artifactresolver.SHA256ChecksumFile(currDstPath)
-		if err != nil {
-			artifactErrors[currLocator] = errors.Wrapf(err, "failed to compute checksum for plugin")
-			return currLocator, false
-		}
-		if gotChecksum != wantChecksum {
-			artifactErrors[currLocator] = errors.Errorf("failed to verify checksum for %s: want %s, got %s", currDstPath, wantChecksum, gotChecksum)
-			return currLocator, false
-		}
-	}
-	return currLocator, true
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
 }
 
-func readConfigFromProvider(locator artifactresolver.Locator, configsDir string) (config.TasksConfig, error) {
-	cfgPath := filepath.Join(configsDir, pathsinternal.ConfigProviderFileName(locator))
+// cachedConfigProviderState returns whether the cached configuration provider file at the provided path exists and
+// whether it is stale based on the provided refresh policy. A file that does not exist is always stale.
+func cachedConfigProviderState(cfgPath string, refresh godellauncher.ConfigProviderRefreshParam) (exists bool, stale bool, rErr error) {
+	fi, err := os.Stat(cfgPath)
+	if os.IsNotExist(err) {
+		return false, true, nil
+	} else if err != nil {
+		return false, false, errors.Wrapf(err, "failed to stat %s", cfgPath)
+	}
+	switch {
+	case refresh.Always:
+		return true, true, nil
+	case refresh.TTL > 0:
+		return true, time.Since(fi.ModTime()) >= refresh.TTL, nil
+	default:
+		return true, false, nil
+	}
+}
 
+func readConfigFromProvider(cfgPath string) (config.TasksConfig, []byte, error) {
 	cfgBytes, err := os.ReadFile(cfgPath)
 	if err != nil {
-		return config.TasksConfig{}, errors.Wrapf(err, "failed to read %s", cfgPath)
+		return config.TasksConfig{}, nil, errors.Wrapf(err, "failed to read %s", cfgPath)
 	}
 
 	var tasksCfg config.TasksConfig
 	if err := yaml.Unmarshal(cfgBytes, &tasksCfg); err != nil {
-		return config.TasksConfig{}, errors.Wrapf(err, "failed to unmarshal %q as godellauncher.GodelConfig", string(cfgBytes))
+		return config.TasksConfig{}, nil, errors.Wrapf(err, "failed to unmarshal %q as godellauncher.GodelConfig", string(cfgBytes))
 	}
-	return tasksCfg, nil
+	return tasksCfg, cfgBytes, nil
 }
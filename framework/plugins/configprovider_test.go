@@ -0,0 +1,392 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/artifactresolver"
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/internal/pathsinternal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	providedPluginCfgTmpl = `plugins:
+  plugins:
+    - locator:
+        id: %s
+`
+	providedOverridePluginCfg = `plugins:
+  plugins:
+    - locator:
+        id: com.palantir:override:1.0.0
+      override: true
+`
+)
+
+func TestResolveConfigProvidersLocalPath(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	projectDir := filepath.Join(tmpDir, "project")
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "providers"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "providers", "relative.yml"), []byte(providedPluginCfg("com.palantir:relative:1.0.0")), 0644))
+	absPath := filepath.Join(tmpDir, "absolute.yml")
+	require.NoError(t, os.WriteFile(absPath, []byte(providedPluginCfg("com.palantir:absolute:1.0.0")), 0644))
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	cfgs, _, err := resolveConfigProviders(projectDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+		ConfigProviders: []godellauncher.ConfigProviderParam{
+			{LocalPath: "providers/relative.yml"},
+			{LocalPath: absPath},
+		},
+	}, false, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Len(t, cfgs, 2)
+	assert.Equal(t, "com.palantir:relative:1.0.0", cfgs[0].Plugins.Plugins[0].Locator.ID)
+	assert.Equal(t, "com.palantir:absolute:1.0.0", cfgs[1].Plugins.Plugins[0].Locator.ID)
+}
+
+func TestResolveConfigProvidersGit(t *testing.T) {
+	requireGit(t)
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	repoDir := filepath.Join(tmpDir, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "config"), 0755))
+	gitCmd(t, repoDir, "init", "--quiet", "--initial-branch", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "config", "godel.yml"), []byte(providedPluginCfg("com.palantir:tester:1.0.0")), 0644))
+	gitCmd(t, repoDir, "add", ".")
+	gitCmd(t, repoDir, "commit", "--quiet", "-m", "v1")
+	gitCmd(t, repoDir, "tag", "-a", "v1", "-m", "v1")
+	v1Commit := gitCmd(t, repoDir, "rev-parse", "HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "config", "godel.yml"), []byte(providedPluginCfg("com.palantir:tester:2.0.0")), 0644))
+	gitCmd(t, repoDir, "commit", "--quiet", "-am", "v2")
+	v2Commit := gitCmd(t, repoDir, "rev-parse", "HEAD")
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	for i, tc := range []struct {
+		name   string
+		ref    string
+		wantID string
+		commit string
+	}{
+		{"annotated tag", "v1", "com.palantir:tester:1.0.0", v1Commit},
+		{"branch", "main", "com.palantir:tester:2.0.0", v2Commit},
+		{"HEAD", "HEAD", "com.palantir:tester:2.0.0", v2Commit},
+		{"commit", v1Commit, "com.palantir:tester:1.0.0", v1Commit},
+	} {
+		cfgs, _, err := resolveConfigProviders(tmpDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+			ConfigProviders: []godellauncher.ConfigProviderParam{
+				{Git: &godellauncher.GitConfigProviderParam{URL: repoDir, Ref: tc.ref, Path: "config/godel.yml"}},
+			},
+		}, false, &bytes.Buffer{})
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		require.Len(t, cfgs, 1, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantID, cfgs[0].Plugins.Plugins[0].Locator.ID, "Case %d: %s", i, tc.name)
+		assert.FileExists(t, filepath.Join(configsDir, pathsinternal.GitConfigProviderFileName(repoDir, "config/godel.yml", tc.commit)), "Case %d: %s", i, tc.name)
+	}
+
+	// configuration providers pinned to a commit are read from the cache without contacting the repository
+	require.NoError(t, os.RemoveAll(repoDir))
+	cfgs, _, err := resolveConfigProviders(tmpDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+		ConfigProviders: []godellauncher.ConfigProviderParam{
+			{Git: &godellauncher.GitConfigProviderParam{URL: repoDir, Ref: v2Commit, Path: "config/godel.yml"}},
+		},
+	}, false, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+	assert.Equal(t, "com.palantir:tester:2.0.0", cfgs[0].Plugins.Plugins[0].Locator.ID)
+}
+
+func TestResolveConfigProvidersErrors(t *testing.T) {
+	requireGit(t)
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	repoDir := filepath.Join(tmpDir, "repo")
+	require.NoError(t, os.MkdirAll(repoDir, 0755))
+	gitCmd(t, repoDir, "init", "--quiet", "--initial-branch", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "godel.yml"), []byte(providedOverridePluginCfg), 0644))
+	gitCmd(t, repoDir, "add", ".")
+	gitCmd(t, repoDir, "commit", "--quiet", "-m", "initial")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "override.yml"), []byte(providedOverridePluginCfg), 0644))
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	_, _, err = resolveConfigProviders(tmpDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+		ConfigProviders: []godellauncher.ConfigProviderParam{
+			{LocalPath: "override.yml"},
+			{LocalPath: "missing.yml"},
+			{Git: &godellauncher.GitConfigProviderParam{URL: repoDir, Ref: "main", Path: "godel.yml"}},
+			{Git: &godellauncher.GitConfigProviderParam{URL: repoDir, Ref: "no-such-branch", Path: "godel.yml"}},
+		},
+	}, false, &bytes.Buffer{})
+	require.Error(t, err)
+	errLines := strings.Split(err.Error(), "\n")
+	require.Len(t, errLines, 5)
+	assert.Equal(t, "failed to resolve 4 configuration provider(s):", errLines[0])
+	assert.Equal(t, "    plugins specify override property as 'true', which is not supported in config providers", errLines[1])
+	assert.Equal(t, "    ref no-such-branch does not exist in git repository "+repoDir, errLines[2])
+	assert.Contains(t, errLines[3], "failed to read "+filepath.Join(tmpDir, "missing.yml"))
+	assert.Equal(t, "    plugins specify override property as 'true', which is not supported in config providers", errLines[4])
+}
+
+func TestResolveConfigProvidersRefresh(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	artifactsDir := filepath.Join(tmpDir, "artifacts")
+	require.NoError(t, os.MkdirAll(artifactsDir, 0755))
+	resolver, err := artifactresolver.NewTemplateResolver(filepath.Join(artifactsDir, "{{Product}}-{{Version}}.yml"))
+	require.NoError(t, err)
+	artifactPath := filepath.Join(artifactsDir, "provider-1.0.0-SNAPSHOT.yml")
+	providerParam := func(refresh godellauncher.ConfigProviderRefreshParam) godellauncher.TasksConfigProvidersParam {
+		return godellauncher.TasksConfigProvidersParam{
+			ConfigProviders: []godellauncher.ConfigProviderParam{
+				{
+					Artifact: &artifactresolver.LocatorWithResolverParam{
+						LocatorWithChecksums: artifactresolver.LocatorParam{
+							Locator: artifactresolver.Locator{
+								Group:   "com.palantir",
+								Product: "provider",
+								Version: "1.0.0-SNAPSHOT",
+							},
+						},
+						Resolver: resolver,
+					},
+					Refresh: refresh,
+				},
+			},
+		}
+	}
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	resolve := func(refresh godellauncher.ConfigProviderRefreshParam, forceRefresh bool) (string, string, string, error) {
+		stderr := &bytes.Buffer{}
+		cfgs, checksum, err := resolveConfigProviders(tmpDir, configsDir, downloadsDir, providerParam(refresh), forceRefresh, stderr)
+		if err != nil {
+			return "", "", stderr.String(), err
+		}
+		return cfgs[0].Plugins.Plugins[0].Locator.ID, checksum, stderr.String(), nil
+	}
+
+	require.NoError(t, os.WriteFile(artifactPath, []byte(providedPluginCfg("com.palantir:tester:1.0.0")), 0644))
+	id, v1Checksum, _, err := resolve(godellauncher.ConfigProviderRefreshParam{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:1.0.0", id)
+
+	// by default, a cached configuration provider is never resolved again
+	require.NoError(t, os.WriteFile(artifactPath, []byte(providedPluginCfg("com.palantir:tester:2.0.0")), 0644))
+	id, checksum, _, err := resolve(godellauncher.ConfigProviderRefreshParam{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:1.0.0", id)
+	assert.Equal(t, v1Checksum, checksum)
+
+	// configuration provider is not resolved again before the TTL elapses
+	id, _, _, err = resolve(godellauncher.ConfigProviderRefreshParam{TTL: time.Hour}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:1.0.0", id)
+
+	// configuration provider is resolved again after the TTL elapses
+	cachedPath := filepath.Join(configsDir, "com.palantir-provider-1.0.0-SNAPSHOT.yml")
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(cachedPath, twoHoursAgo, twoHoursAgo))
+	id, v2Checksum, _, err := resolve(godellauncher.ConfigProviderRefreshParam{TTL: time.Hour}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:2.0.0", id)
+	assert.NotEqual(t, v1Checksum, v2Checksum)
+
+	// configuration provider is always resolved again
+	require.NoError(t, os.WriteFile(artifactPath, []byte(providedPluginCfg("com.palantir:tester:3.0.0")), 0644))
+	id, _, _, err = resolve(godellauncher.ConfigProviderRefreshParam{Always: true}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:3.0.0", id)
+
+	// cached configuration provider is used if it cannot be refreshed
+	require.NoError(t, os.Remove(artifactPath))
+	id, _, stderr, err := resolve(godellauncher.ConfigProviderRefreshParam{Always: true}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:3.0.0", id)
+	assert.Contains(t, stderr, "Failed to refresh configuration provider com.palantir:provider:1.0.0-SNAPSHOT, using cached configuration")
+
+	// forced refresh fails if the configuration provider cannot be resolved
+	_, _, _, err = resolve(godellauncher.ConfigProviderRefreshParam{}, true)
+	assert.Error(t, err)
+}
+
+func TestResolveConfigProvidersChecksum(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	artifactsDir := filepath.Join(tmpDir, "artifacts")
+	require.NoError(t, os.MkdirAll(artifactsDir, 0755))
+	resolver, err := artifactresolver.NewTemplateResolver(filepath.Join(artifactsDir, "{{Product}}-{{Version}}.yml"))
+	require.NoError(t, err)
+	cfgContent := providedPluginCfg("com.palantir:tester:1.0.0")
+	require.NoError(t, os.WriteFile(filepath.Join(artifactsDir, "provider-1.0.0.yml"), []byte(cfgContent), 0644))
+	wantChecksum := sha256Hex([]byte(cfgContent))
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	resolve := func(checksum string) (string, error) {
+		stderr := &bytes.Buffer{}
+		_, _, err := resolveConfigProviders(tmpDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+			ConfigProviders: []godellauncher.ConfigProviderParam{
+				{
+					Artifact: &artifactresolver.LocatorWithResolverParam{
+						LocatorWithChecksums: artifactresolver.LocatorParam{
+							Locator: artifactresolver.Locator{
+								Group:   "com.palantir",
+								Product: "provider",
+								Version: "1.0.0",
+							},
+						},
+						Resolver: resolver,
+					},
+					Checksum: checksum,
+				},
+			},
+		}, false, stderr)
+		return stderr.String(), err
+	}
+
+	// checksum is verified when the configuration provider is downloaded
+	_, err = resolve(wantChecksum)
+	require.NoError(t, err)
+
+	// cached configuration provider that does not match the checksum is resolved again
+	cachedPath := filepath.Join(configsDir, "com.palantir-provider-1.0.0.yml")
+	require.NoError(t, os.WriteFile(cachedPath, []byte(providedOverridePluginCfg), 0644))
+	stderr, err := resolve(wantChecksum)
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Cached configuration provider com.palantir:provider:1.0.0 at "+cachedPath+" does not match the checksum specified in godel.yml")
+	cachedBytes, err := os.ReadFile(cachedPath)
+	require.NoError(t, err)
+	assert.Equal(t, cfgContent, string(cachedBytes))
+
+	// downloaded configuration provider that does not match the checksum is an error
+	wrongChecksum := sha256Hex([]byte("wrong"))
+	_, err = resolve(wrongChecksum)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum of configuration provider com.palantir:provider:1.0.0 does not match the checksum specified in godel.yml: want "+wrongChecksum+", got "+wantChecksum+". "+
+		"If the configuration provider was republished intentionally, update its checksum in godel.yml to "+wantChecksum)
+}
+
+func TestResolveConfigProvidersGitRefresh(t *testing.T) {
+	requireGit(t)
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	repoDir := filepath.Join(tmpDir, "repo")
+	require.NoError(t, os.MkdirAll(repoDir, 0755))
+	gitCmd(t, repoDir, "init", "--quiet", "--initial-branch", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "godel.yml"), []byte(providedPluginCfg("com.palantir:tester:1.0.0")), 0644))
+	gitCmd(t, repoDir, "add", ".")
+	gitCmd(t, repoDir, "commit", "--quiet", "-m", "v1")
+
+	configsDir, downloadsDir := createConfigProviderDirs(t, tmpDir)
+	resolve := func(refresh godellauncher.ConfigProviderRefreshParam, forceRefresh bool) (string, string, error) {
+		stderr := &bytes.Buffer{}
+		cfgs, _, err := resolveConfigProviders(tmpDir, configsDir, downloadsDir, godellauncher.TasksConfigProvidersParam{
+			ConfigProviders: []godellauncher.ConfigProviderParam{
+				{
+					Git:     &godellauncher.GitConfigProviderParam{URL: repoDir, Ref: "main", Path: "godel.yml"},
+					Refresh: refresh,
+				},
+			},
+		}, forceRefresh, stderr)
+		if err != nil {
+			return "", stderr.String(), err
+		}
+		return cfgs[0].Plugins.Plugins[0].Locator.ID, stderr.String(), nil
+	}
+	ttl := godellauncher.ConfigProviderRefreshParam{TTL: time.Hour}
+
+	id, _, err := resolve(ttl, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:1.0.0", id)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "godel.yml"), []byte(providedPluginCfg("com.palantir:tester:2.0.0")), 0644))
+	gitCmd(t, repoDir, "commit", "--quiet", "-am", "v2")
+
+	// commit that the ref resolved to is cached until the TTL elapses
+	id, _, err = resolve(ttl, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:1.0.0", id)
+
+	// forced refresh resolves the ref again
+	id, _, err = resolve(ttl, true)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:2.0.0", id)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "godel.yml"), []byte(providedPluginCfg("com.palantir:tester:3.0.0")), 0644))
+	gitCmd(t, repoDir, "commit", "--quiet", "-am", "v3")
+
+	// by default, the cached commit is used without resolving the ref again
+	id, stderr, err := resolve(godellauncher.ConfigProviderRefreshParam{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:2.0.0", id)
+	assert.Empty(t, stderr)
+
+	// ref that is refreshed every time uses the cached commit if the ref cannot be resolved
+	require.NoError(t, os.RemoveAll(repoDir))
+	id, stderr, err = resolve(godellauncher.ConfigProviderRefreshParam{Always: true}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "com.palantir:tester:2.0.0", id)
+	assert.Contains(t, stderr, "Failed to refresh configuration provider "+repoDir)
+}
+
+func providedPluginCfg(id string) string {
+	return strings.Replace(providedPluginCfgTmpl, "%s", id, 1)
+}
+
+func createConfigProviderDirs(t *testing.T, baseDir string) (string, string) {
+	configsDir := filepath.Join(baseDir, "configs")
+	downloadsDir := filepath.Join(baseDir, "downloads")
+	require.NoError(t, os.MkdirAll(configsDir, 0755))
+	require.NoError(t, os.MkdirAll(downloadsDir, 0755))
+	return configsDir, downloadsDir
+}
+
+func requireGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=tester", "-c", "user.email=tester@example.com"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v failed: %s", args, string(output))
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/palantir/godel/v2/framework/godellauncher"
+	"github.com/palantir/godel/v2/framework/internal/pathsinternal"
+	"github.com/pkg/errors"
+)
+
+var commitSHARegexp = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// resolveGitConfigProvider resolves the configuration provider file in the specified git repository into the
+// configurations directory and returns its path. The ref is resolved to a commit and the file is stored under a name
+// that includes the commit, so the repository is only fetched if the file for that commit has not been resolved before.
+// If the ref is a full commit SHA, the cached file is used without contacting the repository.
+//
+// The commit that a ref resolved to is also cached in the configurations directory. As with artifact providers, the
+// cached commit is used until the provided refresh policy specifies that it is stale, so by default a ref is only
+// resolved again if forceRefresh is true. If resolving a ref fails, the cached commit is used (unless forceRefresh is
+// true).
+func resolveGitConfigProvider(provider godellauncher.GitConfigProviderParam, refresh godellauncher.ConfigProviderRefreshParam, forceRefresh bool, configsDir, downloadsDir string, stderr io.Writer) (string, error) {
+	commit, err := resolveCachedGitRef(provider, refresh, forceRefresh, configsDir, stderr)
+	if err != nil {
+		return "", err
+	}
+	cfgPath := filepath.Join(configsDir, pathsinternal.GitConfigProviderFileName(provider.URL, provider.Path, commit))
+	if _, err := os.Stat(cfgPath); err == nil {
+		return cfgPath, nil
+	} else if !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "failed to stat %s", cfgPath)
+	}
+
+	cfgBytes, err := fetchGitFile(provider, commit, downloadsDir)
+	if err != nil {
+		return "", err
+	}
+
+	// write using a rename so that a partially written file is never used as a cached configuration
+	if err := writeFileUsingRename(cfgPath, cfgBytes); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", cfgPath)
+	}
+	return cfgPath, nil
+}
+
+// resolveCachedGitRef returns the commit SHA that the ref of the provided configuration provider refers to, using the
+// commit cached in the configurations directory if it is not stale based on the provided refresh policy.
+func resolveCachedGitRef(provider godellauncher.GitConfigProviderParam, refresh godellauncher.ConfigProviderRefreshParam, forceRefresh bool, configsDir string, stderr io.Writer) (string, error) {
+	if commitSHARegexp.MatchString(provider.Ref) {
+		return provider.Ref, nil
+	}
+	refPath := filepath.Join(configsDir, pathsinternal.GitConfigProviderRefFileName(provider.URL, provider.Ref))
+	exists, stale, err := cachedConfigProviderState(refPath, refresh)
+	if err != nil {
+		return "", err
+	}
+	var cachedCommit string
+	if exists {
+		refBytes, err := os.ReadFile(refPath)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read %s", refPath)
+		}
+		if cachedCommit = strings.TrimSpace(string(refBytes)); !commitSHARegexp.MatchString(cachedCommit) {
+			// treat invalid content as a cache miss
+			exists, stale, cachedCommit = false, true, ""
+		}
+	}
+	if !stale && !forceRefresh {
+		return cachedCommit, nil
+	}
+
+	commit, err := resolveGitRef(provider.URL, provider.Ref)
+	if err != nil {
+		if !exists || forceRefresh {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(stderr, "Failed to refresh configuration provider %s, using cached commit %s: %v\n", provider, cachedCommit, err)
+		return cachedCommit, nil
+	}
+	if err := writeFileUsingRename(refPath, []byte(commit+"\n")); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", refPath)
+	}
+	return commit, nil
+}
+
+// resolveGitRef returns the commit SHA that the specified ref refers to in the git repository with the specified URL.
+// Branches take precedence over tags and annotated tags are resolved to the commit that they refer to.
+func resolveGitRef(url, ref string) (string, error) {
+	output, err := runGit("", "ls-remote", "--", url, ref, ref+"^{}")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve ref %s in git repository %s", ref, url)
+	}
+	refs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			continue
+		}
+		refs[parts[1]] = parts[0]
+	}
+	for _, candidate := range []string{
+		ref + "^{}",
+		ref,
+		"refs/heads/" + ref,
+		"refs/tags/" + ref + "^{}",
+		"refs/tags/" + ref,
+	} {
+		if commit, ok := refs[candidate]; ok {
+			return commit, nil
+		}
+	}
+	return "", errors.Errorf("ref %s does not exist in git repository %s", ref, url)
+}
+
+// fetchGitFile fetches the specified commit from the git repository into a temporary repository in the downloads
+// directory and returns the content of the configuration provider file at that commit.
+func fetchGitFile(provider godellauncher.GitConfigProviderParam, commit, downloadsDir string) ([]byte, error) {
+	repoDir, err := os.MkdirTemp(downloadsDir, "git-config-provider-")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create temporary directory")
+	}
+	defer func() {
+		_ = os.RemoveAll(repoDir)
+	}()
+
+	if _, err := runGit(repoDir, "init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if _, err := runGit(repoDir, "fetch", "--quiet", "--depth", "1", "--", provider.URL, commit); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch commit %s from git repository %s", commit, provider.URL)
+	}
+	output, err := runGit(repoDir, "show", commit+":"+filepath.ToSlash(provider.Path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s at commit %s from git repository %s", provider.Path, commit, provider.URL)
+	}
+	return output, nil
+}
+
+// runGit runs git with the specified arguments in the specified directory and returns its standard output. Terminal
+// prompts are disabled so that a repository that requires credentials fails rather than blocking.
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
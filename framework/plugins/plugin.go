@@ -23,9 +23,11 @@ import (
 	"strings"
 
 	"github.com/palantir/godel/v2/framework/artifactresolver"
+	"github.com/palantir/godel/v2/framework/godel"
 	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/palantir/godel/v2/framework/internal/pathsinternal"
 	"github.com/palantir/godel/v2/framework/internal/pluginsinternal"
+	"github.com/palantir/godel/v2/framework/internal/versionsinternal"
 	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
 	"github.com/palantir/godel/v2/pkg/osarch"
 	"github.com/pkg/errors"
@@ -44,6 +46,7 @@ type pluginInfoWithAssets struct {
 //     gödel home plugins and downloads directories.
 //   - Verifies that the resolved plugins are valid and compatible with each other (for example, ensures that multiple
 //     plugins do not provide the same task).
+//   - Verifies that the running version of gödel is within the gödel version range declared by each plugin.
 //   - Creates runnable godellauncher.Task tasks for the plugins.
 //
 // Returns the tasks provided by the plugins in the provided parameters.
@@ -98,6 +101,11 @@ func loadPluginsTasks(pluginsParam godellauncher.PluginsParam, stderr io.Writer,
 		}
 	}
 
+	// cache is not keyed on the gödel version, so version compatibility is verified even if cached information is used
+	if err := verifyGodelVersionCompatibility(plugins, godel.Version); err != nil {
+		return nil, nil, err
+	}
+
 	var sortedPluginLocators []artifactresolver.Locator
 	for k := range plugins {
 		sortedPluginLocators = append(sortedPluginLocators, k)
@@ -114,7 +122,13 @@ func loadPluginsTasks(pluginsParam godellauncher.PluginsParam, stderr io.Writer,
 		for _, assetLoc := range pluginInfoWithAssets.Assets {
 			assetPaths = append(assetPaths, pathsinternal.PluginPath(assetsDir, assetLoc))
 		}
-		tasks = append(tasks, pluginInfoWithAssets.PluginInfo.Tasks(pluginExecPath, assetPaths)...)
+		for _, task := range pluginInfoWithAssets.PluginInfo.Tasks(pluginExecPath, assetPaths) {
+			task.Source = godellauncher.TaskSource{
+				Type:   godellauncher.PluginTaskSource,
+				Plugin: pluginLoc.String(),
+			}
+			tasks = append(tasks, task)
+		}
 
 		upgradeConfigTask := pluginInfoWithAssets.PluginInfo.UpgradeConfigTask(pluginExecPath, assetPaths)
 		if upgradeConfigTask != nil {
@@ -253,6 +267,75 @@ func verifyPluginCompatibility(plugins map[artifactresolver.Locator]pluginInfoWi
 	return errors.New(errString.String())
 }
 
+// verifyGodelVersionCompatibility verifies that the provided godelVersion is within the gödel version range declared by
+// each of the provided plugins. Plugins that do not declare a range are compatible with all versions. If godelVersion
+// is not an orderable version (for example, if it is "unspecified" or a local build with uncommitted changes), the
+// check is skipped.
+func verifyGodelVersionCompatibility(plugins map[artifactresolver.Locator]pluginInfoWithAssets, godelVersion string) error {
+	// comparing the version with itself determines whether it is valid and orderable
+	if _, ok, err := versionsinternal.CompareVersions(godelVersion, godelVersion); err != nil || !ok {
+		return nil
+	}
+
+	incompatible := make(map[artifactresolver.Locator]error)
+	for loc, info := range plugins {
+		versionRange := info.PluginInfo.GodelVersionRange()
+		if versionRange == nil {
+			continue
+		}
+		if err := verifyGodelVersionInRange(versionRange, godelVersion); err != nil {
+			incompatible[loc] = err
+		}
+	}
+
+	if len(incompatible) == 0 {
+		return nil
+	}
+
+	var sortedKeys []artifactresolver.Locator
+	for k := range incompatible {
+		sortedKeys = append(sortedKeys, k)
+	}
+	pluginsinternal.SortLocators(sortedKeys)
+
+	errStringsParts := []string{fmt.Sprintf("%d plugin(s) are not compatible with gödel version %s:", len(incompatible), godelVersion)}
+	for _, k := range sortedKeys {
+		errStringsParts = append(errStringsParts, fmt.Sprintf("%s: %v", k.String(), incompatible[k]))
+	}
+	return errors.New(strings.Join(errStringsParts, "\n"+strings.Repeat(" ", pluginsinternal.IndentSpaces)))
+}
+
+func verifyGodelVersionInRange(versionRange pluginapi.GodelVersionRange, godelVersion string) error {
+	var rangeParts []string
+	if versionRange.MinVersion() != "" {
+		rangeParts = append(rangeParts, ">= "+versionRange.MinVersion())
+	}
+	if versionRange.MaxVersion() != "" {
+		rangeParts = append(rangeParts, "< "+versionRange.MaxVersion())
+	}
+	requiredRange := strings.Join(rangeParts, " and ")
+
+	if minVersion := versionRange.MinVersion(); minVersion != "" {
+		cmp, ok, err := versionsinternal.CompareVersions(godelVersion, minVersion)
+		if err != nil || !ok {
+			return errors.Errorf("declares minimum gödel version %q, which is not a valid orderable version", minVersion)
+		}
+		if cmp < 0 {
+			return errors.Errorf("requires gödel version %s", requiredRange)
+		}
+	}
+	if maxVersion := versionRange.MaxVersion(); maxVersion != "" {
+		cmp, ok, err := versionsinternal.CompareVersions(godelVersion, maxVersion)
+		if err != nil || !ok {
+			return errors.Errorf("declares maximum gödel version %q, which is not a valid orderable version", maxVersion)
+		}
+		if cmp >= 0 {
+			return errors.Errorf("requires gödel version %s", requiredRange)
+		}
+	}
+	return nil
+}
+
 func verifySinglePluginCompatibility(plugin artifactresolver.Locator, plugins map[artifactresolver.Locator]pluginInfoWithAssets) map[artifactresolver.Locator]error {
 	errs := make(map[artifactresolver.Locator]error)
 	for otherPlugin, otherPluginInfo := range plugins {
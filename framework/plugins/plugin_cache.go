@@ -148,24 +148,30 @@ func (p *pluginInfoWithAssets) UnmarshalJSON(data []byte) error {
 // than misses, which makes this ideal information to cache.
 //
 // The cache file is determined by the SHA256 checksum of the JSON representation of the plugins config. The provided
-// PluginsParam should be the result of calling "ToParam()" on the provided PluginsConfig. Note that the name of the
-// cache file is based on the checksum of the plugins configuration, but its content is the JSON representation of the
-// plugins map used to compute the result of this function (so the checksum of the content will not match the name of
-// the file).
+// PluginsParam should be the result of calling "ToParam()" on the provided PluginsConfig. The providedConfigsChecksum
+// is the checksum of the content of the tasks configuration providers (as returned by LoadProvidedConfigurations): if
+// it is non-empty, it is included in the checksum that determines the cache file so that the cache is not used if the
+// content of a configuration provider changes. Note that the name of the cache file is based on the checksum of the
+// plugins configuration, but its content is the JSON representation of the plugins map used to compute the result of
+// this function (so the checksum of the content will not match the name of the file).
 //
-// The current design keys the cache content only on the plugins configuration. This means, that if the logic of godel
+// The current design keys the cache content only on the configuration. This means, that if the logic of godel
 // itself changes in a manner such that a given plugin configuration would produce different plugin information, the
 // cached information would not be correct. In such a scenario, the naming scheme for the cache files would need to be
 // updated to ensure that invalid cache information is not used. Namespacing the cache files by the version of godel
 // would be one way of protecting against this, but given that the logic for computing the plugin information is very
 // stable (it has not changed in over 9 years), it is unlikely to be an issue (and in such a circumstance, changing the
 // naming scheme of the cache to add some kind of schema prefix or suffix or as a parent directory should be a
-// sufficient solution).
-func LoadPluginsTasksWithCache(pluginsConfig config.PluginsConfig, pluginsParam godellauncher.PluginsParam, stderr io.Writer) ([]godellauncher.Task, []godellauncher.UpgradeConfigTask, error) {
+// sufficient solution). The same applies when fields are added to the plugin information: the cache entries written by
+// older versions of godel do not contain them, so pluginsConfigCacheVersion must be incremented.
+func LoadPluginsTasksWithCache(pluginsConfig config.PluginsConfig, pluginsParam godellauncher.PluginsParam, providedConfigsChecksum string, stderr io.Writer) ([]godellauncher.Task, []godellauncher.UpgradeConfigTask, error) {
 	configBytes, err := json.Marshal(pluginsConfig)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to marshal plugins config as JSON")
 	}
+	if providedConfigsChecksum != "" {
+		configBytes = append(configBytes, []byte("\n"+providedConfigsChecksum)...)
+	}
 	pluginsConfigCachePath, err := cacheFilePathForBytes(configBytes)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to create plugins config cache file path")
@@ -185,6 +191,14 @@ func cacheFilePathForBytes(data []byte) (string, error) {
 	return filepath.Join(pluginsConfigCacheBasePath, hex.EncodeToString(checksum[:])+".json"), nil
 }
 
+// pluginsConfigCacheVersion is the name of the directory in the plugins-config cache directory that contains the cache
+// files. It must be changed whenever the content of the plugin information changes so that entries written by older
+// versions of godel (which do not contain the new content) are not used.
+//
+//   - v2: plugin information includes the gödel version range and configuration schema of the plugin and the long
+//     description, usage and completion information of its tasks
+const pluginsConfigCacheVersion = "v2"
+
 // Returns the path to the plugins-config cache directory. The path to the directory is created if it does not exist.
 func pluginsConfigCacheDir() (string, error) {
 	godelHomeSpecDir, err := layout.GodelHomeSpecDir(specdir.Create)
@@ -192,7 +206,7 @@ func pluginsConfigCacheDir() (string, error) {
 		return "", errors.Wrapf(err, "failed to create gödel home directory")
 	}
 	cacheDir := godelHomeSpecDir.Path(layout.CacheDir)
-	pluginsConfigPath := filepath.Join(cacheDir, "plugins-config", "v1")
+	pluginsConfigPath := filepath.Join(cacheDir, "plugins-config", pluginsConfigCacheVersion)
 	if err := os.MkdirAll(pluginsConfigPath, 0755); err != nil {
 		return "", errors.Wrapf(err, "failed to create plugins-config cache directory at %q", pluginsConfigPath)
 	}
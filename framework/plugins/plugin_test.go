@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -311,3 +312,83 @@ func TestVerifyPluginCompatibility(t *testing.T) {
 func newPluginName() string {
 	return fmt.Sprintf("tester-%d-plugin", time.Now().Unix())
 }
+
+func TestVerifyGodelVersionCompatibility(t *testing.T) {
+	fooLocator := artifactresolver.Locator{
+		Group:   "com.palantir",
+		Product: "foo-plugin",
+		Version: "1.0.0",
+	}
+	for i, tc := range []struct {
+		name         string
+		godelVersion string
+		params       []pluginapi.PluginInfoParam
+		want         string
+	}{
+		{
+			"plugin without range is compatible",
+			"2.50.0",
+			nil,
+			"",
+		},
+		{
+			"version within range is compatible",
+			"2.50.0",
+			[]pluginapi.PluginInfoParam{pluginapi.PluginInfoGodelVersionRange("2.40.0", "3.0.0")},
+			"",
+		},
+		{
+			"minimum version is inclusive",
+			"2.40.0",
+			[]pluginapi.PluginInfoParam{pluginapi.PluginInfoGodelVersionRange("2.40.0", "")},
+			"",
+		},
+		{
+			"version below minimum is not compatible",
+			"2.30.0",
+			[]pluginapi.PluginInfoParam{pluginapi.PluginInfoGodelVersionRange("2.40.0", "3.0.0")},
+			`1 plugin(s) are not compatible with gödel version 2.30.0:
+    com.palantir:foo-plugin:1.0.0: requires gödel version >= 2.40.0 and < 3.0.0`,
+		},
+		{
+			"maximum version is exclusive",
+			"3.0.0",
+			[]pluginapi.PluginInfoParam{pluginapi.PluginInfoGodelVersionRange("", "3.0.0")},
+			`1 plugin(s) are not compatible with gödel version 3.0.0:
+    com.palantir:foo-plugin:1.0.0: requires gödel version < 3.0.0`,
+		},
+		{
+			"check is skipped if gödel version is not orderable",
+			"unspecified",
+			[]pluginapi.PluginInfoParam{pluginapi.PluginInfoGodelVersionRange("2.40.0", "3.0.0")},
+			"",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plugins := map[artifactresolver.Locator]pluginInfoWithAssets{
+				fooLocator: {
+					PluginInfo: pluginapi.MustNewPluginInfo("com.palantir", "foo-plugin", "1.0.0", tc.params...),
+				},
+			}
+			got := verifyGodelVersionCompatibility(plugins, tc.godelVersion)
+			if tc.want == "" {
+				assert.NoError(t, got, "Case %d: %s", i, tc.name)
+			} else {
+				assert.EqualError(t, got, tc.want, "Case %d: %s", i, tc.name)
+			}
+		})
+	}
+
+	// plugins built using a version of the plugin API that does not validate the range can declare invalid versions
+	infoJSON, err := pluginapi.MustNewPluginInfo("com.palantir", "foo-plugin", "1.0.0", pluginapi.PluginInfoGodelVersionRange("2.40.0", "")).MarshalPluginInfoJSON()
+	require.NoError(t, err)
+	info, err := pluginapi.UnmarshalPluginInfoJSON([]byte(strings.Replace(string(infoJSON), `"2.40.0"`, `"latest"`, 1)))
+	require.NoError(t, err)
+	err = verifyGodelVersionCompatibility(map[artifactresolver.Locator]pluginInfoWithAssets{
+		fooLocator: {
+			PluginInfo: info,
+		},
+	}, "2.50.0")
+	assert.EqualError(t, err, `1 plugin(s) are not compatible with gödel version 2.50.0:
+    com.palantir:foo-plugin:1.0.0: declares minimum gödel version "latest", which is not a valid orderable version`)
+}
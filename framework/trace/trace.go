@@ -0,0 +1,260 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trace records spans in the Chrome trace event format. The trace can be viewed using chrome://tracing or
+// https://ui.perfetto.dev.
+//
+// gödel records spans in memory when it is run with the "--trace" flag and writes them to the trace file before it
+// exits. The processes that gödel starts for plugins are provided the EventsFileEnvVar environment variable, which is
+// the path to a file to which the spans recorded by the process are appended. Once the process exits, gödel adds the
+// spans in the file to its trace as children of the span that ran the process.
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EventsFileEnvVar is the environment variable that specifies the file to which a process started by gödel should
+// append the trace events for its spans. The file contains one JSON-encoded Event per line. If the environment
+// variable is not set, the process should not record spans.
+const EventsFileEnvVar = "GODEL_TRACE_EVENTS_FILE"
+
+// Event is a trace event in the Chrome trace event format. Timestamps and durations are in microseconds.
+type Event struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	TS       int64          `json:"ts"`
+	Duration int64          `json:"dur,omitempty"`
+	PID      int            `json:"pid"`
+	TID      int            `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// completePhase is the phase of an event that records a span with a duration.
+const completePhase = "X"
+
+var (
+	mutex sync.Mutex
+	// recording is true if spans are recorded in memory (gödel was run with the "--trace" flag).
+	recording bool
+	events    []Event
+	// tracePath is the path to which Finish writes the trace.
+	tracePath string
+)
+
+// Start starts recording spans in memory. Finish writes the recorded spans to the provided path.
+func Start(path string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	recording = true
+	events = nil
+	tracePath = path
+}
+
+// Enabled returns true if spans are recorded by this process, either in memory or in the file specified by
+// EventsFileEnvVar.
+func Enabled() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return recording || os.Getenv(EventsFileEnvVar) != ""
+}
+
+// Finish writes the spans recorded since Start was called to the path provided to Start as a Chrome trace JSON object
+// and stops recording. Does nothing if spans are not being recorded.
+func Finish() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !recording {
+		return nil
+	}
+	recording = false
+	traceEvents := append([]Event{{
+		Name:  "process_name",
+		Phase: "M",
+		PID:   os.Getpid(),
+		TID:   mainTID,
+		Args: map[string]any{
+			"name": "godel",
+		},
+	}}, events...)
+	events = nil
+
+	bytes, err := json.MarshalIndent(struct {
+		TraceEvents     []Event `json:"traceEvents"`
+		DisplayTimeUnit string  `json:"displayTimeUnit"`
+	}{
+		TraceEvents:     traceEvents,
+		DisplayTimeUnit: "ms",
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal trace")
+	}
+	if err := os.WriteFile(tracePath, bytes, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write trace file %s", tracePath)
+	}
+	return nil
+}
+
+// mainTID is the thread ID of all of the events. Tasks are run sequentially, so spans that overlap are nested.
+const mainTID = 1
+
+// Span is a span that is being recorded. The zero value (and nil) is a span that is not recorded, so the functions of
+// this package can be called regardless of whether tracing is enabled.
+type Span struct {
+	name     string
+	category string
+	start    time.Time
+	args     map[string]any
+}
+
+// StartSpan starts a span with the provided name and category. The span is recorded when End is called. If spans are
+// not being recorded, returns nil.
+func StartSpan(name, category string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	return &Span{
+		name:     name,
+		category: category,
+		start:    time.Now(),
+	}
+}
+
+// SetArg sets an argument that is shown with the span.
+func (s *Span) SetArg(key string, value any) {
+	if s == nil {
+		return
+	}
+	if s.args == nil {
+		s.args = make(map[string]any)
+	}
+	s.args[key] = value
+}
+
+// End records the span. Errors that occur while appending the span to the file specified by EventsFileEnvVar are
+// ignored because they should not cause the operation that is being traced to fail.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	addEvent(Event{
+		Name:     s.name,
+		Category: s.category,
+		Phase:    completePhase,
+		TS:       s.start.UnixMicro(),
+		Duration: max(time.Since(s.start).Microseconds(), 1),
+		PID:      os.Getpid(),
+		TID:      mainTID,
+		Args:     s.args,
+	})
+}
+
+func appendEvent(path string, event Event) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	// each event is written using a single write so that events appended by multiple processes are not interleaved
+	if _, err := f.Write(append(bytes, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// TraceCommand configures the provided command so that the spans recorded by the process are added to the trace. The
+// returned function must be called after the process exits: it adds the spans recorded by the process as children of
+// the current span. If spans are not being recorded, the command is not modified and the returned function does
+// nothing.
+func TraceCommand(cmd *exec.Cmd) (collect func()) {
+	if !Enabled() {
+		return func() {}
+	}
+	f, err := os.CreateTemp("", "godel-trace-events-")
+	if err != nil {
+		return func() {}
+	}
+	eventsFile := f.Name()
+	_ = f.Close()
+
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, EventsFileEnvVar+"="+eventsFile)
+	return func() {
+		defer func() {
+			_ = os.Remove(eventsFile)
+		}()
+		childEvents, err := readEvents(eventsFile)
+		if err != nil {
+			return
+		}
+		for _, event := range childEvents {
+			// record the events as events of this process so that they are nested in the span that ran the process
+			event.PID = os.Getpid()
+			event.TID = mainTID
+			addEvent(event)
+		}
+	}
+}
+
+// addEvent records the provided event in memory if spans are being recorded and otherwise appends it to the file
+// specified by EventsFileEnvVar (if any).
+func addEvent(event Event) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if recording {
+		events = append(events, event)
+		return
+	}
+	if eventsFile := os.Getenv(EventsFileEnvVar); eventsFile != "" {
+		_ = appendEvent(eventsFile, event)
+	}
+}
+
+func readEvents(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var fileEvents []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// ignore lines that are not valid events (for example, a partially written event of a process that was
+			// killed)
+			continue
+		}
+		fileEvents = append(fileEvents, event)
+	}
+	return fileEvents, scanner.Err()
+}
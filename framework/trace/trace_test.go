@@ -0,0 +1,125 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/palantir/godel/v2/framework/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	tracePath := filepath.Join(tmpDir, "trace.json")
+	trace.Start(tracePath)
+	require.True(t, trace.Enabled())
+
+	parent := trace.StartSpan("run verify", "tasks")
+	child := trace.StartSpan("verify format", "verify")
+	child.SetArg("args", []string{"--verify"})
+	child.End()
+
+	cmd := exec.Command("plugin")
+	collect := trace.TraceCommand(cmd)
+	eventsFile := eventsFileFromEnv(t, cmd.Env)
+	// simulate a plugin process that records a span
+	require.NoError(t, os.WriteFile(eventsFile, []byte(`{"name":"plugin span","ph":"X","ts":1,"dur":2,"pid":123,"tid":7}`+"\n"+`{"name":"partial`), 0644))
+	collect()
+	_, err = os.Stat(eventsFile)
+	assert.True(t, os.IsNotExist(err), "events file should be removed once it is collected")
+	parent.End()
+
+	require.NoError(t, trace.Finish())
+	assert.False(t, trace.Enabled())
+
+	bytes, err := os.ReadFile(tracePath)
+	require.NoError(t, err)
+	var got struct {
+		TraceEvents []trace.Event `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(bytes, &got))
+	var names []string
+	for _, event := range got.TraceEvents {
+		names = append(names, event.Name)
+		if event.Phase == "X" {
+			assert.Equal(t, os.Getpid(), event.PID, "event %s", event.Name)
+			assert.Equal(t, got.TraceEvents[1].TID, event.TID, "event %s", event.Name)
+		}
+	}
+	assert.Equal(t, []string{"process_name", "verify format", "plugin span", "run verify"}, names)
+	assert.Equal(t, map[string]any{"args": []any{"--verify"}}, got.TraceEvents[1].Args)
+	assert.True(t, got.TraceEvents[3].TS <= got.TraceEvents[1].TS)
+}
+
+func TestTraceDisabled(t *testing.T) {
+	require.False(t, trace.Enabled())
+
+	span := trace.StartSpan("span", "tasks")
+	assert.Nil(t, span)
+	// functions of nil spans do nothing
+	span.SetArg("key", "value")
+	span.End()
+
+	cmd := exec.Command("plugin")
+	trace.TraceCommand(cmd)()
+	assert.Nil(t, cmd.Env)
+	assert.NoError(t, trace.Finish())
+}
+
+func TestTraceEventsFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	// a process started by gödel appends its spans to the events file
+	eventsFile := filepath.Join(tmpDir, "events.jsonl")
+	t.Setenv(trace.EventsFileEnvVar, eventsFile)
+	require.True(t, trace.Enabled())
+
+	trace.StartSpan("first", "plugin").End()
+	trace.StartSpan("second", "plugin").End()
+
+	bytes, err := os.ReadFile(eventsFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bytes)), "\n")
+	require.Len(t, lines, 2)
+	for i, wantName := range []string{"first", "second"} {
+		var event trace.Event
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &event))
+		assert.Equal(t, wantName, event.Name)
+		assert.Equal(t, "X", event.Phase)
+	}
+}
+
+func eventsFileFromEnv(t *testing.T, env []string) string {
+	for _, kv := range env {
+		if v, ok := strings.CutPrefix(kv, trace.EventsFileEnvVar+"="); ok {
+			return v
+		}
+	}
+	require.Fail(t, "environment does not contain "+trace.EventsFileEnvVar)
+	return ""
+}
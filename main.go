@@ -15,18 +15,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/nmiyake/pkg/errorstringer"
 	"github.com/palantir/godel/v2/framework/builtintasks"
+	"github.com/palantir/godel/v2/framework/builtintasks/history"
 	"github.com/palantir/godel/v2/framework/godel"
 	"github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/godellauncher"
 	"github.com/palantir/godel/v2/framework/godellauncher/defaulttasks"
+	"github.com/palantir/godel/v2/framework/pluginapi/v2/pluginapi"
 	"github.com/palantir/godel/v2/framework/plugins"
+	"github.com/palantir/godel/v2/framework/trace"
+	"github.com/spf13/cobra"
 )
 
 func main() {
@@ -42,39 +48,109 @@ func runGodelApp(osArgs []string) int {
 	}
 	if err != nil {
 		// match invalid flag output with that provided by Cobra CLI
-		printErrAndExit(fmt.Errorf("%s", err.Error()+"\n"+godellauncher.UsageString(createTasks(nil, nil, nil, tasksCfgInfo))), false)
+		printErrAndExit(fmt.Errorf("%s", err.Error()+"\n"+godellauncher.UsageString(createTasks(nil, nil, nil, godellauncher.UserTasksParam{}, godellauncher.HooksParam{}, godellauncher.TimeoutsParam{}, tasksCfgInfo))), false)
+	}
+	if global.Trace != "" {
+		trace.Start(global.Trace)
 	}
 
 	var allUpgradeConfigTasks []godellauncher.UpgradeConfigTask
 	var defaultTasks, pluginTasks []godellauncher.Task
+	var userTasksParam godellauncher.UserTasksParam
+	var aliases []godellauncher.Alias
+	var hooksParam godellauncher.HooksParam
+	var timeoutsParam godellauncher.TimeoutsParam
+	var historyParam godellauncher.HistoryParam
+	var historyLimits history.Limits
 	if global.Wrapper != "" {
+		span := trace.StartSpan("read godel config", "config")
 		godelCfg, err := config.ReadGodelConfigFromProjectDir(filepath.Dir(global.Wrapper))
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
 
-		// set environment variables specified in configuration
-		for k, v := range godelCfg.Environment {
+		includedConfigs, err := config.ReadIncludedConfigs(filepath.Dir(global.Wrapper), godelCfg)
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		// set environment variables specified in configuration once all of the included files have been read
+		for k, v := range config.CombinedEnvironment(godelCfg, includedConfigs) {
 			if err := os.Setenv(k, v); err != nil {
 				printErrAndExit(err, global.Debug)
 			}
 		}
 
+		userTasksParam, err = config.UserTasksConfig(godelCfg.Tasks).ToParam()
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		aliases, err = config.AliasesConfig(godelCfg.Aliases).ToParam()
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		hooksParam, err = config.HooksConfig(godelCfg.Hooks).ToParam()
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		timeoutsParam, err = config.TimeoutsConfig(godelCfg.Timeouts).ToParam()
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		historyParam = config.HistoryConfig(godelCfg.History).ToParam()
+		historyLimits, err = history.LimitsFromEnv()
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+
 		taskCfgProviders := config.TasksConfigProvidersConfig(godelCfg.TasksConfigProviders)
 		configProvidersParam, err := taskCfgProviders.ToParam()
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
-		providedConfigs, err := plugins.LoadProvidedConfigurations(configProvidersParam, os.Stderr)
+		span.End()
+
+		span = trace.StartSpan("resolve config providers", "config")
+		providedConfigs, providedConfigsChecksum, err := plugins.LoadProvidedConfigurations(filepath.Dir(global.Wrapper), configProvidersParam, os.Stderr)
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
+		span.End()
+
 		tasksConfig := config.TasksConfig{}
+		var tasksConfigProvenance config.TasksConfigProvenance
 		// add resolved configurations
-		tasksConfig.Combine(providedConfigs...)
-		// add configuration specified in config file (overrides any provided config)
-		tasksConfig.Combine(config.TasksConfig(godelCfg.TasksConfig))
+		var sourcedProvidedConfigs []config.SourcedTasksConfig
+		for i, providedConfig := range providedConfigs {
+			sourcedProvidedConfigs = append(sourcedProvidedConfigs, config.SourcedTasksConfig{
+				Source:      fmt.Sprintf("config provider %s", configProvidersParam.ConfigProviders[i]),
+				TasksConfig: providedConfig,
+			})
+		}
+		sourcedProvidedConfigs, pluginVersionConflicts, err := config.ResolvePluginVersionConflicts(sourcedProvidedConfigs, config.PluginVersionConflictPolicy(taskCfgProviders.VersionConflictPolicy))
+		if err != nil {
+			printErrAndExit(err, global.Debug)
+		}
+		tasksCfgInfo.PluginVersionConflicts = pluginVersionConflicts
+		tasksConfig.CombineWithProvenance(&tasksConfigProvenance, sourcedProvidedConfigs...)
+		// add configuration from included files (overrides any provided config)
+		var sourcedIncludedConfigs []config.SourcedTasksConfig
+		var includedConfigFiles []string
+		for _, includedConfig := range includedConfigs {
+			sourcedIncludedConfigs = append(sourcedIncludedConfigs, config.SourcedTasksConfig{
+				Source:      includedConfig.Path,
+				TasksConfig: includedConfig.TasksConfig,
+			})
+			includedConfigFiles = append(includedConfigFiles, includedConfig.Path)
+		}
+		tasksConfig.CombineWithProvenance(&tasksConfigProvenance, sourcedIncludedConfigs...)
+		tasksCfgInfo.IncludedConfigFiles = includedConfigFiles
+		// add configuration specified in config file (overrides any provided and included config)
+		tasksConfig.CombineWithProvenance(&tasksConfigProvenance, config.SourcedTasksConfig{
+			Source:      godellauncher.GodelConfigYML,
+			TasksConfig: config.TasksConfig(godelCfg.TasksConfig),
+		})
 		tasksCfgInfo.TasksConfig = tasksConfig
+		tasksCfgInfo.TasksConfigProvenance = tasksConfigProvenance
 
 		// add default tasks
 		defaultTasksCfg, err := defaulttasks.PluginsConfig(config.DefaultTasksConfig(tasksConfig.DefaultTasks))
@@ -89,10 +165,15 @@ func runGodelApp(osArgs []string) int {
 		var defaultUpgradeConfigTasks, pluginUpgradeConfigTasks []godellauncher.UpgradeConfigTask
 
 		tasksCfgInfo.DefaultTasksPluginsConfig = defaultTasksCfg
-		defaultTasks, defaultUpgradeConfigTasks, err = plugins.LoadPluginsTasksWithCache(defaultTasksCfg, defaultTasksParam, os.Stderr)
+		span = trace.StartSpan("load default tasks", "plugins")
+		defaultTasks, defaultUpgradeConfigTasks, err = plugins.LoadPluginsTasksWithCache(defaultTasksCfg, defaultTasksParam, providedConfigsChecksum, os.Stderr)
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
+		span.End()
+		for i := range defaultTasks {
+			defaultTasks[i].Source.Type = godellauncher.DefaultPluginTaskSource
+		}
 
 		// add tasks provided by plugins
 		pluginsCfg := config.PluginsConfig(tasksConfig.Plugins)
@@ -100,13 +181,16 @@ func runGodelApp(osArgs []string) int {
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
-		pluginTasks, pluginUpgradeConfigTasks, err = plugins.LoadPluginsTasksWithCache(pluginsCfg, pluginsParam, os.Stderr)
+		span = trace.StartSpan("load plugin tasks", "plugins")
+		pluginTasks, pluginUpgradeConfigTasks, err = plugins.LoadPluginsTasksWithCache(pluginsCfg, pluginsParam, providedConfigsChecksum, os.Stderr)
 		if err != nil {
 			printErrAndExit(err, global.Debug)
 		}
+		span.End()
 
 		if len(defaultTasksCfg.Plugins) != 0 && len(tasksConfig.Plugins.Plugins) != 0 {
 			// verify that there are no conflicts
+			span = trace.StartSpan("check plugin conflicts", "plugins")
 			combinedCfg := config.PluginsConfig(tasksConfig.Plugins)
 			combinedCfg.DefaultResolvers = append(combinedCfg.DefaultResolvers, tasksConfig.Plugins.DefaultResolvers...)
 			combinedCfg.Plugins = append(combinedCfg.Plugins, tasksConfig.Plugins.Plugins...)
@@ -114,9 +198,10 @@ func runGodelApp(osArgs []string) int {
 			if err != nil {
 				printErrAndExit(err, global.Debug)
 			}
-			if _, _, err := plugins.LoadPluginsTasksWithCache(combinedCfg, combinedParam, io.Discard); err != nil {
+			if _, _, err := plugins.LoadPluginsTasksWithCache(combinedCfg, combinedParam, providedConfigsChecksum, io.Discard); err != nil {
 				printErrAndExit(err, global.Debug)
 			}
+			span.End()
 		}
 
 		// add all upgrade tasks
@@ -124,31 +209,122 @@ func runGodelApp(osArgs []string) int {
 		allUpgradeConfigTasks = append(allUpgradeConfigTasks, defaultUpgradeConfigTasks...)
 		allUpgradeConfigTasks = append(allUpgradeConfigTasks, pluginUpgradeConfigTasks...)
 	}
-	task, err := godellauncher.TaskForInput(global, createTasks(defaultTasks, pluginTasks, allUpgradeConfigTasks, tasksCfgInfo))
+	span := trace.StartSpan("create tasks", "tasks")
+	tasks := createTasks(defaultTasks, pluginTasks, allUpgradeConfigTasks, userTasksParam, hooksParam, timeoutsParam, tasksCfgInfo)
+	if err := builtintasks.CheckHooks(hooksParam, tasks); err != nil {
+		printErrAndExit(err, global.Debug)
+	}
+	if err := builtintasks.CheckTimeouts(timeoutsParam, tasks); err != nil {
+		printErrAndExit(err, global.Debug)
+	}
+	if err := builtintasks.CheckUserTasks(userTasksParam, tasks); err != nil {
+		printErrAndExit(err, global.Debug)
+	}
+	// expand aliases before determining the task so that the task is run with the expanded task name and arguments
+	expandedGlobal, err := godellauncher.ExpandAlias(global, aliases, tasks)
+	if err != nil {
+		printErrAndExit(err, global.Debug)
+	}
+	global = expandedGlobal
+	span.End()
+	// aliases are included in the tasks so that they are listed in the help output
+	task, err := godellauncher.TaskForInput(global, append(tasks, godellauncher.AliasTasks(aliases, tasks)...))
 	if err != nil {
 		// match missing command output with that provided by Cobra CLI
 		errTmpl := "%s\nRun '%s --help' for usage."
 		printErrAndExit(fmt.Errorf(errTmpl, err.Error(), godel.AppName), false)
 	}
 
-	if err := task.Run(global, os.Stdout); err != nil {
+	// the context is cancelled if gödel receives SIGINT or SIGTERM so that the task can stop the processes it started
+	ctx, stopSignalHandling := godellauncher.SignalContext(context.Background())
+	span = trace.StartSpan("run "+task.Name, "tasks")
+	span.SetArg("args", global.TaskArgs)
+	start := time.Now()
+	err = task.RunContext(ctx, global, os.Stdout)
+	span.End()
+	recordTaskRun(historyParam, historyLimits, global, task, start, err)
+	stopSignalHandling()
+	// shut down any long-lived plugin processes started to run the task
+	span = trace.StartSpan("close plugin processes", "plugins")
+	pluginapi.ClosePluginProcesses()
+	span.End()
+	if err != nil {
 		// note that only app/amalgomated tasks will never reach this point, as they return an exit code and then
 		// pass through an empty error. Those tasks are expected to handle their own error output.
 		printErrAndExit(err, global.Debug)
 	}
+	finishTrace()
 	return 0
 }
 
-func createTasks(defaultTasks, pluginTasks []godellauncher.Task, upgradeConfigTasks []godellauncher.UpgradeConfigTask, tasksCfgInfo config.TasksConfigInfo) []godellauncher.Task {
+func createTasks(defaultTasks, pluginTasks []godellauncher.Task, upgradeConfigTasks []godellauncher.UpgradeConfigTask, userTasksParam godellauncher.UserTasksParam, hooksParam godellauncher.HooksParam, timeoutsParam godellauncher.TimeoutsParam, tasksCfgInfo config.TasksConfigInfo) []godellauncher.Task {
 	var allTasks []godellauncher.Task
-	allTasks = append(allTasks, builtintasks.Tasks(tasksCfgInfo)...)
-	allTasks = append(allTasks, defaultTasks...)
-	allTasks = append(allTasks, builtintasks.VerifyTask(append(allTasks, pluginTasks...), config.VerifyTasksConfig(tasksCfgInfo.TasksConfig.VerifyTasks)))
-	allTasks = append(allTasks, builtintasks.UpgradeConfigTask(upgradeConfigTasks))
-	allTasks = append(allTasks, pluginTasks...)
+	// the steps of user-defined tasks and the tasks run by hooks are resolved against the complete set of tasks when
+	// they are run
+	allTasksFn := func() []godellauncher.Task {
+		return allTasks
+	}
+	// timeouts and hooks are added to the tasks before they are provided to tasks such as "verify" so that they apply
+	// however the tasks are run. Timeouts only apply to the task itself and not to its hooks.
+	withTimeoutsAndHooks := func(tasks ...godellauncher.Task) []godellauncher.Task {
+		return builtintasks.WithHooks(builtintasks.WithTimeouts(tasks, timeoutsParam), hooksParam, allTasksFn)
+	}
+	var extraTasks []godellauncher.Task
+	extraTasks = append(extraTasks, withTimeoutsAndHooks(pluginTasks...)...)
+	extraTasks = append(extraTasks, withTimeoutsAndHooks(builtintasks.UserTasks(userTasksParam, allTasksFn)...)...)
+
+	allTasks = append(allTasks, withTimeoutsAndHooks(builtintasks.Tasks(tasksCfgInfo)...)...)
+	allTasks = append(allTasks, withTimeoutsAndHooks(defaultTasks...)...)
+	allTasks = append(allTasks, withTimeoutsAndHooks(builtintasks.VerifyTask(append(allTasks, extraTasks...), config.VerifyTasksConfig(tasksCfgInfo.TasksConfig.VerifyTasks)))...)
+	allTasks = append(allTasks, withTimeoutsAndHooks(builtintasks.UpgradeConfigTask(upgradeConfigTasks))...)
+	allTasks = append(allTasks, withTimeoutsAndHooks(builtintasks.ConfigTask(append(allTasks, extraTasks...)))...)
+	allTasks = append(allTasks, withTimeoutsAndHooks(builtintasks.IDEATask(append(allTasks, extraTasks...)))...)
+	allTasks = append(allTasks, extraTasks...)
 	return allTasks
 }
 
+// recordTaskRun records the run of the task that started at the provided time in the task run history. Runs are only
+// recorded for projects (when the wrapper is specified) and are not recorded for shell completion requests, which are
+// run on every key press. Failing to record the run does not change the outcome of the task, so the error is only
+// printed in debug mode.
+func recordTaskRun(param godellauncher.HistoryParam, limits history.Limits, global godellauncher.GlobalConfig, task godellauncher.Task, start time.Time, taskErr error) {
+	if global.Wrapper == "" || global.Task == "" || global.Task == cobra.ShellCompRequestCmd || global.Task == cobra.ShellCompNoDescRequestCmd || history.Disabled(param) {
+		return
+	}
+	duration := time.Since(start)
+	err := func() error {
+		projectDir, err := filepath.Abs(filepath.Dir(global.Wrapper))
+		if err != nil {
+			return err
+		}
+		historyFile, err := history.FilePath()
+		if err != nil {
+			return err
+		}
+		return history.Append(historyFile, history.Record{
+			Time:           start,
+			Task:           global.Task,
+			Args:           append([]string{}, global.TaskArgs...),
+			ProjectDir:     projectDir,
+			DurationMillis: duration.Milliseconds(),
+			ExitCode:       godellauncher.ExitCode(taskErr),
+			GodelVersion:   godel.Version,
+			Plugin:         task.Source.Plugin,
+		}, limits)
+	}()
+	if err != nil && global.Debug {
+		_, _ = fmt.Fprintln(os.Stderr, "Warning: failed to record task run in history:", errorstringer.StackWithInterleavedMessages(err))
+	}
+}
+
+// finishTrace writes the trace file if gödel was run with the "--trace" flag. Failing to write the trace does not change
+// the outcome of the task, so the error is only printed.
+func finishTrace() {
+	if err := trace.Finish(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "Warning:", err.Error())
+	}
+}
+
 func printErrAndExit(err error, debug bool) {
 	if errStr := err.Error(); errStr != "" {
 		if debug {
@@ -156,5 +332,7 @@ func printErrAndExit(err error, debug bool) {
 		}
 		fmt.Println("Error:", errStr)
 	}
-	os.Exit(1)
+	finishTrace()
+	// exit with the exit code of the task that failed (such as the exit code of a plugin) if one is available
+	os.Exit(godellauncher.ExitCode(err))
 }
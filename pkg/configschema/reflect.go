@@ -0,0 +1,133 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DescriptionFunc returns the description for the provided field of the provided struct type. Returns an empty string
+// if the field has no description.
+type DescriptionFunc func(structType reflect.Type, field reflect.StructField) string
+
+// ForType returns the schema for the YAML representation of the provided type. The schema is derived from the "yaml"
+// struct tags of the type using the same rules as YAML decoding: fields without a tag use the lowercased field name,
+// fields with the tag "-" and unexported fields are omitted and the fields of ",inline" struct fields are included in
+// the parent. Structs do not allow keys that do not correspond to a field.
+func ForType(t reflect.Type) *Schema {
+	return ForTypeWithDescriptions(t, nil)
+}
+
+// ForTypeWithDescriptions returns the schema for the provided type in the same manner as ForType. If descriptionFn is
+// non-nil, it is used to set the descriptions of the properties that correspond to struct fields.
+func ForTypeWithDescriptions(t reflect.Type, descriptionFn DescriptionFunc) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return ForTypeWithDescriptions(t.Elem(), descriptionFn)
+	case reflect.Struct:
+		s := &Schema{
+			Type:                 Types{"object"},
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: BoolSchema(false),
+		}
+		addStructProperties(s, t, descriptionFn)
+		return s
+	case reflect.Map:
+		return &Schema{
+			Type:                 Types{"object"},
+			AdditionalProperties: ForTypeWithDescriptions(t.Elem(), descriptionFn),
+		}
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  Types{"array"},
+			Items: ForTypeWithDescriptions(t.Elem(), descriptionFn),
+		}
+	case reflect.String:
+		return &Schema{
+			Type: Types{"string"},
+		}
+	case reflect.Bool:
+		return &Schema{
+			Type: Types{"boolean"},
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{
+			Type: Types{"integer"},
+		}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{
+			Type: Types{"number"},
+		}
+	default:
+		// interfaces and other types can hold any value
+		return BoolSchema(true)
+	}
+}
+
+func addStructProperties(s *Schema, t reflect.Type, descriptionFn DescriptionFunc) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, skip := yamlFieldName(field)
+		if skip {
+			continue
+		}
+		if inline {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				addStructProperties(s, fieldType, descriptionFn)
+			}
+			continue
+		}
+		fieldSchema := ForTypeWithDescriptions(field.Type, descriptionFn)
+		if descriptionFn != nil {
+			if description := descriptionFn(t, field); description != "" {
+				if fieldSchema.Bool != nil {
+					// boolean schemas cannot have a description: use the equivalent empty schema
+					fieldSchema = &Schema{}
+				}
+				fieldSchema.Description = description
+			}
+		}
+		s.Properties[name] = fieldSchema
+	}
+}
+
+// yamlFieldName returns the YAML key for the provided field, whether the field is inlined and whether the field
+// should be skipped.
+func yamlFieldName(field reflect.StructField) (name string, inline bool, skip bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		// unexported field
+		return "", false, true
+	}
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			return "", true, false
+		}
+	}
+	if parts[0] != "" {
+		return parts[0], false, false
+	}
+	return strings.ToLower(field.Name), false, false
+}
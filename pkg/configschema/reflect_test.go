@@ -0,0 +1,68 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/palantir/godel/v2/pkg/configschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForType(t *testing.T) {
+	type Inner struct {
+		ID      string            `yaml:"id,omitempty"`
+		Weights map[string]int    `yaml:"weights"`
+		Extra   interface{}       `yaml:"extra"`
+		Ignored string            `yaml:"-"`
+		Labels  map[string]string `yaml:",omitempty"`
+	}
+	type Embedded struct {
+		Version string `yaml:"version"`
+	}
+	type Outer struct {
+		Embedded `yaml:",inline"`
+		Items    []Inner `yaml:"items"`
+		Enabled  *bool   `yaml:"enabled"`
+		hidden   string
+	}
+
+	schemaJSON, err := json.Marshal(configschema.ForType(reflect.TypeOf(Outer{})))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "version": {"type": "string"},
+    "enabled": {"type": "boolean"},
+    "items": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "weights": {"type": "object", "additionalProperties": {"type": "integer"}},
+          "extra": true,
+          "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+        },
+        "additionalProperties": false
+      }
+    }
+  },
+  "additionalProperties": false
+}`, string(schemaJSON))
+}
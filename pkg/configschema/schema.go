@@ -0,0 +1,124 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configschema provides support for describing YAML configuration files using JSON Schema and for validating
+// configuration files against such schemas.
+//
+// Only a subset of JSON Schema is supported. The following keywords are used for validation: "type", "properties",
+// "additionalProperties", "required", "items", "enum", "const", "pattern", "minimum", "maximum", "allOf", "anyOf",
+// "oneOf" and "$ref" (only local references of the form "#/definitions/name" or "#/$defs/name"). Other keywords are
+// preserved but ignored.
+package configschema
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// DraftVersion is the JSON Schema draft that is declared by generated schemas.
+const DraftVersion = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema. A Schema with a non-nil Bool is a boolean schema ("true" accepts all values, "false"
+// rejects all values) and all of its other fields are ignored.
+type Schema struct {
+	Bool *bool `json:"-"`
+
+	SchemaVersion        string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// BoolSchema returns a boolean schema with the provided value.
+func BoolSchema(val bool) *Schema {
+	return &Schema{
+		Bool: &val,
+	}
+}
+
+// Parse parses the provided JSON-encoded bytes as a Schema.
+func Parse(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse JSON Schema")
+	}
+	return &schema, nil
+}
+
+// schemaAlias has the same fields as Schema but not its JSON functions.
+type schemaAlias Schema
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.Bool != nil {
+		return json.Marshal(*s.Bool)
+	}
+	return json.Marshal(schemaAlias(s))
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var boolVal bool
+	if err := json.Unmarshal(data, &boolVal); err == nil {
+		*s = Schema{
+			Bool: &boolVal,
+		}
+		return nil
+	}
+	var alias schemaAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*s = Schema(alias)
+	return nil
+}
+
+// Types is the value of the "type" keyword, which may be a single type or a list of types.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errors.Errorf(`"type" must be a string or an array of strings, was %s`, string(data))
+	}
+	*t = multiple
+	return nil
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.yaml.in/yaml/v3"
+)
+
+// ValidationError describes a value in a YAML document that does not conform to a schema.
+type ValidationError struct {
+	// Line and Column are the 1-based position of the value in the document.
+	Line   int
+	Column int
+	// Path is the path to the value in the document ("plugins.plugins[0].locator"). Empty for the root value.
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// Validate validates the provided YAML document against the provided schema and returns the errors for all of the
+// values that do not conform to it, ordered by position. Returns an error if the document is not valid YAML.
+//
+// Validation is performed on the values as they would be decoded into Go types: null values (such as a key with no
+// value) are valid for every schema and every scalar value is valid for the "string" type.
+func Validate(schema *Schema, yamlBytes []byte) ([]ValidationError, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse YAML")
+	}
+	if len(doc.Content) == 0 {
+		// empty document
+		return nil, nil
+	}
+	v := &validator{
+		root: schema,
+	}
+	v.validate(schema, doc.Content[0], "")
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		if v.errs[i].Line != v.errs[j].Line {
+			return v.errs[i].Line < v.errs[j].Line
+		}
+		return v.errs[i].Column < v.errs[j].Column
+	})
+	return v.errs, nil
+}
+
+type validator struct {
+	root *Schema
+	errs []ValidationError
+}
+
+func (v *validator) addErr(n *yaml.Node, path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// matches returns true if the provided node is valid for the provided schema.
+func (v *validator) matches(s *Schema, n *yaml.Node, path string) bool {
+	sub := &validator{
+		root: v.root,
+	}
+	sub.validate(s, n, path)
+	return len(sub.errs) == 0
+}
+
+func (v *validator) validate(s *Schema, n *yaml.Node, path string) {
+	if s == nil {
+		return
+	}
+	if s.Bool != nil {
+		if !*s.Bool {
+			v.addErr(n, path, "value is not allowed")
+		}
+		return
+	}
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+
+	if s.Ref != "" {
+		refSchema, err := v.resolveRef(s.Ref)
+		if err != nil {
+			v.addErr(n, path, "%v", err)
+		} else {
+			v.validate(refSchema, n, path)
+		}
+	}
+
+	if len(s.Type) > 0 && !nodeMatchesTypes(n, s.Type) {
+		v.addErr(n, path, "expected %s, was %s", strings.Join(s.Type, " or "), nodeTypeName(n))
+		return
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(s, n, path)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case yaml.ScalarNode:
+		v.validateScalar(s, n, path)
+	}
+
+	if len(s.Enum) > 0 {
+		matched := false
+		for _, want := range s.Enum {
+			if nodeEquals(n, want) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			var allowed []string
+			for _, want := range s.Enum {
+				wantJSON, _ := json.Marshal(want)
+				allowed = append(allowed, string(wantJSON))
+			}
+			v.addErr(n, path, "value must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+	if s.Const != nil && !nodeEquals(n, s.Const) {
+		wantJSON, _ := json.Marshal(s.Const)
+		v.addErr(n, path, "value must be %s", string(wantJSON))
+	}
+
+	for _, sub := range s.AllOf {
+		v.validate(sub, n, path)
+	}
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if v.matches(sub, n, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addErr(n, path, "value does not match any of the allowed schemas")
+		}
+	}
+	if len(s.OneOf) > 0 {
+		numMatched := 0
+		for _, sub := range s.OneOf {
+			if v.matches(sub, n, path) {
+				numMatched++
+			}
+		}
+		if numMatched != 1 {
+			v.addErr(n, path, "value must match exactly one of the allowed schemas, but matched %d", numMatched)
+		}
+	}
+}
+
+func (v *validator) validateMapping(s *Schema, n *yaml.Node, path string) {
+	seenKeys := make(map[string]struct{})
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keyNode, valNode := n.Content[i], n.Content[i+1]
+		key := keyNode.Value
+		seenKeys[key] = struct{}{}
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		if propSchema, ok := s.Properties[key]; ok {
+			v.validate(propSchema, valNode, keyPath)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.Bool != nil && !*s.AdditionalProperties.Bool {
+			v.addErr(keyNode, path, "unknown key %q", key)
+			continue
+		}
+		v.validate(s.AdditionalProperties, valNode, keyPath)
+	}
+	for _, required := range s.Required {
+		if _, ok := seenKeys[required]; !ok {
+			v.addErr(n, path, "missing required key %q", required)
+		}
+	}
+}
+
+func (v *validator) validateScalar(s *Schema, n *yaml.Node, path string) {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			v.addErr(n, path, "schema specifies invalid pattern %q: %v", s.Pattern, err)
+		} else if !re.MatchString(n.Value) {
+			v.addErr(n, path, "value %q does not match pattern %q", n.Value, s.Pattern)
+		}
+	}
+	if s.Minimum == nil && s.Maximum == nil {
+		return
+	}
+	if n.Tag != "!!int" && n.Tag != "!!float" {
+		return
+	}
+	var num float64
+	if err := n.Decode(&num); err != nil {
+		return
+	}
+	if s.Minimum != nil && num < *s.Minimum {
+		v.addErr(n, path, "value must be >= %s", strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+	}
+	if s.Maximum != nil && num > *s.Maximum {
+		v.addErr(n, path, "value must be <= %s", strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+	}
+}
+
+// resolveRef resolves the provided local reference against the root schema.
+func (v *validator) resolveRef(ref string) (*Schema, error) {
+	var defs map[string]*Schema
+	var name string
+	switch {
+	case strings.HasPrefix(ref, "#/definitions/"):
+		defs, name = v.root.Definitions, strings.TrimPrefix(ref, "#/definitions/")
+	case strings.HasPrefix(ref, "#/$defs/"):
+		defs, name = v.root.Defs, strings.TrimPrefix(ref, "#/$defs/")
+	default:
+		return nil, errors.Errorf("schema specifies unsupported reference %q", ref)
+	}
+	refSchema, ok := defs[name]
+	if !ok {
+		return nil, errors.Errorf("schema specifies reference %q that does not exist", ref)
+	}
+	return refSchema, nil
+}
+
+func nodeMatchesTypes(n *yaml.Node, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "object":
+			if n.Kind == yaml.MappingNode {
+				return true
+			}
+		case "array":
+			if n.Kind == yaml.SequenceNode {
+				return true
+			}
+		case "string":
+			if n.Kind == yaml.ScalarNode {
+				return true
+			}
+		case "integer":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!int" {
+				return true
+			}
+		case "number":
+			if n.Kind == yaml.ScalarNode && (n.Tag == "!!int" || n.Tag == "!!float") {
+				return true
+			}
+		case "boolean":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!bool" {
+				return true
+			}
+		case "null":
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func nodeTypeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.Tag {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// nodeEquals returns true if the value represented by the provided node is equal to the provided JSON value. Values
+// are compared using their JSON representations.
+func nodeEquals(n *yaml.Node, jsonVal interface{}) bool {
+	var nodeVal interface{}
+	if err := n.Decode(&nodeVal); err != nil {
+		return false
+	}
+	nodeJSON, err := json.Marshal(nodeVal)
+	if err != nil {
+		return false
+	}
+	wantJSON, err := json.Marshal(jsonVal)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(nodeJSON, wantJSON)
+}
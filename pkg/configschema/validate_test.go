@@ -0,0 +1,147 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configschema_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/pkg/configschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]+$"},
+    "count": {"type": "integer", "minimum": 1, "maximum": 10},
+    "mode": {"enum": ["fast", "slow"]},
+    "items": {"type": "array", "items": {"$ref": "#/definitions/item"}},
+    "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+  },
+  "additionalProperties": false,
+  "definitions": {
+    "item": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "enabled": {"type": "boolean"}
+      },
+      "required": ["id"],
+      "additionalProperties": false
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := configschema.Parse([]byte(testSchema))
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{
+			"empty document is valid",
+			``,
+			nil,
+		},
+		{
+			"valid document",
+			`name: foo
+count: 3
+mode: fast
+items:
+  - id: a
+    enabled: true
+labels:
+  key: value
+`,
+			nil,
+		},
+		{
+			"null values are valid",
+			`name:
+items:
+`,
+			nil,
+		},
+		{
+			"unknown keys",
+			`name: foo
+itemz:
+  - id: a
+items:
+  - id: b
+    enabeld: true
+`,
+			[]string{
+				`2:1: unknown key "itemz"`,
+				`6:5: items[0]: unknown key "enabeld"`,
+			},
+		},
+		{
+			"type, pattern, range and enum violations",
+			`name: Foo
+count: 11
+mode: medium
+labels: [a]
+`,
+			[]string{
+				`1:7: name: value "Foo" does not match pattern "^[a-z]+$"`,
+				`2:8: count: value must be <= 10`,
+				`3:7: mode: value must be one of "fast", "slow"`,
+				`4:9: labels: expected object, was array`,
+			},
+		},
+		{
+			"missing required key",
+			`items:
+  - enabled: false
+`,
+			[]string{
+				`2:5: items[0]: missing required key "id"`,
+			},
+		},
+	} {
+		errs, err := configschema.Validate(schema, []byte(tc.yaml))
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+
+		var got []string
+		for _, validationErr := range errs {
+			got = append(got, validationErr.Error())
+		}
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestValidateInvalidYAML(t *testing.T) {
+	_, err := configschema.Validate(configschema.BoolSchema(true), []byte("foo: [bar"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse YAML")
+}
+
+func TestSchemaJSONRoundTrip(t *testing.T) {
+	schema, err := configschema.Parse([]byte(`{"type":["string","null"],"additionalProperties":false,"items":true}`))
+	require.NoError(t, err)
+	assert.Equal(t, configschema.Types{"string", "null"}, schema.Type)
+	require.NotNil(t, schema.AdditionalProperties.Bool)
+	assert.False(t, *schema.AdditionalProperties.Bool)
+
+	out, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":["string","null"],"additionalProperties":false,"items":true}`, string(out))
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package textdiff computes line-based differences between text content.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextLines is the number of unchanged lines that are included before and after each change in a unified diff.
+const ContextLines = 3
+
+// Unified returns the unified diff that transforms the "from" content into the "to" content using the provided names
+// as the names of the files in the diff header. Returns an empty string if the content is equal.
+func Unified(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	out := &strings.Builder{}
+	_, _ = fmt.Fprintf(out, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks(ops) {
+		writeHunk(out, ops[h.start:h.end])
+	}
+	return out.String()
+}
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+// op is a single line of a diff. fromLine and toLine are the number of lines of the "from" and "to" content that
+// precede the line.
+type op struct {
+	kind     opKind
+	text     string
+	fromLine int
+	toLine   int
+}
+
+// splitLines splits the provided content into lines. Every line except for possibly the last one ends in a newline.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the operations that transform the "from" lines into the "to" lines based on their longest common
+// subsequence.
+func diffLines(from, to []string) []op {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			ops = append(ops, op{kind: opEqual, text: from[i], fromLine: i, toLine: j})
+			i++
+			j++
+		case j < len(to) && (i == len(from) || lcs[i][j+1] >= lcs[i+1][j]):
+			ops = append(ops, op{kind: opInsert, text: to[j], fromLine: i, toLine: j})
+			j++
+		default:
+			ops = append(ops, op{kind: opDelete, text: from[i], fromLine: i, toLine: j})
+			i++
+		}
+	}
+	// list deletions before insertions within every run of changes
+	for start := 0; start < len(ops); {
+		if ops[start].kind == opEqual {
+			start++
+			continue
+		}
+		end := start
+		for end < len(ops) && ops[end].kind != opEqual {
+			end++
+		}
+		var deletes, inserts []op
+		for _, currOp := range ops[start:end] {
+			if currOp.kind == opDelete {
+				deletes = append(deletes, currOp)
+			} else {
+				inserts = append(inserts, currOp)
+			}
+		}
+		copy(ops[start:], append(deletes, inserts...))
+		fromLine, toLine := ops[start].fromLine, ops[start].toLine
+		for k := start; k < end; k++ {
+			ops[k].fromLine, ops[k].toLine = fromLine, toLine
+			if ops[k].kind == opDelete {
+				fromLine++
+			} else {
+				toLine++
+			}
+		}
+		start = end
+	}
+	return ops
+}
+
+type hunk struct {
+	start, end int
+}
+
+// hunks returns the ranges of the provided operations that should be printed as hunks: every change along with up to
+// ContextLines unchanged lines before and after it, where changes whose context overlaps are combined.
+func hunks(ops []op) []hunk {
+	var out []hunk
+	for i, currOp := range ops {
+		if currOp.kind == opEqual {
+			continue
+		}
+		start := max(0, i-ContextLines)
+		end := min(len(ops), i+ContextLines+1)
+		if len(out) > 0 && start <= out[len(out)-1].end {
+			out[len(out)-1].end = end
+			continue
+		}
+		out = append(out, hunk{start: start, end: end})
+	}
+	return out
+}
+
+func writeHunk(out *strings.Builder, ops []op) {
+	fromCount, toCount := 0, 0
+	for _, currOp := range ops {
+		if currOp.kind != opInsert {
+			fromCount++
+		}
+		if currOp.kind != opDelete {
+			toCount++
+		}
+	}
+	_, _ = fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(ops[0].fromLine, fromCount), hunkRange(ops[0].toLine, toCount))
+	for _, currOp := range ops {
+		out.WriteByte(byte(currOp.kind))
+		out.WriteString(currOp.text)
+		if !strings.HasSuffix(currOp.text, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange returns the range of a hunk header for a hunk that starts after the provided number of lines and contains
+// the provided number of lines.
+func hunkRange(precedingLines, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", precedingLines)
+	case 1:
+		return fmt.Sprintf("%d", precedingLines+1)
+	default:
+		return fmt.Sprintf("%d,%d", precedingLines+1, count)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textdiff_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/pkg/textdiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified(t *testing.T) {
+	for i, tc := range []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			"equal content",
+			"a\nb\n",
+			"a\nb\n",
+			"",
+		},
+		{
+			"changed line",
+			"a\nb\nc\n",
+			"a\nB\nc\n",
+			`--- from
++++ to
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n12\n",
+			`--- from
++++ to
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -8,5 +9,4 @@
+ 8
+ 9
+ 10
+-11
+ 12
+`,
+		},
+		{
+			"empty from",
+			"",
+			"a\nb\n",
+			`--- from
++++ to
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		},
+		{
+			"missing trailing newline",
+			"a\nb",
+			"a\nb\n",
+			`--- from
++++ to
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`,
+		},
+	} {
+		got := textdiff.Unified("from", "to", tc.from, tc.to)
+		assert.Equal(t, tc.want, got, "Case %d: %s", i, tc.name)
+	}
+}
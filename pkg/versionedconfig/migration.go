@@ -0,0 +1,193 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionedconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/palantir/godel/v2/pkg/yamlnode"
+	"github.com/pkg/errors"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// VersionSpec describes a version of a configuration: the value of its "version" key, the Go type that its YAML
+// representation is unmarshaled into and, for versions other than the latest one, the function that migrates it to
+// the next version. Create values using Version or VersionWithMigration.
+type VersionSpec struct {
+	version    string
+	cfgType    reflect.Type
+	unmarshal  func(cfgBytes []byte) (interface{}, error)
+	migrate    func(cfg interface{}) (interface{}, error)
+	migrateOut reflect.Type
+}
+
+// Version returns the specification for a configuration version whose YAML representation is unmarshaled into values
+// of type T. Used for the latest version of a configuration, which does not have a migration.
+func Version[T any](version string) VersionSpec {
+	return VersionSpec{
+		version: version,
+		cfgType: reflect.TypeFor[T](),
+		unmarshal: func(cfgBytes []byte) (interface{}, error) {
+			var cfg T
+			if err := yaml.Unmarshal(cfgBytes, &cfg); err != nil {
+				return nil, err
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// VersionWithMigration returns the specification for a configuration version whose YAML representation is unmarshaled
+// into values of type T and that is migrated to the next version using the provided function. Next must be the type
+// of the next registered version.
+func VersionWithMigration[T, Next any](version string, migrateFn func(cfg T) (Next, error)) VersionSpec {
+	spec := Version[T](version)
+	spec.migrate = func(cfg interface{}) (interface{}, error) {
+		return migrateFn(cfg.(T))
+	}
+	spec.migrateOut = reflect.TypeFor[Next]()
+	return spec
+}
+
+// Migrator upgrades versioned configuration to its latest version by applying the migrations between every
+// registered version in sequence.
+type Migrator struct {
+	name     string
+	versions []VersionSpec
+}
+
+// NewMigrator returns a Migrator for the configuration with the provided name (used in error messages) that supports
+// the provided versions. Versions must be provided from oldest to newest: every version except for the last one must
+// specify a migration to the type of the version that follows it, and the last version must not specify a migration.
+// Configuration that does not specify a version is treated as the oldest version.
+func NewMigrator(name string, versions ...VersionSpec) (*Migrator, error) {
+	if len(versions) == 0 {
+		return nil, errors.Errorf("%s: at least one configuration version must be provided", name)
+	}
+	seen := make(map[string]struct{})
+	for _, version := range versions {
+		if _, ok := seen[version.version]; ok {
+			return nil, errors.Errorf("%s: configuration version %q is provided more than once", name, version.version)
+		}
+		seen[version.version] = struct{}{}
+	}
+	for i, version := range versions {
+		if i == len(versions)-1 {
+			if version.migrate != nil {
+				return nil, errors.Errorf("%s: latest configuration version %q must not specify a migration", name, version.version)
+			}
+			continue
+		}
+		if version.migrate == nil {
+			return nil, errors.Errorf("%s: configuration version %q must specify a migration to version %q", name, version.version, versions[i+1].version)
+		}
+		if next := versions[i+1]; version.migrateOut != next.cfgType {
+			return nil, errors.Errorf("%s: migration for configuration version %q returns %v, but the type of version %q is %v", name, version.version, version.migrateOut, next.version, next.cfgType)
+		}
+	}
+	return &Migrator{
+		name:     name,
+		versions: versions,
+	}, nil
+}
+
+// LatestVersion returns the latest configuration version supported by the migrator.
+func (m *Migrator) LatestVersion() string {
+	return m.versions[len(m.versions)-1].version
+}
+
+// Upgrade upgrades the provided configuration to the latest version. The version of the input is determined using its
+// "version" key and the migrations from that version to the latest version are applied in order. The upgraded
+// configuration has its "version" key set to the latest version and preserves the comments and key order of the input
+// where possible. If the input is already the latest version, it is returned unmodified. Returns an error if the
+// version of the input is not supported, including if it is newer than the latest version. Legacy configuration
+// ("legacy-config: true") is not supported and must be converted to a supported version before it is upgraded.
+func (m *Migrator) Upgrade(cfgBytes []byte) ([]byte, error) {
+	version, err := ConfigVersion(cfgBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to determine version of %s configuration", m.name)
+	}
+	startIdx, err := m.versionIndex(version)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := m.versions[startIdx].unmarshal(cfgBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s configuration as version %q", m.name, m.versions[startIdx].version)
+	}
+	if startIdx == len(m.versions)-1 {
+		return cfgBytes, nil
+	}
+	for i := startIdx; i < len(m.versions)-1; i++ {
+		cfg, err = m.versions[i].migrate(cfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to migrate %s configuration from version %q to version %q", m.name, m.versions[i].version, m.versions[i+1].version)
+		}
+	}
+
+	upgradedBytes, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal upgraded %s configuration", m.name)
+	}
+	upgradedBytes, err = yamlnode.Update(upgradedBytes, func(doc *yamlv3.Node) error {
+		yamlnode.InsertMappingValue(doc.Content[0], 0, "version", &yamlv3.Node{
+			Kind:  yamlv3.ScalarNode,
+			Value: m.LatestVersion(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to set version of upgraded %s configuration", m.name)
+	}
+	return yamlnode.PreserveFormatting(cfgBytes, upgradedBytes)
+}
+
+// versionIndex returns the index of the provided version. The empty version corresponds to the oldest version.
+func (m *Migrator) versionIndex(version string) (int, error) {
+	if version == "" {
+		return 0, nil
+	}
+	for i, currVersion := range m.versions {
+		if currVersion.version == version {
+			return i, nil
+		}
+	}
+	if isNewerVersion(version, m.LatestVersion()) {
+		return -1, errors.Errorf("%s configuration has version %q, which is newer than the latest version supported by this version of %s (%q): upgrade %s to use this configuration", m.name, version, m.name, m.LatestVersion(), m.name)
+	}
+	var supported []string
+	for _, currVersion := range m.versions {
+		supported = append(supported, fmt.Sprintf("%q", currVersion.version))
+	}
+	return -1, errors.Errorf("%s configuration has unsupported version %q: supported versions are %s", m.name, version, strings.Join(supported, ", "))
+}
+
+// isNewerVersion returns true if both versions are integers and the first version is greater than the second version.
+func isNewerVersion(version, latestVersion string) bool {
+	versionNum, err := strconv.Atoi(version)
+	if err != nil {
+		return false
+	}
+	latestNum, err := strconv.Atoi(latestVersion)
+	if err != nil {
+		return false
+	}
+	return versionNum > latestNum
+}
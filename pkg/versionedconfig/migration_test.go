@@ -0,0 +1,236 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionedconfig_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/palantir/godel/v2/pkg/versionedconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configV0 struct {
+	Name string `yaml:"name"`
+}
+
+type configV1 struct {
+	versionedconfig.ConfigWithVersion `yaml:",inline"`
+	Names                             []string `yaml:"names"`
+}
+
+type configV2 struct {
+	versionedconfig.ConfigWithVersion `yaml:",inline"`
+	Products                          map[string]productV2 `yaml:"products"`
+}
+
+type productV2 struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+func newTestMigrator(t *testing.T) *versionedconfig.Migrator {
+	migrator, err := versionedconfig.NewMigrator("test-plugin",
+		versionedconfig.VersionWithMigration("0", func(cfg configV0) (configV1, error) {
+			return configV1{
+				Names: []string{cfg.Name},
+			}, nil
+		}),
+		versionedconfig.VersionWithMigration("1", func(cfg configV1) (configV2, error) {
+			products := make(map[string]productV2)
+			for _, name := range cfg.Names {
+				products[name] = productV2{
+					Enabled: true,
+				}
+			}
+			return configV2{
+				Products: products,
+			}, nil
+		}),
+		versionedconfig.Version[configV2]("2"),
+	)
+	require.NoError(t, err)
+	return migrator
+}
+
+func TestMigratorUpgrade(t *testing.T) {
+	migrator := newTestMigrator(t)
+	assert.Equal(t, "2", migrator.LatestVersion())
+
+	for i, tc := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"configuration without version is migrated from oldest version",
+			`name: foo
+`,
+			`version: 2
+products:
+  foo:
+    enabled: true
+`,
+		},
+		{
+			"migrations are chained",
+			`version: 1
+# products
+names:
+  - foo
+  - bar
+`,
+			`version: 2
+products:
+  bar:
+    enabled: true
+  foo:
+    enabled: true
+`,
+		},
+		{
+			"comments are preserved for keys that are not migrated",
+			`version: 2
+# products
+products:
+  # foo product
+  foo:
+    enabled: true
+`,
+			`version: 2
+# products
+products:
+  # foo product
+  foo:
+    enabled: true
+`,
+		},
+	} {
+		got, err := migrator.Upgrade([]byte(tc.in))
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, string(got), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestMigratorUpgradePreservesComments(t *testing.T) {
+	migrator, err := versionedconfig.NewMigrator("test-plugin",
+		versionedconfig.VersionWithMigration("1", func(cfg configV1) (configV1, error) {
+			cfg.Names = append(cfg.Names, "added")
+			return cfg, nil
+		}),
+		versionedconfig.Version[configV1]("2"),
+	)
+	require.NoError(t, err)
+
+	got, err := migrator.Upgrade([]byte(`# header
+version: 1
+# names comment
+names:
+  - "foo" # foo comment
+`))
+	require.NoError(t, err)
+	assert.Equal(t, `# header
+version: 2
+# names comment
+names:
+  - "foo" # foo comment
+  - added
+`, string(got))
+}
+
+func TestMigratorUpgradeErrors(t *testing.T) {
+	migrator := newTestMigrator(t)
+	for i, tc := range []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{
+			"future version",
+			`version: 3
+`,
+			`test-plugin configuration has version "3", which is newer than the latest version supported by this version of test-plugin ("2"): upgrade test-plugin to use this configuration`,
+		},
+		{
+			"unknown version",
+			`version: beta
+`,
+			`test-plugin configuration has unsupported version "beta": supported versions are "0", "1", "2"`,
+		},
+		{
+			"invalid configuration",
+			`version: 1
+names: foo
+`,
+			`failed to unmarshal test-plugin configuration as version "1"`,
+		},
+	} {
+		_, err := migrator.Upgrade([]byte(tc.in))
+		require.Error(t, err, "Case %d: %s", i, tc.name)
+		assert.True(t, strings.HasPrefix(err.Error(), tc.wantErr), "Case %d: %s\nerror: %v", i, tc.name, err)
+	}
+}
+
+func TestNewMigratorErrors(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		versions []versionedconfig.VersionSpec
+		wantErr  string
+	}{
+		{
+			"no versions",
+			nil,
+			"test-plugin: at least one configuration version must be provided",
+		},
+		{
+			"duplicate versions",
+			[]versionedconfig.VersionSpec{
+				versionedconfig.Version[configV1]("1"),
+				versionedconfig.Version[configV1]("1"),
+			},
+			`test-plugin: configuration version "1" is provided more than once`,
+		},
+		{
+			"missing migration",
+			[]versionedconfig.VersionSpec{
+				versionedconfig.Version[configV1]("1"),
+				versionedconfig.Version[configV2]("2"),
+			},
+			`test-plugin: configuration version "1" must specify a migration to version "2"`,
+		},
+		{
+			"migration to wrong type",
+			[]versionedconfig.VersionSpec{
+				versionedconfig.VersionWithMigration("0", func(cfg configV0) (configV1, error) {
+					return configV1{}, nil
+				}),
+				versionedconfig.Version[configV2]("2"),
+			},
+			`test-plugin: migration for configuration version "0" returns versionedconfig_test.configV1, but the type of version "2" is versionedconfig_test.configV2`,
+		},
+		{
+			"latest version with migration",
+			[]versionedconfig.VersionSpec{
+				versionedconfig.VersionWithMigration("0", func(cfg configV0) (configV1, error) {
+					return configV1{}, nil
+				}),
+			},
+			`test-plugin: latest configuration version "0" must not specify a migration`,
+		},
+	} {
+		_, err := versionedconfig.NewMigrator("test-plugin", tc.versions...)
+		assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yamlnode provides functions for reading and modifying YAML documents using their node representation so
+// that comments, key order and the style of scalar values are preserved when the document is written.
+package yamlnode
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.yaml.in/yaml/v3"
+)
+
+// Parse parses the provided YAML and returns its document node. The returned document always has a single content
+// node: if the input is empty or only contains comments, the content node is an empty mapping.
+func Parse(in []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal YAML")
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{
+			NewMapping(),
+		}
+	}
+	return &doc, nil
+}
+
+// Marshal returns the YAML representation of the provided node using an indentation of 2 spaces.
+func Marshal(n *yaml.Node) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal YAML")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal YAML")
+	}
+	return buf.Bytes(), nil
+}
+
+// Update parses the provided YAML, calls the provided function with its document node and returns the YAML
+// representation of the document after the function returns. If the content of the document after the update is
+// equal to the content of the input, the input is returned unmodified so that its formatting is fully preserved.
+func Update(in []byte, updateFn func(doc *yaml.Node) error) ([]byte, error) {
+	doc, err := Parse(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateFn(doc); err != nil {
+		return nil, err
+	}
+	out, err := Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	if equal, err := contentEqual(in, out); err != nil {
+		return nil, err
+	} else if equal {
+		return in, nil
+	}
+	return out, nil
+}
+
+// NewMapping returns a new empty block mapping node.
+func NewMapping() *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+	}
+}
+
+// NewSequence returns a new empty block sequence node.
+func NewSequence() *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.SequenceNode,
+		Tag:  "!!seq",
+	}
+}
+
+// NewString returns a new scalar node with the provided string value.
+func NewString(val string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Value: val,
+	}
+}
+
+// MappingValue returns the value for the provided key in the provided mapping node. Returns nil if the node is not a
+// mapping or does not contain the key.
+func MappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if idx := mappingKeyIndex(mapping, key); idx != -1 {
+		return mapping.Content[idx+1]
+	}
+	return nil
+}
+
+// SetMappingValue sets the value for the provided key in the provided mapping node. If the key already exists, its
+// value is replaced in place (the comments of the key are preserved). Otherwise, the key is added as the last key of
+// the mapping.
+func SetMappingValue(mapping *yaml.Node, key string, val *yaml.Node) {
+	if idx := mappingKeyIndex(mapping, key); idx != -1 {
+		mapping.Content[idx+1] = val
+		return
+	}
+	mapping.Content = append(mapping.Content, NewString(key), val)
+}
+
+// InsertMappingValue inserts the provided key and value into the provided mapping node so that it is the key at the
+// provided index. If the mapping already contains the key, the existing key and value are removed first.
+func InsertMappingValue(mapping *yaml.Node, index int, key string, val *yaml.Node) {
+	DeleteMappingValue(mapping, key)
+	pos := 2 * index
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(mapping.Content) {
+		pos = len(mapping.Content)
+	}
+	content := make([]*yaml.Node, 0, len(mapping.Content)+2)
+	content = append(content, mapping.Content[:pos]...)
+	content = append(content, NewString(key), val)
+	content = append(content, mapping.Content[pos:]...)
+	mapping.Content = content
+}
+
+// DeleteMappingValue removes the provided key and its value from the provided mapping node. Returns true if the key
+// was removed.
+func DeleteMappingValue(mapping *yaml.Node, key string) bool {
+	idx := mappingKeyIndex(mapping, key)
+	if idx == -1 {
+		return false
+	}
+	mapping.Content = append(mapping.Content[:idx], mapping.Content[idx+2:]...)
+	return true
+}
+
+func mappingKeyIndex(mapping *yaml.Node, key string) int {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// PreserveFormatting returns the YAML representation of the upgraded document with the comments, key order and
+// scalar styles of the original document applied to it. It is intended to be used by configuration upgraders that
+// upgrade configuration by unmarshaling it into Go types and marshaling the result: values that exist in both
+// documents keep their comments and style, keys that exist in both documents keep their original order and keys that
+// only exist in the upgraded document keep their position relative to the keys that precede them. If the content of
+// the upgraded document is equal to the content of the original document, the original document is returned
+// unmodified.
+func PreserveFormatting(orig, upgraded []byte) ([]byte, error) {
+	if equal, err := contentEqual(orig, upgraded); err != nil {
+		return nil, err
+	} else if equal {
+		return orig, nil
+	}
+	origDoc, err := Parse(orig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse original YAML")
+	}
+	upgradedDoc, err := Parse(upgraded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse upgraded YAML")
+	}
+	applyFormatting(origDoc, upgradedDoc)
+	return Marshal(upgradedDoc)
+}
+
+func applyFormatting(orig, upgraded *yaml.Node) {
+	copyComments(orig, upgraded)
+	if orig.Kind != upgraded.Kind {
+		return
+	}
+	switch upgraded.Kind {
+	case yaml.DocumentNode:
+		if len(orig.Content) > 0 && len(upgraded.Content) > 0 {
+			applyFormatting(orig.Content[0], upgraded.Content[0])
+		}
+	case yaml.MappingNode:
+		applyMappingFormatting(orig, upgraded)
+	case yaml.SequenceNode:
+		upgraded.Style = orig.Style
+		for i, item := range upgraded.Content {
+			if origItem := matchingSequenceItem(orig, i, item); origItem != nil {
+				applyFormatting(origItem, item)
+			}
+		}
+	case yaml.ScalarNode:
+		if orig.Value == upgraded.Value && orig.ShortTag() == upgraded.ShortTag() {
+			upgraded.Style = orig.Style
+		}
+	}
+}
+
+func applyMappingFormatting(orig, upgraded *yaml.Node) {
+	upgraded.Style = orig.Style
+
+	type keyValue struct {
+		key, val *yaml.Node
+		rank     int
+	}
+	var pairs []keyValue
+	prevRank := -1
+	for i := 0; i+1 < len(upgraded.Content); i += 2 {
+		key, val := upgraded.Content[i], upgraded.Content[i+1]
+		// keys in the original document are ranked by their content index, which is always even. New keys are ranked
+		// directly after the key that precedes them using the odd rank after it.
+		rank := prevRank
+		if origIdx := mappingKeyIndex(orig, key.Value); origIdx != -1 {
+			copyComments(orig.Content[origIdx], key)
+			applyFormatting(orig.Content[origIdx+1], val)
+			rank = origIdx
+		} else if rank%2 == 0 {
+			rank++
+		}
+		pairs = append(pairs, keyValue{
+			key:  key,
+			val:  val,
+			rank: rank,
+		})
+		prevRank = rank
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].rank < pairs[j].rank
+	})
+	upgraded.Content = upgraded.Content[:0]
+	for _, pair := range pairs {
+		upgraded.Content = append(upgraded.Content, pair.key, pair.val)
+	}
+}
+
+// matchingSequenceItem returns the item in the provided original sequence that corresponds to the provided item of the
+// upgraded sequence. Scalars are matched by value and other nodes are matched by position. Returns nil if there is no
+// corresponding item.
+func matchingSequenceItem(origSeq *yaml.Node, idx int, item *yaml.Node) *yaml.Node {
+	if item.Kind != yaml.ScalarNode {
+		if idx < len(origSeq.Content) && origSeq.Content[idx].Kind == item.Kind {
+			return origSeq.Content[idx]
+		}
+		return nil
+	}
+	for _, origItem := range origSeq.Content {
+		if origItem.Kind == yaml.ScalarNode && origItem.Value == item.Value {
+			return origItem
+		}
+	}
+	return nil
+}
+
+// copyComments copies the comments of the original node to the upgraded node. Comments that are already set on the
+// upgraded node are not overwritten.
+func copyComments(orig, upgraded *yaml.Node) {
+	if upgraded.HeadComment == "" {
+		upgraded.HeadComment = orig.HeadComment
+	}
+	if upgraded.LineComment == "" {
+		upgraded.LineComment = orig.LineComment
+	}
+	if upgraded.FootComment == "" {
+		upgraded.FootComment = orig.FootComment
+	}
+}
+
+// contentEqual returns true if the provided YAML documents represent the same value.
+func contentEqual(a, b []byte) (bool, error) {
+	var aVal, bVal interface{}
+	if err := yaml.Unmarshal(a, &aVal); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal YAML")
+	}
+	if err := yaml.Unmarshal(b, &bVal); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal YAML")
+	}
+	return reflect.DeepEqual(aVal, bVal), nil
+}
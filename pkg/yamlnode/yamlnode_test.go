@@ -0,0 +1,182 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlnode_test
+
+import (
+	"testing"
+
+	"github.com/palantir/godel/v2/pkg/yamlnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestUpdate(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		in       string
+		updateFn func(doc *yaml.Node) error
+		want     string
+	}{
+		{
+			"unchanged content returns input",
+			`# comment
+
+key:   "value"   # line comment
+`,
+			func(doc *yaml.Node) error {
+				yamlnode.SetMappingValue(doc.Content[0], "key", yamlnode.NewString("value"))
+				return nil
+			},
+			`# comment
+
+key:   "value"   # line comment
+`,
+		},
+		{
+			"comments and key order are preserved",
+			`# head comment
+version: 1
+# comment for b
+b: "b-value" # line comment for b
+a:
+  # nested comment
+  - one
+  - two
+`,
+			func(doc *yaml.Node) error {
+				root := doc.Content[0]
+				yamlnode.SetMappingValue(root, "version", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "2"})
+				seq := yamlnode.MappingValue(root, "a")
+				seq.Content = append(seq.Content, yamlnode.NewString("three"))
+				yamlnode.SetMappingValue(root, "c", yamlnode.NewString("c-value"))
+				return nil
+			},
+			`# head comment
+version: 2
+# comment for b
+b: "b-value" # line comment for b
+a:
+  # nested comment
+  - one
+  - two
+  - three
+c: c-value
+`,
+		},
+		{
+			"keys are inserted and deleted",
+			`a: a-value
+# comment for b
+b: b-value
+c: c-value
+`,
+			func(doc *yaml.Node) error {
+				root := doc.Content[0]
+				yamlnode.InsertMappingValue(root, 0, "first", yamlnode.NewString("first-value"))
+				yamlnode.DeleteMappingValue(root, "c")
+				return nil
+			},
+			`first: first-value
+a: a-value
+# comment for b
+b: b-value
+`,
+		},
+		{
+			"empty input",
+			``,
+			func(doc *yaml.Node) error {
+				yamlnode.SetMappingValue(doc.Content[0], "key", yamlnode.NewString("value"))
+				return nil
+			},
+			`key: value
+`,
+		},
+	} {
+		got, err := yamlnode.Update([]byte(tc.in), tc.updateFn)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, string(got), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestPreserveFormatting(t *testing.T) {
+	for i, tc := range []struct {
+		name     string
+		orig     string
+		upgraded string
+		want     string
+	}{
+		{
+			"equal content returns original",
+			`# comment
+b: 'b'
+a: [1, 2]
+`,
+			`a:
+- 1
+- 2
+b: b
+`,
+			`# comment
+b: 'b'
+a: [1, 2]
+`,
+		},
+		{
+			"comments, order and styles are applied to upgraded content",
+			`# head comment
+legacy: true
+# tasks comment
+tasks:
+  # comment for foo
+  foo:
+    enabled: "true" # line comment
+    args:
+      - "--bar" # bar comment
+  zzz:
+    enabled: false
+`,
+			`tasks:
+  foo:
+    args:
+    - --bar
+    - --baz
+    enabled: "true"
+    timeout: 10
+  zzz:
+    enabled: false
+version: 1
+`,
+			`# tasks comment
+tasks:
+  # comment for foo
+  foo:
+    enabled: "true" # line comment
+    timeout: 10
+    args:
+      - "--bar" # bar comment
+      - --baz
+  zzz:
+    enabled: false
+version: 1
+`,
+		},
+	} {
+		got, err := yamlnode.PreserveFormatting([]byte(tc.orig), []byte(tc.upgraded))
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, string(got), "Case %d: %s", i, tc.name)
+	}
+}